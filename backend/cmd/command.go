@@ -0,0 +1,41 @@
+package main
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/usecase"
+	"flag"
+	"log"
+	"os"
+)
+
+// runCommand runs a command-line subcommand and exits on failure.
+func runCommand(authUsecase usecase.AuthUsecase, name string, args []string) {
+	switch name {
+	case "bootstrap":
+		bootstrap(authUsecase, args)
+	default:
+		log.Fatalf("Unknown command %q (available: bootstrap)", name)
+	}
+}
+
+// bootstrap creates an organization and its first owner, which is how the
+// first account is made while self-registration is closed:
+//
+//	go run ./cmd bootstrap -org "Kost Melati" -name Admin -email admin@kos.com
+//
+// The password is read from -password or the BOOTSTRAP_PASSWORD variable.
+func bootstrap(authUsecase usecase.AuthUsecase, args []string) {
+	flags := flag.NewFlagSet("bootstrap", flag.ExitOnError)
+	organizationName := flags.String("org", "", "organization name (defaults to the owner's name)")
+	name := flags.String("name", "", "owner name")
+	email := flags.String("email", "", "owner email")
+	password := flags.String("password", os.Getenv("BOOTSTRAP_PASSWORD"), "owner password")
+	flags.Parse(args)
+
+	organization := &entity.Organization{Name: *organizationName}
+	user := &entity.User{Name: *name, Email: *email}
+	if err := authUsecase.Bootstrap(organization, user, *password); err != nil {
+		log.Fatal("Failed to bootstrap:", err)
+	}
+	log.Printf("Created organization %q (id %d) with owner %s", organization.Name, organization.ID, user.Email)
+}
@@ -5,12 +5,16 @@ import (
 	"ezkost/internal/delivery/http"
 	"ezkost/internal/delivery/http/handler"
 	"ezkost/internal/delivery/http/middleware"
+	"ezkost/internal/delivery/scheduler"
+	"ezkost/internal/domain/entity"
 	"ezkost/internal/repository"
 	"ezkost/internal/usecase"
 	"ezkost/package/database"
+	"ezkost/package/mail"
+	"ezkost/package/pdf"
+	"ezkost/package/ratelimit"
 	"log"
-
-	"github.com/gin-gonic/gin"
+	"os"
 )
 
 func main() {
@@ -18,42 +22,153 @@ func main() {
 	cfg := config.LoadConfig()
 
 	// Connect to database
-	db := database.ConnectDB(cfg)
+	db, err := database.ConnectDB(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Manage the schema instead of running the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrate(db, os.Args[2:])
+		return
+	}
+
+	// Apply pending migrations
+	if cfg.AutoMigrate {
+		migrator, err := database.NewMigrator(db)
+		if err != nil {
+			log.Fatal("Failed to load migrations:", err)
+		}
+		if _, err := migrator.Up(); err != nil {
+			log.Fatal("Failed to migrate database:", err)
+		}
+	}
 
-	// Auto migrate
-	database.AutoMigrate(db)
+	// Scope every query made for an organization to its rows
+	if err := repository.RegisterOrganizationScope(db); err != nil {
+		log.Fatal("Failed to register organization scope:", err)
+	}
+	// Record every change made for a user or job in the audit trail
+	if err := repository.RegisterAuditTrail(db); err != nil {
+		log.Fatal("Failed to register audit trail:", err)
+	}
+	// Return domain errors instead of driver errors from every repository
+	if err := repository.RegisterErrorTranslation(db); err != nil {
+		log.Fatal("Failed to register error translation:", err)
+	}
 
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db)
-	roomRepo := repository.NewRoomRepository(db)
-	tenantRepo := repository.NewTenantRepository(db)
-	paymentRepo := repository.NewPaymentRepository(db)
-	expenseRepo := repository.NewExpenseRepository(db)
+	organizationRepo := repository.NewOrganizationRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	uow := repository.NewUnitOfWork(db)
+
+	// Load the permission matrix
+	permissions := usecase.DefaultPermissions()
+	if cfg.PermissionsFile != "" {
+		data, err := os.ReadFile(cfg.PermissionsFile)
+		if err != nil {
+			log.Fatal("Failed to read permissions file:", err)
+		}
+		if permissions, err = usecase.ParsePermissions(data); err != nil {
+			log.Fatal("Invalid permissions file:", err)
+		}
+	}
 
 	// Initialize use cases
-	authUsecase := usecase.NewAuthUsecase(userRepo, cfg.JWTSecret)
-	roomUsecase := usecase.NewRoomUsecase(roomRepo)
-	tenantUsecase := usecase.NewTenantUsecase(tenantRepo, roomRepo)
-	paymentUsecase := usecase.NewPaymentUsecase(paymentRepo, tenantRepo)
-	dashboardUsecase := usecase.NewDashboardUsecase(roomRepo, tenantRepo, paymentRepo, expenseRepo)
-	expenseUsecase := usecase.NewExpenseUsecase(expenseRepo)
+	roundTo, err := entity.ParseMoney(cfg.ProrationRoundTo)
+	if err != nil {
+		log.Fatal("Invalid PRORATION_ROUND_TO:", err)
+	}
+	switch cfg.ProrationBasis {
+	case usecase.ProrationBasisCalendar, usecase.ProrationBasis30Days:
+	default:
+		log.Fatal("Invalid PRORATION_BASIS: must be calendar or 30day")
+	}
+	options := usecase.UsecaseOptions{
+		Proration: usecase.ProrationPolicy{Basis: cfg.ProrationBasis, RoundTo: roundTo},
+		Renderer:  pdf.NewRenderer(),
+
+		Roles:         permissions.Roles(),
+		InvitationTTL: cfg.InvitationTTL,
+		Accounts:      userRepo,
+	}
+	usecases := func(organizationID uint, actor entity.Actor) *usecase.Usecases {
+		scoped := repository.ForActor(repository.ForOrganization(db, organizationID), actor)
+		return usecase.NewUsecases(repository.NewUnitOfWork(scoped), repository.NewRepositories(scoped), options)
+	}
+	var mailer usecase.Mailer
+	switch cfg.Mailer {
+	case "smtp":
+		mailer = mail.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	case "log":
+		mailer = mail.NewLogMailer(cfg.MailFile)
+	default:
+		log.Fatal("Invalid MAILER: must be smtp or log")
+	}
+	authUsecase := usecase.NewAuthUsecase(uow, userRepo, organizationRepo, sessionRepo, usecase.AuthOptions{
+		JWTSecret:        cfg.JWTSecret,
+		RegistrationOpen: cfg.RegistrationOpen,
+		AccessTokenTTL:   cfg.AccessTokenTTL,
+		RefreshTokenTTL:  cfg.RefreshTokenTTL,
+		Mailer:           mailer,
+		PasswordResetTTL: cfg.PasswordResetTTL,
+		PasswordResetURL: cfg.PasswordResetURL,
+		ChallengeTTL:     cfg.TwoFactorChallengeTTL,
+
+		AccountLimiter:     ratelimit.NewMemoryLimiter(cfg.AuthRateLimitAccount, cfg.AuthRateWindow),
+		LockoutThreshold:   cfg.LockoutThreshold,
+		LockoutDuration:    cfg.LockoutDuration,
+		LockoutMaxDuration: cfg.LockoutMaxDuration,
+	})
+	organizationUsecase := usecase.NewOrganizationUsecase(organizationRepo)
+
+	// Run a command instead of the server, e.g. "bootstrap"
+	if len(os.Args) > 1 {
+		runCommand(authUsecase, os.Args[1], os.Args[2:])
+		return
+	}
 
 	// Initialize handlers
+	requestUsecases := handler.OrganizationUsecases(usecases)
 	authHandler := handler.NewAuthHandler(authUsecase)
-	roomHandler := handler.NewRoomHandler(roomUsecase)
-	tenantHandler := handler.NewTenantHandler(tenantUsecase)
-	paymentHandler := handler.NewPaymentHandler(paymentUsecase)
-	dashboardHandler := handler.NewDashboardHandler(dashboardUsecase)
-	expenseHandler := handler.NewExpenseHandler(expenseUsecase)
+	organizationHandler := handler.NewOrganizationHandler(organizationUsecase)
+	propertyHandler := handler.NewPropertyHandler(requestUsecases)
+	roomHandler := handler.NewRoomHandler(requestUsecases)
+	tenantHandler := handler.NewTenantHandler(requestUsecases)
+	paymentHandler := handler.NewPaymentHandler(requestUsecases)
+	dashboardHandler := handler.NewDashboardHandler(requestUsecases)
+	expenseHandler := handler.NewExpenseHandler(requestUsecases)
+	billingHandler := handler.NewBillingHandler(requestUsecases)
+	depositHandler := handler.NewDepositHandler(requestUsecases)
+	lateFeeHandler := handler.NewLateFeeHandler(requestUsecases)
+	meteringHandler := handler.NewMeteringHandler(requestUsecases)
+	documentHandler := handler.NewDocumentHandler(requestUsecases)
+	leaseHandler := handler.NewLeaseHandler(requestUsecases)
+	userHandler := handler.NewUserHandler(requestUsecases)
+	auditHandler := handler.NewAuditHandler(requestUsecases)
+
+	// Start background jobs
+	if cfg.SchedulerEnabled {
+		jobs := scheduler.NewScheduler()
+		jobs.Register(scheduler.BillingJob(organizationUsecase, usecases, cfg.BillingInterval))
+		jobs.Register(scheduler.LateFeeJob(organizationUsecase, usecases, cfg.LateFeeInterval))
+		jobs.Start()
+	}
 
 	// Setup Gin
-	r := gin.Default()
+	r, err := http.NewEngine(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES:", err)
+	}
 
 	// Setup middleware
-	authMiddleware := middleware.NewAuthMiddleware(cfg.JWTSecret)
+	authMiddleware := middleware.NewAuthMiddleware(authUsecase)
+	authorizer := middleware.NewAuthorizer(permissions, usecases)
+	authRateLimit := middleware.RateLimit(ratelimit.NewMemoryLimiter(cfg.AuthRateLimitIP, cfg.AuthRateWindow))
 
 	// Setup routes
-	http.SetupRoutes(r, authMiddleware, authHandler, roomHandler, tenantHandler, paymentHandler, dashboardHandler, expenseHandler)
+	http.SetupRoutes(r, authMiddleware, authorizer, authRateLimit, authHandler, roomHandler, tenantHandler, paymentHandler, dashboardHandler, expenseHandler, billingHandler, depositHandler, lateFeeHandler, meteringHandler, documentHandler, leaseHandler, propertyHandler, organizationHandler, userHandler, auditHandler)
 
 	// Run server
 	log.Printf("Server running on port %s", cfg.ServerPort)
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"ezkost/package/database"
+	"flag"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// migrate applies, rolls back or lists the schema migrations:
+//
+//	go run ./cmd migrate up
+//	go run ./cmd migrate down -steps 1
+//	go run ./cmd migrate status
+func migrate(db *gorm.DB, args []string) {
+	if len(args) == 0 {
+		log.Fatal("Usage: migrate up|down|status")
+	}
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		if err != nil {
+			log.Fatal("Failed to migrate database:", err)
+		}
+		log.Printf("Applied %d migration(s)", len(applied))
+	case "down":
+		flags := flag.NewFlagSet("migrate down", flag.ExitOnError)
+		steps := flags.Int("steps", 1, "number of migrations to roll back")
+		flags.Parse(args[1:])
+
+		reverted, err := migrator.Down(*steps)
+		if err != nil {
+			log.Fatal("Failed to roll back migrations:", err)
+		}
+		log.Printf("Rolled back %d migration(s)", len(reverted))
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatal("Failed to read migration status:", err)
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			log.Printf("%04d_%s  %s", status.Version, status.Name, state)
+		}
+	default:
+		log.Fatalf("Unknown migrate command %q (available: up, down, status)", args[0])
+	}
+}
@@ -2,9 +2,16 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
+	// DBDriver is "postgres" (the default), "sqlite" for the file at DBPath,
+	// or "memory" for an SQLite database that is gone when the process exits
+	DBDriver   string
+	DBPath     string
 	DBHost     string
 	DBPort     string
 	DBUser     string
@@ -12,10 +19,56 @@ type Config struct {
 	DBName     string
 	ServerPort string
 	JWTSecret  string
+
+	// TrustedProxies are the addresses or CIDRs of reverse proxies whose
+	// X-Forwarded-For is believed. Empty means none: the client IP is the
+	// connection's remote address.
+	TrustedProxies []string
+
+	// AutoMigrate applies pending migrations when the server starts
+	AutoMigrate bool
+
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	// Mailer is "log" (the default, for local development) or "smtp"
+	Mailer           string
+	MailFile         string
+	SMTPHost         string
+	SMTPPort         string
+	SMTPUsername     string
+	SMTPPassword     string
+	MailFrom         string
+	PasswordResetTTL time.Duration
+	PasswordResetURL string
+
+	TwoFactorChallengeTTL time.Duration
+
+	// Requests per AuthRateWindow allowed on /auth per IP, and login
+	// attempts per account
+	AuthRateLimitIP      int
+	AuthRateLimitAccount int
+	AuthRateWindow       time.Duration
+	LockoutThreshold     int
+	LockoutDuration      time.Duration
+	LockoutMaxDuration   time.Duration
+
+	// PermissionsFile is a JSON permission matrix replacing the default one
+	PermissionsFile  string
+	RegistrationOpen bool
+	InvitationTTL    time.Duration
+
+	SchedulerEnabled bool
+	BillingInterval  time.Duration
+	LateFeeInterval  time.Duration
+	ProrationBasis   string
+	ProrationRoundTo string
 }
 
 func LoadConfig() *Config {
 	return &Config{
+		DBDriver:   getEnv("DB_DRIVER", "postgres"),
+		DBPath:     getEnv("DB_PATH", "ezkost.db"),
 		DBHost:     getEnv("DB_HOST", "localhost"),
 		DBPort:     getEnv("DB_PORT", "5432"),
 		DBUser:     getEnv("DB_USER", "postgres"),
@@ -23,6 +76,42 @@ func LoadConfig() *Config {
 		DBName:     getEnv("DB_NAME", "kos_management"),
 		ServerPort: getEnv("SERVER_PORT", "8080"),
 		JWTSecret:  getEnv("JWT_SECRET", "your-secret-key-change-this"),
+
+		TrustedProxies: getEnvList("TRUSTED_PROXIES"),
+
+		AutoMigrate: getEnv("AUTO_MIGRATE", "true") == "true",
+
+		AccessTokenTTL:  getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL: getEnvDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+
+		Mailer:           getEnv("MAILER", "log"),
+		MailFile:         getEnv("MAIL_FILE", ""),
+		SMTPHost:         getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:         getEnv("SMTP_PORT", "587"),
+		SMTPUsername:     getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:     getEnv("SMTP_PASSWORD", ""),
+		MailFrom:         getEnv("MAIL_FROM", "no-reply@ezkost.local"),
+		PasswordResetTTL: getEnvDuration("PASSWORD_RESET_TTL", time.Hour),
+		PasswordResetURL: getEnv("PASSWORD_RESET_URL", ""),
+
+		TwoFactorChallengeTTL: getEnvDuration("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute),
+
+		AuthRateLimitIP:      getEnvInt("AUTH_RATE_LIMIT_IP", 30),
+		AuthRateLimitAccount: getEnvInt("AUTH_RATE_LIMIT_ACCOUNT", 10),
+		AuthRateWindow:       getEnvDuration("AUTH_RATE_WINDOW", time.Minute),
+		LockoutThreshold:     getEnvInt("LOCKOUT_THRESHOLD", 5),
+		LockoutDuration:      getEnvDuration("LOCKOUT_DURATION", time.Minute),
+		LockoutMaxDuration:   getEnvDuration("LOCKOUT_MAX_DURATION", time.Hour),
+
+		PermissionsFile:  getEnv("PERMISSIONS_FILE", ""),
+		RegistrationOpen: getEnv("REGISTRATION_OPEN", "false") == "true",
+		InvitationTTL:    getEnvDuration("INVITATION_TTL", 72*time.Hour),
+
+		SchedulerEnabled: getEnv("SCHEDULER_ENABLED", "true") == "true",
+		BillingInterval:  getEnvDuration("BILLING_INTERVAL", 6*time.Hour),
+		LateFeeInterval:  getEnvDuration("LATE_FEE_INTERVAL", 6*time.Hour),
+		ProrationBasis:   getEnv("PRORATION_BASIS", "calendar"),
+		ProrationRoundTo: getEnv("PRORATION_ROUND_TO", "1000"),
 	}
 }
 
@@ -32,3 +121,32 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvList splits a comma-separated variable, dropping empty entries.
+func getEnvList(key string) []string {
+	var list []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,20 @@
+package http
+
+import (
+	"ezkost/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NewEngine returns the Gin engine serving the API, logging requests and
+// answering panics as problems. Only the proxies in trustedProxies may set
+// the client IP through X-Forwarded-For; with none, the client IP rate
+// limits and login attempts see is the remote address.
+func NewEngine(trustedProxies []string) (*gin.Engine, error) {
+	r := gin.New()
+	r.Use(gin.Logger(), middleware.Recovery())
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,109 @@
+package http
+
+import (
+	"encoding/json"
+	"ezkost/internal/delivery/http/middleware"
+	"ezkost/package/ratelimit"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestEngineClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		remoteAddr string
+		forwarded  string
+		wantStatus int
+	}
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		requests       []request
+	}{
+		{
+			// A client forging X-Forwarded-For still fills its own bucket
+			name: "no trusted proxies",
+			requests: []request{
+				{"203.0.113.7:4321", "198.51.100.1", nethttp.StatusOK},
+				{"203.0.113.7:4321", "198.51.100.2", nethttp.StatusTooManyRequests},
+				{"203.0.113.7:4321", "", nethttp.StatusTooManyRequests},
+				{"203.0.113.8:4321", "", nethttp.StatusOK},
+			},
+		},
+		{
+			name:           "behind a trusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			requests: []request{
+				{"10.0.0.2:4321", "198.51.100.1", nethttp.StatusOK},
+				{"10.0.0.2:4321", "198.51.100.2", nethttp.StatusOK},
+				{"10.0.0.2:4321", "198.51.100.1", nethttp.StatusTooManyRequests},
+				{"203.0.113.7:4321", "198.51.100.3", nethttp.StatusOK},
+				{"203.0.113.7:4321", "198.51.100.4", nethttp.StatusTooManyRequests},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewEngine(tt.trustedProxies)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.Use(middleware.Errors(), middleware.RateLimit(ratelimit.NewMemoryLimiter(1, time.Minute)))
+			r.GET("/ping", func(c *gin.Context) { c.Status(nethttp.StatusOK) })
+
+			for i, req := range tt.requests {
+				httpReq := httptest.NewRequest(nethttp.MethodGet, "/ping", nil)
+				httpReq.RemoteAddr = req.remoteAddr
+				if req.forwarded != "" {
+					httpReq.Header.Set("X-Forwarded-For", req.forwarded)
+				}
+				rec := httptest.NewRecorder()
+				r.ServeHTTP(rec, httpReq)
+				if rec.Code != req.wantStatus {
+					t.Errorf("request %d from %s forwarded for %q: status %d, want %d", i, req.remoteAddr, req.forwarded, rec.Code, req.wantStatus)
+				}
+			}
+		})
+	}
+
+	if _, err := NewEngine([]string{"not-an-ip"}); err == nil {
+		t.Error("an invalid trusted proxy was accepted")
+	}
+}
+
+func TestEngineRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r, err := NewEngine(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Use(middleware.Errors())
+	r.GET("/panic", func(c *gin.Context) { panic("nil map") })
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, "/panic", nil))
+
+	var problem struct {
+		Status int    `json:"status"`
+		Code   string `json:"code"`
+		Detail string `json:"detail"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("body %q is not JSON: %v", rec.Body, err)
+	}
+	if rec.Code != nethttp.StatusInternalServerError || problem.Status != nethttp.StatusInternalServerError || problem.Code != "internal_error" {
+		t.Errorf("status %d, problem %+v, want a 500 internal_error problem", rec.Code, problem)
+	}
+	if contentType := rec.Header().Get("Content-Type"); contentType != "application/problem+json" {
+		t.Errorf("content type = %q", contentType)
+	}
+	if strings.Contains(rec.Body.String(), "nil map") {
+		t.Errorf("panic value leaked to the client: %s", rec.Body)
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Audit Handler
+type AuditHandler struct {
+	usecases Usecases
+}
+
+func NewAuditHandler(usecases Usecases) *AuditHandler {
+	return &AuditHandler{usecases: usecases}
+}
+
+// Search lists audit entries, newest first. Filters: entity (e.g. payment),
+// id, user_id, action, from and to (YYYY-MM-DD, to inclusive) and limit.
+func (h *AuditHandler) Search(c *gin.Context) {
+	filter := repository.AuditFilter{
+		EntityType: c.Query("entity"),
+		Action:     c.Query("action"),
+	}
+
+	var ok bool
+	if filter.EntityID, ok = uintQuery(c, "id"); !ok {
+		return
+	}
+	if filter.UserID, ok = uintQuery(c, "user_id"); !ok {
+		return
+	}
+	limit, ok := uintQuery(c, "limit")
+	if !ok {
+		return
+	}
+	filter.Limit = int(limit)
+
+	if filter.From, ok = dateQuery(c, "from", false); !ok {
+		return
+	}
+	if filter.To, ok = dateQuery(c, "to", true); !ok {
+		return
+	}
+
+	entries, err := h.usecases(c).Audit.Search(filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// uintQuery reads an optional numeric query parameter; 0 when absent.
+func uintQuery(c *gin.Context, name string) (uint, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, true
+	}
+	n, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		c.Error(entity.Invalid("invalid_query", "%s must be a number", name))
+		return 0, false
+	}
+	return uint(n), true
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"ezkost/internal/domain/entity"
 	"ezkost/internal/usecase"
 	"net/http"
@@ -22,49 +23,290 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// RegisterRequest signs up a new organization; the user becomes its owner.
 type RegisterRequest struct {
+	OrganizationName string `json:"organization_name"`
+	Name             string `json:"name" binding:"required"`
+	Email            string `json:"email" binding:"required,email"`
+	Password         string `json:"password" binding:"required,min=6"`
+}
+
+// VerifyTwoFactorRequest takes a code from the authenticator app or a
+// recovery code.
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type EnrollTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required"`
 	Name     string `json:"name" binding:"required"`
-	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
-	Role     string `json:"role" binding:"required,oneof=owner staff"`
 }
 
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
-	token, user, err := h.authUsecase.Login(req.Email, req.Password)
+	result, err := h.authUsecase.Login(req.Email, req.Password, c.ClientIP())
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		respondAuthError(c, err)
+		return
+	}
+	respondLogin(c, result)
+}
+
+// VerifyTwoFactor is the second step of a login that returned a challenge.
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	var req VerifyTwoFactorRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.authUsecase.VerifyTwoFactor(req.ChallengeToken, req.Code, c.ClientIP())
+	if err != nil {
+		respondAuthError(c, err)
+		return
+	}
+	respondLogin(c, result)
+}
+
+// respondAuthError records a failed login step. A rejected code or token is
+// answered with 401 like wrong credentials; rate limits and locks get 429.
+func respondAuthError(c *gin.Context, err error) {
+	var invalid *entity.Error
+	if errors.As(err, &invalid) && invalid.Kind == entity.ErrValidation {
+		err = &entity.Error{Kind: entity.ErrUnauthorized, Code: invalid.Code, Message: invalid.Message, Err: err}
+	}
+	c.Error(err)
+}
+
+func respondLogin(c *gin.Context, result *usecase.LoginResult) {
+	if result.Challenge != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"two_factor_required": true,
+			"challenge":           result.Challenge,
+		})
+		return
+	}
+
+	response := gin.H{
+		"token":         result.Tokens.AccessToken,
+		"refresh_token": result.Tokens.RefreshToken,
+		"expires_at":    result.Tokens.ExpiresAt,
+		"user":          result.User,
+	}
+	if result.RecoveryCodes != nil {
+		response["recovery_codes"] = result.RecoveryCodes
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// EnrollTwoFactor sets up an authenticator for a user who cannot log in
+// until they enroll.
+func (h *AuthHandler) EnrollTwoFactor(c *gin.Context) {
+	var req EnrollTwoFactorRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	setup, err := h.authUsecase.EnrollWithChallenge(req.ChallengeToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, setup)
+}
+
+func (h *AuthHandler) SetupTwoFactor(c *gin.Context) {
+	setup, err := h.authUsecase.SetupTwoFactor(c.GetUint("user_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, setup)
+}
+
+func (h *AuthHandler) EnableTwoFactor(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	codes, err := h.authUsecase.EnableTwoFactor(c.GetUint("user_id"), req.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	var req DisableTwoFactorRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.authUsecase.DisableTwoFactor(c.GetUint("user_id"), req.Password); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req TwoFactorCodeRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	codes, err := h.authUsecase.RegenerateRecoveryCodes(c.GetUint("user_id"), req.Code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	tokens, err := h.authUsecase.Refresh(req.RefreshToken)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"token": token,
-		"user":  user,
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_at":    tokens.ExpiresAt,
 	})
 }
 
+func (h *AuthHandler) Logout(c *gin.Context) {
+	if err := h.authUsecase.Logout(c.GetUint("session_id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+}
+
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	if err := h.authUsecase.LogoutAll(c.GetUint("user_id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out of all sessions"})
+}
+
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &req) {
 		return
 	}
 
+	organization := &entity.Organization{Name: req.OrganizationName}
 	user := &entity.User{
 		Name:  req.Name,
 		Email: req.Email,
-		Role:  req.Role,
 	}
 
-	if err := h.authUsecase.Register(user, req.Password); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.authUsecase.Register(organization, user, req.Password); err != nil {
+		c.Error(err)
 		return
 	}
 
+	c.JSON(http.StatusCreated, gin.H{
+		"organization": organization,
+		"user":         user,
+	})
+}
+
+func (h *AuthHandler) AcceptInvitation(c *gin.Context) {
+	var req AcceptInvitationRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.authUsecase.AcceptInvitation(req.Token, req.Name, req.Password)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 	c.JSON(http.StatusCreated, user)
 }
+
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req ChangePasswordRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.authUsecase.ChangePassword(c.GetUint("user_id"), c.GetUint("session_id"), req.OldPassword, req.NewPassword); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed"})
+}
+
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.authUsecase.ForgotPassword(req.Email); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "If the email is registered, a reset link has been sent"})
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := h.authUsecase.ResetPassword(req.Token, req.Password); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset; log in with the new password"})
+}
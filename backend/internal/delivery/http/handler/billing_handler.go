@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"ezkost/internal/usecase"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Billing Handler
+type BillingHandler struct {
+	usecases Usecases
+}
+
+func NewBillingHandler(usecases Usecases) *BillingHandler {
+	return &BillingHandler{usecases: usecases}
+}
+
+type ProrationPreviewRequest struct {
+	RoomID    uint       `json:"room_id" binding:"required"`
+	StartDate time.Time  `json:"start_date" binding:"required"`
+	EndDate   *time.Time `json:"end_date"`
+}
+
+func (h *BillingHandler) Run(c *gin.Context) {
+	period, err := usecase.ParseBillingPeriod(c.Query("period"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	result, err := h.usecases(c).Billing.RunMonthly(period)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *BillingHandler) PreviewProration(c *gin.Context) {
+	var req ProrationPreviewRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	preview, err := h.usecases(c).Billing.PreviewProration(req.RoomID, req.StartDate, req.EndDate)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, preview)
+}
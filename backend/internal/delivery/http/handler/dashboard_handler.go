@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"ezkost/internal/usecase"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -9,17 +8,22 @@ import (
 
 // Dashboard Handler
 type DashboardHandler struct {
-	dashboardUsecase usecase.DashboardUsecase
+	usecases Usecases
 }
 
-func NewDashboardHandler(dashboardUsecase usecase.DashboardUsecase) *DashboardHandler {
-	return &DashboardHandler{dashboardUsecase: dashboardUsecase}
+func NewDashboardHandler(usecases Usecases) *DashboardHandler {
+	return &DashboardHandler{usecases: usecases}
 }
 
 func (h *DashboardHandler) GetSummary(c *gin.Context) {
-	summary, err := h.dashboardUsecase.GetSummary()
+	propertyID, ok := propertyFilter(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.usecases(c).Dashboard.GetSummary(propertyID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, summary)
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"ezkost/internal/domain/entity"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deposit Handler
+type DepositHandler struct {
+	usecases Usecases
+}
+
+func NewDepositHandler(usecases Usecases) *DepositHandler {
+	return &DepositHandler{usecases: usecases}
+}
+
+type DepositReceiveRequest struct {
+	Amount entity.Money `json:"amount"`
+	Reason string       `json:"reason"`
+}
+
+type DepositDeductionRequest struct {
+	Amount    entity.Money `json:"amount"`
+	Reason    string       `json:"reason"`
+	PaymentID *uint        `json:"payment_id"`
+}
+
+func (h *DepositHandler) GetStatement(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	statement, err := h.usecases(c).Deposit.GetStatement(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, statement)
+}
+
+func (h *DepositHandler) Receive(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req DepositReceiveRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	statement, err := h.usecases(c).Deposit.Receive(id, req.Amount, req.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, statement)
+}
+
+func (h *DepositHandler) Deduct(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req DepositDeductionRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	statement, err := h.usecases(c).Deposit.Deduct(id, req.Amount, req.Reason, req.PaymentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, statement)
+}
+
+func (h *DepositHandler) Settle(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	statement, err := h.usecases(c).Deposit.Settle(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, statement)
+}
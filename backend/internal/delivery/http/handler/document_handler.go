@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/usecase"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Document Handler
+type DocumentHandler struct {
+	usecases Usecases
+}
+
+func NewDocumentHandler(usecases Usecases) *DocumentHandler {
+	return &DocumentHandler{usecases: usecases}
+}
+
+func (h *DocumentHandler) GetLetterhead(c *gin.Context) {
+	letterhead, err := h.usecases(c).Document.GetLetterhead()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, letterhead)
+}
+
+func (h *DocumentHandler) UpdateLetterhead(c *gin.Context) {
+	var letterhead entity.Letterhead
+	if !bindJSON(c, &letterhead) {
+		return
+	}
+
+	if err := h.usecases(c).Document.UpdateLetterhead(&letterhead); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, letterhead)
+}
+
+func (h *DocumentHandler) Receipt(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	data, doc, err := h.usecases(c).Document.Receipt(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	sendPDF(c, doc, data)
+}
+
+func (h *DocumentHandler) Invoice(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	data, doc, err := h.usecases(c).Document.Invoice(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	sendPDF(c, doc, data)
+}
+
+func sendPDF(c *gin.Context, doc *usecase.PaymentDocument, data []byte) {
+	filename := strings.ReplaceAll(doc.Number, "/", "-") + ".pdf"
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	c.Data(http.StatusOK, "application/pdf", data)
+}
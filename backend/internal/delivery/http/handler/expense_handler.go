@@ -2,36 +2,56 @@ package handler
 
 import (
 	"ezkost/internal/domain/entity"
-	"ezkost/internal/usecase"
+	"ezkost/internal/domain/repository"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Expense Handler
 type ExpenseHandler struct {
-	expenseUsecase usecase.ExpenseUsecase
+	usecases Usecases
 }
 
-func NewExpenseHandler(expenseUsecase usecase.ExpenseUsecase) *ExpenseHandler {
-	return &ExpenseHandler{expenseUsecase: expenseUsecase}
+func NewExpenseHandler(usecases Usecases) *ExpenseHandler {
+	return &ExpenseHandler{usecases: usecases}
 }
 
+// GetAll lists a page of expenses, newest first unless sorted otherwise.
+// Filters: property_id, from and to (YYYY-MM-DD, to inclusive); see pageQuery
+// for paging and sorting.
 func (h *ExpenseHandler) GetAll(c *gin.Context) {
-	expenses, err := h.expenseUsecase.GetAll()
+	var query repository.ExpenseQuery
+	var ok bool
+	if query.PropertyID, ok = propertyFilter(c); !ok {
+		return
+	}
+	if query.From, ok = dateQuery(c, "from", false); !ok {
+		return
+	}
+	if query.To, ok = dateQuery(c, "to", true); !ok {
+		return
+	}
+	if query.Page, ok = pageQuery(c, repository.ExpenseSortFields); !ok {
+		return
+	}
+
+	expenses, total, err := h.usecases(c).Expense.GetAll(query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, expenses)
+	respondPage(c, expenses, total, query.Page)
 }
 
 func (h *ExpenseHandler) GetByID(c *gin.Context) {
-	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
-	expense, err := h.expenseUsecase.GetByID(uint(id))
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	expense, err := h.usecases(c).Expense.GetByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Expense not found"})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, expense)
@@ -39,13 +59,12 @@ func (h *ExpenseHandler) GetByID(c *gin.Context) {
 
 func (h *ExpenseHandler) Create(c *gin.Context) {
 	var expense entity.Expense
-	if err := c.ShouldBindJSON(&expense); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &expense) {
 		return
 	}
 
-	if err := h.expenseUsecase.Create(&expense); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.usecases(c).Expense.Create(&expense); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -53,17 +72,19 @@ func (h *ExpenseHandler) Create(c *gin.Context) {
 }
 
 func (h *ExpenseHandler) Update(c *gin.Context) {
-	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
 
 	var expense entity.Expense
-	if err := c.ShouldBindJSON(&expense); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &expense) {
 		return
 	}
 
-	expense.ID = uint(id)
-	if err := h.expenseUsecase.Update(&expense); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	expense.ID = id
+	if err := h.usecases(c).Expense.Update(&expense); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -71,9 +92,12 @@ func (h *ExpenseHandler) Update(c *gin.Context) {
 }
 
 func (h *ExpenseHandler) Delete(c *gin.Context) {
-	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err := h.expenseUsecase.Delete(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.usecases(c).Expense.Delete(id); err != nil {
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Expense deleted successfully"})
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"ezkost/internal/domain/entity"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Late Fee Handler
+type LateFeeHandler struct {
+	usecases Usecases
+}
+
+func NewLateFeeHandler(usecases Usecases) *LateFeeHandler {
+	return &LateFeeHandler{usecases: usecases}
+}
+
+type WaiveRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// GetPolicy returns the late fee policy of the property in ?property_id=.
+func (h *LateFeeHandler) GetPolicy(c *gin.Context) {
+	propertyID, ok := policyProperty(c)
+	if !ok {
+		return
+	}
+
+	policy, err := h.usecases(c).LateFee.GetPolicy(propertyID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdatePolicy replaces the late fee policy of the property in ?property_id=.
+func (h *LateFeeHandler) UpdatePolicy(c *gin.Context) {
+	propertyID, ok := policyProperty(c)
+	if !ok {
+		return
+	}
+
+	var policy entity.LateFeePolicy
+	if !bindJSON(c, &policy) {
+		return
+	}
+
+	if err := h.usecases(c).LateFee.UpdatePolicy(propertyID, &policy); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, policy)
+}
+
+func (h *LateFeeHandler) Run(c *gin.Context) {
+	result, err := h.usecases(c).LateFee.Apply(time.Now())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *LateFeeHandler) Waive(c *gin.Context) {
+	paymentID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	itemID, ok := idParam(c, "item_id")
+	if !ok {
+		return
+	}
+
+	var req WaiveRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	item, err := h.usecases(c).LateFee.Waive(uint(paymentID), itemID, c.GetUint("user_id"), req.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// policyProperty reads the required ?property_id= of a late fee policy.
+func policyProperty(c *gin.Context) (uint, bool) {
+	propertyID, ok := propertyFilter(c)
+	if ok && propertyID == 0 {
+		c.Error(entity.Invalid("property_required", "property_id is required"))
+		return 0, false
+	}
+	return propertyID, ok
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"ezkost/internal/domain/entity"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Lease Handler
+type LeaseHandler struct {
+	usecases Usecases
+}
+
+func NewLeaseHandler(usecases Usecases) *LeaseHandler {
+	return &LeaseHandler{usecases: usecases}
+}
+
+type RenewLeaseRequest struct {
+	TermMonths int          `json:"term_months"`
+	Rent       entity.Money `json:"rent"`
+}
+
+type TerminateLeaseRequest struct {
+	Date   time.Time `json:"date" binding:"required"`
+	Reason string    `json:"reason" binding:"required"`
+}
+
+func (h *LeaseHandler) GetAll(c *gin.Context) {
+	leases, err := h.usecases(c).Lease.GetAll()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, leases)
+}
+
+func (h *LeaseHandler) GetByID(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	lease, err := h.usecases(c).Lease.GetByID(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, lease)
+}
+
+func (h *LeaseHandler) GetByTenantID(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	leases, err := h.usecases(c).Lease.GetByTenantID(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, leases)
+}
+
+func (h *LeaseHandler) GetExpiring(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil {
+		c.Error(entity.Invalid("invalid_query", "days must be a number"))
+		return
+	}
+
+	leases, err := h.usecases(c).Lease.GetExpiring(days)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, leases)
+}
+
+func (h *LeaseHandler) Create(c *gin.Context) {
+	var lease entity.Lease
+	if !bindJSON(c, &lease) {
+		return
+	}
+
+	if err := h.usecases(c).Lease.Create(&lease); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, lease)
+}
+
+func (h *LeaseHandler) Renew(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req RenewLeaseRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	lease, err := h.usecases(c).Lease.Renew(id, req.TermMonths, req.Rent)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, lease)
+}
+
+func (h *LeaseHandler) Terminate(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req TerminateLeaseRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	lease, err := h.usecases(c).Lease.Terminate(id, req.Date, req.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, lease)
+}
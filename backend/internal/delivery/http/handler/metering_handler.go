@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"ezkost/internal/domain/entity"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metering Handler
+type MeteringHandler struct {
+	usecases Usecases
+}
+
+func NewMeteringHandler(usecases Usecases) *MeteringHandler {
+	return &MeteringHandler{usecases: usecases}
+}
+
+type MeterReadingRequest struct {
+	Utility string  `json:"utility" binding:"required,oneof=electricity water"`
+	Period  string  `json:"period" binding:"required"`
+	Reading float64 `json:"reading" binding:"gte=0"`
+}
+
+func (h *MeteringHandler) GetTariffs(c *gin.Context) {
+	tariffs, err := h.usecases(c).Metering.GetTariffs()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, tariffs)
+}
+
+func (h *MeteringHandler) SaveTariff(c *gin.Context) {
+	var tariff entity.UtilityTariff
+	if !bindJSON(c, &tariff) {
+		return
+	}
+
+	tariff.Utility = c.Param("utility")
+	if err := h.usecases(c).Metering.SaveTariff(&tariff); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, tariff)
+}
+
+func (h *MeteringHandler) GetReadingsByRoom(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	readings, err := h.usecases(c).Metering.GetReadingsByRoom(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, readings)
+}
+
+func (h *MeteringHandler) RecordReading(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req MeterReadingRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	reading := &entity.MeterReading{
+		RoomID:     id,
+		Utility:    req.Utility,
+		Period:     req.Period,
+		Reading:    req.Reading,
+		RecordedBy: c.GetUint("user_id"),
+	}
+	if err := h.usecases(c).Metering.RecordReading(reading); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, reading)
+}
+
+func (h *MeteringHandler) GetFlagged(c *gin.Context) {
+	readings, err := h.usecases(c).Metering.GetFlagged()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, readings)
+}
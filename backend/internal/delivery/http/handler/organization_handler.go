@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Organization Handler
+type OrganizationHandler struct {
+	organizationUsecase usecase.OrganizationUsecase
+}
+
+func NewOrganizationHandler(organizationUsecase usecase.OrganizationUsecase) *OrganizationHandler {
+	return &OrganizationHandler{organizationUsecase: organizationUsecase}
+}
+
+// Get returns the organization of the logged in user.
+func (h *OrganizationHandler) Get(c *gin.Context) {
+	organization, err := h.organizationUsecase.GetByID(c.GetUint("organization_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, organization)
+}
+
+func (h *OrganizationHandler) Update(c *gin.Context) {
+	var organization entity.Organization
+	if !bindJSON(c, &organization) {
+		return
+	}
+
+	organization.ID = c.GetUint("organization_id")
+	if err := h.organizationUsecase.Update(&organization); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, organization)
+}
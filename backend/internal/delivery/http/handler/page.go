@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pageQuery reads limit, offset and sort, e.g. ?sort=-due_date,amount for
+// the latest due date first and then the smallest amount. fields are the
+// fields the list can be sorted by.
+func pageQuery(c *gin.Context, fields []string) (repository.Page, bool) {
+	var page repository.Page
+	limit, ok := uintQuery(c, "limit")
+	if !ok {
+		return page, false
+	}
+	offset, ok := uintQuery(c, "offset")
+	if !ok {
+		return page, false
+	}
+	page.Limit, page.Offset = int(limit), int(offset)
+
+	if value := c.Query("sort"); value != "" {
+		for _, field := range strings.Split(value, ",") {
+			sort := repository.Sort{Field: strings.TrimSpace(field)}
+			if strings.HasPrefix(sort.Field, "-") {
+				sort.Field, sort.Desc = sort.Field[1:], true
+			}
+			if !slices.Contains(fields, sort.Field) {
+				c.Error(entity.Invalid("invalid_query", "sort must be a comma-separated list of %s, each optionally prefixed with -", strings.Join(fields, ", ")))
+				return page, false
+			}
+			page.Sort = append(page.Sort, sort)
+		}
+	}
+	return page, true
+}
+
+// dateQuery reads an optional YYYY-MM-DD query parameter; the zero time when
+// absent. An end date is inclusive, so it returns the start of the next day.
+func dateQuery(c *gin.Context, name string, end bool) (time.Time, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, true
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		c.Error(entity.Invalid("invalid_query", "%s must be in YYYY-MM-DD format", name))
+		return time.Time{}, false
+	}
+	if end {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date, true
+}
+
+// respondPage writes one page of a list with the number of matching rows.
+// next links to the following page and is null on the last one.
+func respondPage[T any](c *gin.Context, data []T, total int64, page repository.Page) {
+	var next *string
+	if end := page.Offset + len(data); len(data) > 0 && int64(end) < total {
+		u := *c.Request.URL
+		query := u.Query()
+		query.Set("offset", strconv.Itoa(end))
+		u.RawQuery = query.Encode()
+		link := u.RequestURI()
+		next = &link
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data":   data,
+		"total":  total,
+		"offset": page.Offset,
+		"next":   next,
+	})
+}
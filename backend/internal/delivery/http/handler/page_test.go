@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"ezkost/internal/delivery/http/middleware"
+	"ezkost/internal/domain/repository"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestPageQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.Errors())
+	r.GET("/payments", func(c *gin.Context) {
+		page, ok := pageQuery(c, repository.PaymentSortFields)
+		if !ok {
+			return
+		}
+		c.JSON(http.StatusOK, page)
+	})
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantPage   repository.Page
+	}{
+		{"no parameters", "", http.StatusOK, repository.Page{}},
+		{"limit and offset", "?limit=20&offset=40", http.StatusOK, repository.Page{Limit: 20, Offset: 40}},
+		{"sort fields", "?sort=-due_date,%20amount", http.StatusOK, repository.Page{Sort: []repository.Sort{{Field: "due_date", Desc: true}, {Field: "amount"}}}},
+		{"negative limit", "?limit=-1", http.StatusBadRequest, repository.Page{}},
+		{"negative offset", "?offset=-10", http.StatusBadRequest, repository.Page{}},
+		{"limit out of range", "?limit=99999999999", http.StatusBadRequest, repository.Page{}},
+		{"unknown sort field", "?sort=tenant.name", http.StatusBadRequest, repository.Page{}},
+		{"empty sort field", "?sort=amount,", http.StatusBadRequest, repository.Page{}},
+		{"only a minus", "?sort=-", http.StatusBadRequest, repository.Page{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/payments"+tt.query, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if w.Code != http.StatusOK {
+				var problem struct {
+					Code string `json:"code"`
+				}
+				if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil || problem.Code != "invalid_query" {
+					t.Errorf("body = %s, want code invalid_query", w.Body)
+				}
+				return
+			}
+			var page repository.Page
+			if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(page, tt.wantPage) {
+				t.Errorf("page = %+v, want %+v", page, tt.wantPage)
+			}
+		})
+	}
+}
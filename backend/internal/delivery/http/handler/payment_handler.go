@@ -2,55 +2,95 @@ package handler
 
 import (
 	"ezkost/internal/domain/entity"
-	"ezkost/internal/usecase"
+	"ezkost/internal/domain/repository"
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Payment Handler
 type PaymentHandler struct {
-	paymentUsecase usecase.PaymentUsecase
+	usecases Usecases
 }
 
-func NewPaymentHandler(paymentUsecase usecase.PaymentUsecase) *PaymentHandler {
-	return &PaymentHandler{paymentUsecase: paymentUsecase}
+func NewPaymentHandler(usecases Usecases) *PaymentHandler {
+	return &PaymentHandler{usecases: usecases}
 }
 
+type TransactionRequest struct {
+	Amount entity.Money `json:"amount"`
+	Method string       `json:"method" binding:"required"`
+	PaidAt *time.Time   `json:"paid_at"`
+	Note   string       `json:"note"`
+}
+
+// GetAll lists a page of payments. Filters: property_id, tenant_id, room_id,
+// status, method, due_from and due_to (YYYY-MM-DD, due_to inclusive); see
+// pageQuery for paging and sorting.
 func (h *PaymentHandler) GetAll(c *gin.Context) {
-	payments, err := h.paymentUsecase.GetAll()
+	query := repository.PaymentQuery{
+		Status: c.Query("status"),
+		Method: c.Query("method"),
+	}
+	var ok bool
+	if query.PropertyID, ok = propertyFilter(c); !ok {
+		return
+	}
+	if query.TenantID, ok = uintQuery(c, "tenant_id"); !ok {
+		return
+	}
+	if query.RoomID, ok = uintQuery(c, "room_id"); !ok {
+		return
+	}
+	if query.DueFrom, ok = dateQuery(c, "due_from", false); !ok {
+		return
+	}
+	if query.DueTo, ok = dateQuery(c, "due_to", true); !ok {
+		return
+	}
+	if query.Page, ok = pageQuery(c, repository.PaymentSortFields); !ok {
+		return
+	}
+
+	payments, total, err := h.usecases(c).Payment.GetAll(query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, payments)
+	respondPage(c, payments, total, query.Page)
 }
 
 func (h *PaymentHandler) GetByID(c *gin.Context) {
-	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
-	payment, err := h.paymentUsecase.GetByID(uint(id))
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	payment, err := h.usecases(c).Payment.GetByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Payment not found"})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, payment)
 }
 
 func (h *PaymentHandler) GetByTenantID(c *gin.Context) {
-	id, _ := strconv.ParseUint(c.Param("tenant_id"), 10, 32)
-	payments, err := h.paymentUsecase.GetByTenantID(uint(id))
+	id, ok := idParam(c, "tenant_id")
+	if !ok {
+		return
+	}
+	payments, err := h.usecases(c).Payment.GetByTenantID(id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, payments)
 }
 
 func (h *PaymentHandler) GetOverdue(c *gin.Context) {
-	payments, err := h.paymentUsecase.GetOverdue()
+	payments, err := h.usecases(c).Payment.GetOverdue()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, payments)
@@ -58,13 +98,12 @@ func (h *PaymentHandler) GetOverdue(c *gin.Context) {
 
 func (h *PaymentHandler) Create(c *gin.Context) {
 	var payment entity.Payment
-	if err := c.ShouldBindJSON(&payment); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &payment) {
 		return
 	}
 
-	if err := h.paymentUsecase.Create(&payment); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.usecases(c).Payment.Create(&payment); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -72,19 +111,49 @@ func (h *PaymentHandler) Create(c *gin.Context) {
 }
 
 func (h *PaymentHandler) Update(c *gin.Context) {
-	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
 
 	var payment entity.Payment
-	if err := c.ShouldBindJSON(&payment); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &payment) {
 		return
 	}
 
-	payment.ID = uint(id)
-	if err := h.paymentUsecase.Update(&payment); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	payment.ID = id
+	if err := h.usecases(c).Payment.Update(&payment); err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, payment)
 }
+
+func (h *PaymentHandler) RecordTransaction(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req TransactionRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	transaction := &entity.PaymentTransaction{
+		Amount: req.Amount,
+		Method: req.Method,
+		Note:   req.Note,
+	}
+	if req.PaidAt != nil {
+		transaction.PaidAt = *req.PaidAt
+	}
+
+	payment, err := h.usecases(c).Payment.RecordTransaction(id, transaction)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, payment)
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"ezkost/internal/domain/entity"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Property Handler
+type PropertyHandler struct {
+	usecases Usecases
+}
+
+func NewPropertyHandler(usecases Usecases) *PropertyHandler {
+	return &PropertyHandler{usecases: usecases}
+}
+
+func (h *PropertyHandler) GetAll(c *gin.Context) {
+	properties, err := h.usecases(c).Property.GetAll()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, properties)
+}
+
+func (h *PropertyHandler) GetByID(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	property, err := h.usecases(c).Property.GetByID(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, property)
+}
+
+func (h *PropertyHandler) Create(c *gin.Context) {
+	var property entity.Property
+	if !bindJSON(c, &property) {
+		return
+	}
+
+	if err := h.usecases(c).Property.Create(&property); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, property)
+}
+
+func (h *PropertyHandler) Update(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var property entity.Property
+	if !bindJSON(c, &property) {
+		return
+	}
+
+	property.ID = id
+	if err := h.usecases(c).Property.Update(&property); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, property)
+}
+
+func (h *PropertyHandler) Delete(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.usecases(c).Property.Delete(id); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Property deleted successfully"})
+}
+
+// propertyFilter reads the optional ?property_id= filter; 0 means every
+// property.
+func propertyFilter(c *gin.Context) (uint, bool) {
+	value := c.Query("property_id")
+	if value == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		c.Error(entity.Invalid("invalid_query", "property_id must be a number"))
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// idParam reads a numeric path parameter such as :id. Anything that is not
+// a positive number is rejected rather than looked up as id 0.
+func idParam(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil || id == 0 {
+		c.Error(entity.Invalid("invalid_id", "%s must be a positive number", name))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// bindJSON reads the request body into obj, recording a validation error
+// when the body does not fit it. Values that validate themselves while being
+// decoded, such as amounts, report their own domain error.
+func bindJSON(c *gin.Context, obj any) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		var domainErr *entity.Error
+		if errors.As(err, &domainErr) {
+			c.Error(domainErr)
+			return false
+		}
+		c.Error(entity.Invalid("invalid_body", "%v", err))
+		return false
+	}
+	return true
+}
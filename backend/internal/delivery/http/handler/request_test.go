@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"ezkost/internal/delivery/http/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBindJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.Errors())
+	r.POST("/transactions", func(c *gin.Context) {
+		var req TransactionRequest
+		if !bindJSON(c, &req) {
+			return
+		}
+		c.JSON(http.StatusOK, req)
+	})
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{"valid", `{"amount": {"amount": "50000", "currency": "IDR"}, "method": "cash"}`, http.StatusOK, ""},
+		{"other currency", `{"amount": {"amount": "50000", "currency": "USD"}, "method": "cash"}`, http.StatusBadRequest, "unsupported_currency"},
+		{"missing method", `{"amount": "50000"}`, http.StatusBadRequest, "invalid_body"},
+		{"malformed", `{"amount":`, http.StatusBadRequest, "invalid_body"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/transactions", strings.NewReader(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantCode == "" {
+				return
+			}
+			var problem struct {
+				Code string `json:"code"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil || problem.Code != tt.wantCode {
+				t.Errorf("body = %s, want code %s", w.Body, tt.wantCode)
+			}
+		})
+	}
+}
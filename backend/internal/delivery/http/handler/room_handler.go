@@ -2,36 +2,49 @@ package handler
 
 import (
 	"ezkost/internal/domain/entity"
-	"ezkost/internal/usecase"
+	"ezkost/internal/domain/repository"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Room Handler
 type RoomHandler struct {
-	roomUsecase usecase.RoomUsecase
+	usecases Usecases
 }
 
-func NewRoomHandler(roomUsecase usecase.RoomUsecase) *RoomHandler {
-	return &RoomHandler{roomUsecase: roomUsecase}
+func NewRoomHandler(usecases Usecases) *RoomHandler {
+	return &RoomHandler{usecases: usecases}
 }
 
+// GetAll lists a page of rooms. Filters: property_id and status; see
+// pageQuery for paging and sorting.
 func (h *RoomHandler) GetAll(c *gin.Context) {
-	rooms, err := h.roomUsecase.GetAll()
+	query := repository.RoomQuery{Status: c.Query("status")}
+	var ok bool
+	if query.PropertyID, ok = propertyFilter(c); !ok {
+		return
+	}
+	if query.Page, ok = pageQuery(c, repository.RoomSortFields); !ok {
+		return
+	}
+
+	rooms, total, err := h.usecases(c).Room.GetAll(query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, rooms)
+	respondPage(c, rooms, total, query.Page)
 }
 
 func (h *RoomHandler) GetByID(c *gin.Context) {
-	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
-	room, err := h.roomUsecase.GetByID(uint(id))
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	room, err := h.usecases(c).Room.GetByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Room not found"})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, room)
@@ -39,13 +52,12 @@ func (h *RoomHandler) GetByID(c *gin.Context) {
 
 func (h *RoomHandler) Create(c *gin.Context) {
 	var room entity.Room
-	if err := c.ShouldBindJSON(&room); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &room) {
 		return
 	}
 
-	if err := h.roomUsecase.Create(&room); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.usecases(c).Room.Create(&room); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -53,17 +65,19 @@ func (h *RoomHandler) Create(c *gin.Context) {
 }
 
 func (h *RoomHandler) Update(c *gin.Context) {
-	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
 
 	var room entity.Room
-	if err := c.ShouldBindJSON(&room); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &room) {
 		return
 	}
 
-	room.ID = uint(id)
-	if err := h.roomUsecase.Update(&room); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	room.ID = id
+	if err := h.usecases(c).Room.Update(&room); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -71,10 +85,26 @@ func (h *RoomHandler) Update(c *gin.Context) {
 }
 
 func (h *RoomHandler) Delete(c *gin.Context) {
-	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err := h.roomUsecase.Delete(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.usecases(c).Room.Delete(id); err != nil {
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Room deleted successfully"})
 }
+
+func (h *RoomHandler) GetHistory(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	history, err := h.usecases(c).Room.GetHistory(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
@@ -2,36 +2,61 @@ package handler
 
 import (
 	"ezkost/internal/domain/entity"
-	"ezkost/internal/usecase"
+	"ezkost/internal/domain/repository"
 	"net/http"
-	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Tenant Handler
 type TenantHandler struct {
-	tenantUsecase usecase.TenantUsecase
+	usecases Usecases
 }
 
-func NewTenantHandler(tenantUsecase usecase.TenantUsecase) *TenantHandler {
-	return &TenantHandler{tenantUsecase: tenantUsecase}
+func NewTenantHandler(usecases Usecases) *TenantHandler {
+	return &TenantHandler{usecases: usecases}
 }
 
+type TransferRequest struct {
+	RoomID uint       `json:"room_id" binding:"required"`
+	Date   *time.Time `json:"date"`
+	Reason string     `json:"reason"`
+}
+
+// GetAll lists a page of tenants. Filters: property_id, status and search,
+// which matches part of the name in any case; see pageQuery for paging and
+// sorting.
 func (h *TenantHandler) GetAll(c *gin.Context) {
-	tenants, err := h.tenantUsecase.GetAll()
+	query := repository.TenantQuery{
+		Status: c.Query("status"),
+		Search: strings.TrimSpace(c.Query("search")),
+	}
+	var ok bool
+	if query.PropertyID, ok = propertyFilter(c); !ok {
+		return
+	}
+	if query.Page, ok = pageQuery(c, repository.TenantSortFields); !ok {
+		return
+	}
+
+	tenants, total, err := h.usecases(c).Tenant.GetAll(query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, tenants)
+	respondPage(c, tenants, total, query.Page)
 }
 
 func (h *TenantHandler) GetByID(c *gin.Context) {
-	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
-	tenant, err := h.tenantUsecase.GetByID(uint(id))
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	tenant, err := h.usecases(c).Tenant.GetByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Tenant not found"})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, tenant)
@@ -39,13 +64,12 @@ func (h *TenantHandler) GetByID(c *gin.Context) {
 
 func (h *TenantHandler) Create(c *gin.Context) {
 	var tenant entity.Tenant
-	if err := c.ShouldBindJSON(&tenant); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &tenant) {
 		return
 	}
 
-	if err := h.tenantUsecase.Create(&tenant); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.usecases(c).Tenant.Create(&tenant); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -53,24 +77,26 @@ func (h *TenantHandler) Create(c *gin.Context) {
 }
 
 func (h *TenantHandler) Update(c *gin.Context) {
-	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
 
 	// Get old tenant data for room tracking
-	oldTenant, err := h.tenantUsecase.GetByID(uint(id))
+	oldTenant, err := h.usecases(c).Tenant.GetByID(id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Tenant not found"})
+		c.Error(err)
 		return
 	}
 
 	var tenant entity.Tenant
-	if err := c.ShouldBindJSON(&tenant); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &tenant) {
 		return
 	}
 
-	tenant.ID = uint(id)
-	if err := h.tenantUsecase.Update(oldTenant.RoomID, &tenant); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	tenant.ID = id
+	if err := h.usecases(c).Tenant.Update(oldTenant.RoomID, &tenant); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -78,10 +104,37 @@ func (h *TenantHandler) Update(c *gin.Context) {
 }
 
 func (h *TenantHandler) Delete(c *gin.Context) {
-	id, _ := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err := h.tenantUsecase.Delete(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.usecases(c).Tenant.Delete(id); err != nil {
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Tenant deleted successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "Tenant moved out successfully"})
+}
+
+func (h *TenantHandler) Transfer(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req TransferRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	date := time.Now()
+	if req.Date != nil {
+		date = *req.Date
+	}
+
+	tenant, err := h.usecases(c).Tenant.Transfer(id, req.RoomID, date, req.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, tenant)
 }
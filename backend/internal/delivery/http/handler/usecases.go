@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Usecases returns the usecases of the organization making the request.
+type Usecases func(c *gin.Context) *usecase.Usecases
+
+// OrganizationUsecases resolves usecases from the organization_id set by the
+// auth middleware, building them once per request. Changes are made on
+// behalf of the authenticated user.
+func OrganizationUsecases(factory usecase.UsecaseFactory) Usecases {
+	return func(c *gin.Context) *usecase.Usecases {
+		if u, ok := c.Get("usecases"); ok {
+			return u.(*usecase.Usecases)
+		}
+		u := factory(c.GetUint("organization_id"), RequestActor(c))
+		c.Set("usecases", u)
+		return u
+	}
+}
+
+// RequestActor is the authenticated user making the request.
+func RequestActor(c *gin.Context) entity.Actor {
+	return entity.Actor{
+		UserID: c.GetUint("user_id"),
+		Role:   c.GetString("role"),
+		IP:     c.ClientIP(),
+	}
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"ezkost/internal/domain/entity"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// User Handler
+type UserHandler struct {
+	usecases Usecases
+}
+
+func NewUserHandler(usecases Usecases) *UserHandler {
+	return &UserHandler{usecases: usecases}
+}
+
+type InviteUserRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required"`
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+func (h *UserHandler) GetAll(c *gin.Context) {
+	users, err := h.usecases(c).User.GetAll()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+func (h *UserHandler) Invite(c *gin.Context) {
+	var req InviteUserRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	invitation := &entity.Invitation{
+		Email:     req.Email,
+		Role:      req.Role,
+		InvitedBy: c.GetUint("user_id"),
+	}
+	token, err := h.usecases(c).User.Invite(invitation)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"invitation": invitation,
+		"token":      token,
+	})
+}
+
+func (h *UserHandler) GetInvitations(c *gin.Context) {
+	invitations, err := h.usecases(c).User.GetInvitations()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, invitations)
+}
+
+func (h *UserHandler) RevokeInvitation(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.usecases(c).User.RevokeInvitation(id); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation revoked successfully"})
+}
+
+func (h *UserHandler) ChangeRole(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req ChangeRoleRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.usecases(c).User.ChangeRole(id, req.Role)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *UserHandler) Deactivate(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	user, err := h.usecases(c).User.Deactivate(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *UserHandler) Activate(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	user, err := h.usecases(c).User.Activate(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// Unlock lets a user locked out after failed logins try again.
+func (h *UserHandler) Unlock(c *gin.Context) {
+	id, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+	user, err := h.usecases(c).User.Unlock(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/usecase"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Authorizer checks the permission matrix for the role set by Authenticate.
+// Denied requests get a 403 and are written to the organization's audit log.
+type Authorizer struct {
+	permissions usecase.PermissionMatrix
+	usecases    usecase.UsecaseFactory
+}
+
+func NewAuthorizer(permissions usecase.PermissionMatrix, usecases usecase.UsecaseFactory) *Authorizer {
+	return &Authorizer{permissions: permissions, usecases: usecases}
+}
+
+// Resource guards a route group. The action follows the HTTP method: GET and
+// HEAD read, DELETE deletes and every other method writes.
+func (a *Authorizer) Resource(resource string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		a.check(c, resource+":"+methodAction(c.Request.Method))
+	}
+}
+
+// Require guards a route with one explicit permission.
+func (a *Authorizer) Require(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		a.check(c, permission)
+	}
+}
+
+func (a *Authorizer) check(c *gin.Context, permission string) {
+	role := c.GetString("role")
+	if a.permissions.Allows(role, permission) {
+		c.Next()
+		return
+	}
+
+	a.recordDenial(c, role, permission)
+	Problem(c, http.StatusForbidden, "forbidden", "Insufficient permissions", gin.H{
+		"permission": permission,
+		"role":       role,
+	})
+}
+
+func (a *Authorizer) recordDenial(c *gin.Context, role, permission string) {
+	entry := &entity.AuditEntry{
+		UserID: c.GetUint("user_id"),
+		Role:   role,
+		Action: usecase.AuditAccessDenied,
+		IP:     c.ClientIP(),
+		Detail: fmt.Sprintf("%s %s needs %s", c.Request.Method, c.Request.URL.Path, permission),
+	}
+	entry.EntityType, _, _ = strings.Cut(permission, ":")
+	actor := entity.Actor{UserID: entry.UserID, Role: role, IP: entry.IP}
+	if err := a.usecases(c.GetUint("organization_id"), actor).Audit.Record(entry); err != nil {
+		log.Printf("Failed to audit denied request: %v", err)
+	}
+}
+
+func methodAction(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return usecase.ActionRead
+	case http.MethodDelete:
+		return usecase.ActionDelete
+	}
+	return usecase.ActionWrite
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/usecase"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// problemKinds gives the status and default code of each kind of domain
+// error.
+var problemKinds = []struct {
+	kind   error
+	status int
+	code   string
+}{
+	{entity.ErrNotFound, http.StatusNotFound, "not_found"},
+	{entity.ErrConflict, http.StatusConflict, "conflict"},
+	{entity.ErrValidation, http.StatusBadRequest, "validation_failed"},
+	{entity.ErrForbidden, http.StatusForbidden, "forbidden"},
+	{entity.ErrUnauthorized, http.StatusUnauthorized, "unauthorized"},
+}
+
+// Errors answers the error a handler recorded with c.Error as an RFC 7807
+// problem. Domain errors get the status of their kind and their own code; a
+// *usecase.RetryError gets 429 and Retry-After. Any other error is logged
+// and answered with a bare 500, so driver messages never reach clients.
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		err := c.Errors.Last().Err
+
+		var retry *usecase.RetryError
+		if errors.As(err, &retry) {
+			c.Header("Retry-After", strconv.Itoa(retry.RetrySeconds()))
+			Problem(c, http.StatusTooManyRequests, "rate_limited", err.Error(), gin.H{"retry_after": retry.RetrySeconds()})
+			return
+		}
+
+		// The outermost domain error decides, so a handler can restate an
+		// error as another kind by wrapping it
+		var domainErr *entity.Error
+		isDomain := errors.As(err, &domainErr)
+		for _, p := range problemKinds {
+			switch {
+			case isDomain && domainErr.Kind == p.kind:
+				Problem(c, p.status, domainErr.Code, domainErr.Message, nil)
+				return
+			case !isDomain && errors.Is(err, p.kind):
+				Problem(c, p.status, p.code, err.Error(), nil)
+				return
+			}
+		}
+
+		log.Printf("%s %s failed: %v", c.Request.Method, c.Request.URL.Path, err)
+		Problem(c, http.StatusInternalServerError, "internal_error", "something went wrong on our side", nil)
+	}
+}
+
+// Recovery answers a request whose handler panicked with the same 500
+// problem as any other unexpected error. Gin logs the panic and its stack.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+		if c.Writer.Written() {
+			c.Abort()
+			return
+		}
+		Problem(c, http.StatusInternalServerError, "internal_error", "something went wrong on our side", nil)
+	})
+}
+
+// Problem aborts the request with an RFC 7807 problem. code is the
+// machine-readable reason clients branch on and detail explains it to the
+// user; extensions are added as extra members.
+func Problem(c *gin.Context, status int, code, detail string, extensions gin.H) {
+	problem := gin.H{
+		"type":     "about:blank",
+		"title":    http.StatusText(status),
+		"status":   status,
+		"detail":   detail,
+		"code":     code,
+		"instance": c.Request.URL.Path,
+	}
+	for name, value := range extensions {
+		problem[name] = value
+	}
+	c.Header("Content-Type", "application/problem+json")
+	c.AbortWithStatusJSON(status, problem)
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"ezkost/internal/usecase"
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit limits requests per client IP. Refused requests get a 429 with
+// Retry-After. If the limiter fails the request is let through, so an
+// outage of a shared store does not lock everyone out.
+func RateLimit(limiter usecase.RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ok, retryAfter, err := limiter.Allow("ip:" + c.ClientIP())
+		if err != nil {
+			log.Printf("Rate limiter failed: %v", err)
+			c.Next()
+			return
+		}
+		if ok {
+			c.Next()
+			return
+		}
+
+		c.Error(&usecase.RetryError{Reason: "too many requests", RetryAfter: retryAfter})
+		c.Abort()
+	}
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"ezkost/internal/delivery/http/handler"
 	"ezkost/internal/delivery/http/middleware"
+	"ezkost/internal/domain/entity"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,52 +11,141 @@ import (
 func SetupRoutes(
 	r *gin.Engine,
 	authMiddleware *middleware.AuthMiddleware,
+	authorizer *middleware.Authorizer,
+	authRateLimit gin.HandlerFunc,
 	authHandler *handler.AuthHandler,
 	roomHandler *handler.RoomHandler,
 	tenantHandler *handler.TenantHandler,
 	paymentHandler *handler.PaymentHandler,
 	dashboardHandler *handler.DashboardHandler,
 	expenseHandler *handler.ExpenseHandler,
+	billingHandler *handler.BillingHandler,
+	depositHandler *handler.DepositHandler,
+	lateFeeHandler *handler.LateFeeHandler,
+	meteringHandler *handler.MeteringHandler,
+	documentHandler *handler.DocumentHandler,
+	leaseHandler *handler.LeaseHandler,
+	propertyHandler *handler.PropertyHandler,
+	organizationHandler *handler.OrganizationHandler,
+	userHandler *handler.UserHandler,
+	auditHandler *handler.AuditHandler,
 ) {
+	// Every error is answered as problem JSON
+	r.Use(middleware.Errors())
+	r.NoRoute(func(c *gin.Context) {
+		c.Error(entity.NotFound("route_not_found", "no route for %s %s", c.Request.Method, c.Request.URL.Path))
+	})
+
 	// API v1
 	v1 := r.Group("/api/v1")
 
 	// Public routes
-	auth := v1.Group("/auth")
+	auth := v1.Group("/auth", authRateLimit)
 	{
 		auth.POST("/login", authHandler.Login)
 		auth.POST("/register", authHandler.Register)
+		auth.POST("/2fa/verify", authHandler.VerifyTwoFactor)
+		auth.POST("/2fa/enroll", authHandler.EnrollTwoFactor)
+		auth.POST("/refresh", authHandler.Refresh)
+		auth.POST("/password/forgot", authHandler.ForgotPassword)
+		auth.POST("/password/reset", authHandler.ResetPassword)
+		auth.POST("/invitations/accept", authHandler.AcceptInvitation)
+	}
+
+	// Session, password and two-factor routes need a valid access token but no permission
+	session := v1.Group("/auth", authRateLimit, authMiddleware.Authenticate())
+	{
+		session.POST("/logout", authHandler.Logout)
+		session.POST("/logout-all", authHandler.LogoutAll)
+		session.POST("/password/change", authHandler.ChangePassword)
+		session.POST("/2fa/setup", authHandler.SetupTwoFactor)
+		session.POST("/2fa/enable", authHandler.EnableTwoFactor)
+		session.POST("/2fa/disable", authHandler.DisableTwoFactor)
+		session.POST("/2fa/recovery-codes", authHandler.RegenerateRecoveryCodes)
 	}
 
-	// Protected routes
+	// Protected routes; each group is guarded by the permission matrix
 	protected := v1.Group("")
 	protected.Use(authMiddleware.Authenticate())
 	{
 		// Dashboard
-		protected.GET("/dashboard/summary", dashboardHandler.GetSummary)
+		protected.GET("/dashboard/summary", authorizer.Resource("dashboard"), dashboardHandler.GetSummary)
+
+		// Organization
+		organization := protected.Group("/organization", authorizer.Resource("organization"))
+		{
+			organization.GET("", organizationHandler.Get)
+			organization.PUT("", organizationHandler.Update)
+		}
+
+		// Users
+		users := protected.Group("/users", authorizer.Resource("users"))
+		{
+			users.GET("", userHandler.GetAll)
+			users.PUT("/:id/role", userHandler.ChangeRole)
+			users.POST("/:id/deactivate", userHandler.Deactivate)
+			users.POST("/:id/activate", userHandler.Activate)
+			users.POST("/:id/unlock", userHandler.Unlock)
+			users.GET("/invitations", userHandler.GetInvitations)
+			users.POST("/invitations", userHandler.Invite)
+			users.DELETE("/invitations/:id", userHandler.RevokeInvitation)
+		}
+
+		// Properties
+		properties := protected.Group("/properties", authorizer.Resource("properties"))
+		{
+			properties.GET("", propertyHandler.GetAll)
+			properties.GET("/:id", propertyHandler.GetByID)
+			properties.POST("", propertyHandler.Create)
+			properties.PUT("/:id", propertyHandler.Update)
+			properties.DELETE("/:id", propertyHandler.Delete)
+		}
 
 		// Rooms
-		rooms := protected.Group("/rooms")
+		rooms := protected.Group("/rooms", authorizer.Resource("rooms"))
 		{
 			rooms.GET("", roomHandler.GetAll)
 			rooms.GET("/:id", roomHandler.GetByID)
 			rooms.POST("", roomHandler.Create)
 			rooms.PUT("/:id", roomHandler.Update)
 			rooms.DELETE("/:id", roomHandler.Delete)
+			rooms.GET("/:id/history", roomHandler.GetHistory)
 		}
 
 		// Tenants
-		tenants := protected.Group("/tenants")
+		tenants := protected.Group("/tenants", authorizer.Resource("tenants"))
 		{
 			tenants.GET("", tenantHandler.GetAll)
 			tenants.GET("/:id", tenantHandler.GetByID)
 			tenants.POST("", tenantHandler.Create)
 			tenants.PUT("/:id", tenantHandler.Update)
 			tenants.DELETE("/:id", tenantHandler.Delete)
+			tenants.POST("/:id/transfer", tenantHandler.Transfer)
+		}
+
+		// Deposit ledger
+		deposits := protected.Group("/tenants/:id/deposit", authorizer.Resource("deposits"))
+		{
+			deposits.GET("", depositHandler.GetStatement)
+			deposits.POST("/receipts", depositHandler.Receive)
+			deposits.POST("/deductions", depositHandler.Deduct)
+			deposits.POST("/settle", depositHandler.Settle)
 		}
 
+		// Leases
+		leases := protected.Group("/leases", authorizer.Resource("leases"))
+		{
+			leases.GET("", leaseHandler.GetAll)
+			leases.GET("/expiring", leaseHandler.GetExpiring)
+			leases.GET("/:id", leaseHandler.GetByID)
+			leases.POST("", leaseHandler.Create)
+			leases.POST("/:id/renew", leaseHandler.Renew)
+			leases.POST("/:id/terminate", leaseHandler.Terminate)
+		}
+		protected.GET("/tenants/:id/leases", authorizer.Resource("leases"), leaseHandler.GetByTenantID)
+
 		// Payments
-		payments := protected.Group("/payments")
+		payments := protected.Group("/payments", authorizer.Resource("payments"))
 		{
 			payments.GET("", paymentHandler.GetAll)
 			payments.GET("/:id", paymentHandler.GetByID)
@@ -63,10 +153,14 @@ func SetupRoutes(
 			payments.GET("/overdue", paymentHandler.GetOverdue)
 			payments.POST("", paymentHandler.Create)
 			payments.PUT("/:id", paymentHandler.Update)
+			payments.POST("/:id/transactions", paymentHandler.RecordTransaction)
+			payments.GET("/:id/receipt.pdf", documentHandler.Receipt)
+			payments.GET("/:id/invoice.pdf", documentHandler.Invoice)
 		}
+		protected.POST("/payments/:id/line-items/:item_id/waive", authorizer.Require("payments:waive"), lateFeeHandler.Waive)
 
 		// Expenses
-		expenses := protected.Group("/expenses")
+		expenses := protected.Group("/expenses", authorizer.Resource("expenses"))
 		{
 			expenses.GET("", expenseHandler.GetAll)
 			expenses.GET("/:id", expenseHandler.GetByID)
@@ -74,5 +168,40 @@ func SetupRoutes(
 			expenses.PUT("/:id", expenseHandler.Update)
 			expenses.DELETE("/:id", expenseHandler.Delete)
 		}
+
+		// Billing
+		billing := protected.Group("/billing", authorizer.Resource("billing"))
+		{
+			billing.POST("/run", billingHandler.Run)
+			billing.POST("/proration/preview", billingHandler.PreviewProration)
+		}
+
+		// Late fees
+		lateFees := protected.Group("/late-fees", authorizer.Resource("late-fees"))
+		{
+			lateFees.GET("/policy", lateFeeHandler.GetPolicy)
+			lateFees.PUT("/policy", lateFeeHandler.UpdatePolicy)
+			lateFees.POST("/run", lateFeeHandler.Run)
+		}
+
+		// Utilities
+		metering := protected.Group("", authorizer.Resource("metering"))
+		{
+			metering.GET("/rooms/:id/meter-readings", meteringHandler.GetReadingsByRoom)
+			metering.POST("/rooms/:id/meter-readings", meteringHandler.RecordReading)
+			metering.GET("/meter-readings/flagged", meteringHandler.GetFlagged)
+			metering.GET("/utility-tariffs", meteringHandler.GetTariffs)
+			metering.PUT("/utility-tariffs/:utility", meteringHandler.SaveTariff)
+		}
+
+		// Settings
+		settings := protected.Group("/settings", authorizer.Resource("settings"))
+		{
+			settings.GET("/letterhead", documentHandler.GetLetterhead)
+			settings.PUT("/letterhead", documentHandler.UpdateLetterhead)
+		}
+
+		// Audit trail; entries are read-only
+		protected.GET("/audit", authorizer.Resource("audit"), auditHandler.Search)
 	}
 }
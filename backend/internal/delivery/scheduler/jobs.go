@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/usecase"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BillingJob generates the current month's rent payments of every
+// organization.
+func BillingJob(organizationUsecase usecase.OrganizationUsecase, usecases usecase.UsecaseFactory, interval time.Duration) Job {
+	return Job{
+		Name:     "billing",
+		Interval: interval,
+		Run: forEachOrganization(organizationUsecase, usecases, func(u *usecase.Usecases) (string, error) {
+			result, err := u.Billing.RunMonthly(time.Now())
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s: %d created, %d skipped", result.Period, len(result.Created), result.Skipped), nil
+		}),
+	}
+}
+
+// LateFeeJob charges late fees on overdue payments of every organization.
+func LateFeeJob(organizationUsecase usecase.OrganizationUsecase, usecases usecase.UsecaseFactory, interval time.Duration) Job {
+	return Job{
+		Name:     "late-fees",
+		Interval: interval,
+		Run: forEachOrganization(organizationUsecase, usecases, func(u *usecase.Usecases) (string, error) {
+			result, err := u.LateFee.Apply(time.Now())
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d charged, %d updated, %d skipped", result.Charged, result.Updated, result.Skipped), nil
+		}),
+	}
+}
+
+// forEachOrganization runs fn with each organization's usecases. A failing
+// organization does not stop the others; its error is reported with the rest.
+func forEachOrganization(
+	organizationUsecase usecase.OrganizationUsecase,
+	usecases usecase.UsecaseFactory,
+	fn func(u *usecase.Usecases) (string, error),
+) func() (string, error) {
+	return func() (string, error) {
+		organizations, err := organizationUsecase.GetAll()
+		if err != nil {
+			return "", err
+		}
+
+		var summaries []string
+		var errs []error
+		for _, organization := range organizations {
+			summary, err := fn(usecases(organization.ID, entity.SystemActor))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", organization.Name, err))
+				continue
+			}
+			summaries = append(summaries, fmt.Sprintf("%s %s", organization.Name, summary))
+		}
+		return strings.Join(summaries, "; "), errors.Join(errs...)
+	}
+}
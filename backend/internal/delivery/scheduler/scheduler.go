@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+)
+
+// Job is a background task that is safe to run repeatedly.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() (string, error)
+}
+
+// Scheduler runs registered jobs in-process, each on its own ticker.
+type Scheduler struct {
+	jobs []Job
+	stop chan struct{}
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{stop: make(chan struct{})}
+}
+
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every job immediately and then on each of its ticks.
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		go s.loop(job)
+	}
+}
+
+func (s *Scheduler) Stop() {
+	close(s.stop)
+}
+
+func (s *Scheduler) loop(job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	s.run(job)
+	for {
+		select {
+		case <-ticker.C:
+			s.run(job)
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *Scheduler) run(job Job) {
+	summary, err := job.Run()
+	if err != nil {
+		log.Printf("Job %s failed: %v", job.Name, err)
+		return
+	}
+	log.Printf("Job %s: %s", job.Name, summary)
+}
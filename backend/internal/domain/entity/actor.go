@@ -0,0 +1,12 @@
+package entity
+
+// Actor is who makes changes: the user behind a request, or the system for
+// scheduled jobs. Changes are recorded in the audit trail under the actor.
+type Actor struct {
+	UserID uint
+	Role   string
+	IP     string
+}
+
+// SystemActor makes the changes of scheduled jobs.
+var SystemActor = Actor{Role: "system"}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry records something a user did, or tried to do, in their
+// organization. Entries are only ever appended. For changes to data, Before
+// and After hold the changed columns as JSON objects: only After for a
+// create, only Before for a delete.
+type AuditEntry struct {
+	ID         uint
+	UserID     uint
+	Role       string
+	Action     string
+	EntityType string
+	EntityID   uint
+	IP         string
+	Detail     string
+	Before     json.RawMessage
+	After      json.RawMessage
+	CreatedAt  time.Time
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+type Deposit struct {
+	ID        uint
+	TenantID  uint
+	Status    string
+	ClosedAt  *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Entries   []DepositEntry
+}
+
+type DepositEntry struct {
+	ID        uint
+	DepositID uint
+	Type      string
+	Amount    Money
+	Reason    string
+	PaymentID *uint
+	CreatedAt time.Time
+}
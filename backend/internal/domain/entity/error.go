@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of domain errors. Match an error against them with errors.Is; the
+// delivery layer answers each kind with its own status code, and anything
+// else as an internal error.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrForbidden    = errors.New("forbidden")
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// Error is a domain error of one kind. Code is a machine-readable reason such
+// as "room_occupied"; Message explains it to the user. Err is the cause, if
+// any, e.g. the database error a repository translated.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether the error is of the target kind.
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NotFound reports a record that does not exist, or that the caller may not
+// know exists.
+func NotFound(code, format string, args ...any) error {
+	return newError(ErrNotFound, code, format, args)
+}
+
+// Conflict reports a request that clashes with the current state, such as a
+// duplicate or a record still in use.
+func Conflict(code, format string, args ...any) error {
+	return newError(ErrConflict, code, format, args)
+}
+
+// Invalid reports input that breaks a rule, whatever the current state.
+func Invalid(code, format string, args ...any) error {
+	return newError(ErrValidation, code, format, args)
+}
+
+// Forbidden reports an action the caller is not allowed to take.
+func Forbidden(code, format string, args ...any) error {
+	return newError(ErrForbidden, code, format, args)
+}
+
+// Unauthorized reports a caller whose credentials are missing or wrong.
+func Unauthorized(code, format string, args ...any) error {
+	return newError(ErrUnauthorized, code, format, args)
+}
+
+func newError(kind error, code, format string, args []any) error {
+	return &Error{Kind: kind, Code: code, Message: fmt.Sprintf(format, args...)}
+}
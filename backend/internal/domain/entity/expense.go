@@ -4,8 +4,9 @@ import "time"
 
 type Expense struct {
 	ID          uint
+	PropertyID  uint
 	Description string
-	Amount      float64
+	Amount      Money
 	ExpenseDate time.Time
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
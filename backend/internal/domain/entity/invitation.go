@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// Invitation lets someone join an organization with a given role. Only a hash
+// of its token is stored; the token works once, until ExpiresAt.
+type Invitation struct {
+	ID             uint
+	OrganizationID uint
+	Email          string
+	Role           string
+	TokenHash      string `json:"-"`
+	InvitedBy      uint
+	ExpiresAt      time.Time
+	AcceptedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
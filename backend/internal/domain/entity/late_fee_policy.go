@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type LateFeePolicy struct {
+	ID         uint
+	PropertyID uint
+	Type       string
+	Amount     Money
+	Percentage float64
+	Cap        Money
+	GraceDays  int
+	Enabled    bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+// Lease is the contract under which a tenant occupies a room. Rent is the
+// agreed monthly rent, which may differ from the room's current price.
+type Lease struct {
+	ID                uint
+	TenantID          uint
+	RoomID            uint
+	StartDate         time.Time
+	EndDate           time.Time
+	TermMonths        int
+	Rent              Money
+	BillingCycle      string
+	Deposit           Money
+	NoticeDays        int
+	Status            string
+	TerminatedAt      *time.Time
+	TerminationReason string
+	RenewedFromID     *uint
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Tenant            *Tenant
+	Room              *Room
+}
+
+// CycleMonths is the number of months covered by one bill.
+func (l *Lease) CycleMonths() int {
+	switch l.BillingCycle {
+	case "quarterly":
+		return 3
+	case "yearly":
+		return 12
+	}
+	return 1
+}
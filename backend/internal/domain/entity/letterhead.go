@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type Letterhead struct {
+	ID        uint
+	Name      string
+	Address   string
+	Phone     string
+	Email     string
+	Footer    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// LoginAttempt records a failed login. UserID is 0 when the email did not
+// belong to any user.
+type LoginAttempt struct {
+	ID        uint
+	UserID    uint
+	Email     string
+	IP        string
+	Reason    string
+	CreatedAt time.Time
+}
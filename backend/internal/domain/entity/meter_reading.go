@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type MeterReading struct {
+	ID          uint
+	RoomID      uint
+	Utility     string
+	Period      string
+	Reading     float64
+	Consumption float64
+	Charge      Money
+	Flag        string
+	PaymentID   *uint
+	RecordedBy  uint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
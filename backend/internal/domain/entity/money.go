@@ -0,0 +1,268 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// DefaultCurrency is used for amounts stored without an explicit currency.
+const DefaultCurrency = "IDR"
+
+// minorPerUnit is the number of minor units in one currency unit. Amounts are
+// kept to two decimal places, which covers IDR and every currency we bill in.
+const minorPerUnit = 100
+
+// Money is an exact amount held as an integer number of minor units
+// (1/100 of the currency unit) together with its ISO 4217 currency code.
+type Money struct {
+	Minor    int64
+	Currency string
+}
+
+// NewMoney returns an amount of whole currency units in the default currency.
+func NewMoney(units int64) Money {
+	return Money{Minor: units * minorPerUnit, Currency: DefaultCurrency}
+}
+
+// decimalPattern matches a plain decimal such as "-1250.50". Fractions and
+// exponents, which big.Rat also reads, are not amounts.
+var decimalPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// ParseMoney parses a decimal string such as "1500000" or "1250.50" in the
+// default currency. Digits past the second decimal place are rounded half away
+// from zero.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if !decimalPattern.MatchString(s) {
+		return Money{}, fmt.Errorf("invalid money amount %q", s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Money{}, fmt.Errorf("invalid money amount %q", s)
+	}
+	return moneyFromRat(r)
+}
+
+func moneyFromRat(r *big.Rat) (Money, error) {
+	minor := roundRat(new(big.Rat).Mul(r, big.NewRat(minorPerUnit, 1)))
+	if !minor.IsInt64() {
+		return Money{}, errors.New("money amount out of range")
+	}
+	return Money{Minor: minor.Int64(), Currency: DefaultCurrency}, nil
+}
+
+// roundRat rounds half away from zero to an integer.
+func roundRat(r *big.Rat) *big.Int {
+	num := new(big.Int).Set(r.Num())
+	den := r.Denom()
+	twice := new(big.Int).Mul(num, big.NewInt(2))
+	if num.Sign() >= 0 {
+		twice.Add(twice, den)
+	} else {
+		twice.Sub(twice, den)
+	}
+	return twice.Quo(twice, new(big.Int).Mul(den, big.NewInt(2)))
+}
+
+func (m Money) currency() string {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+func (m Money) with(minor int64) Money {
+	return Money{Minor: minor, Currency: m.currency()}
+}
+
+func (m Money) mustMatch(o Money) {
+	if m.currency() != o.currency() {
+		panic(fmt.Sprintf("money: currency mismatch %s and %s", m.currency(), o.currency()))
+	}
+}
+
+func (m Money) Add(o Money) Money {
+	m.mustMatch(o)
+	return m.with(m.Minor + o.Minor)
+}
+
+func (m Money) Sub(o Money) Money {
+	m.mustMatch(o)
+	return m.with(m.Minor - o.Minor)
+}
+
+func (m Money) Neg() Money {
+	return m.with(-m.Minor)
+}
+
+// Mul multiplies the amount by a whole number, e.g. a daily fee by days late.
+func (m Money) Mul(n int64) Money {
+	return m.with(m.Minor * n)
+}
+
+// MulDiv returns m * num / den rounded to the nearest minor unit, which is how
+// partial periods are charged without drifting.
+func (m Money) MulDiv(num, den int64) Money {
+	r := new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(m.Minor), big.NewInt(num)), big.NewInt(den))
+	return m.with(roundRat(r).Int64())
+}
+
+// MulFloat multiplies the amount by a measured quantity such as kWh used,
+// rounding to the nearest minor unit.
+func (m Money) MulFloat(q float64) Money {
+	r := new(big.Rat).SetFloat64(q)
+	if r == nil {
+		return m.with(0)
+	}
+	r.Mul(r, big.NewRat(m.Minor, 1))
+	return m.with(roundRat(r).Int64())
+}
+
+// Percent returns pct percent of the amount rounded to the nearest minor unit.
+func (m Money) Percent(pct float64) Money {
+	r := new(big.Rat).SetFloat64(pct)
+	if r == nil {
+		return m.with(0)
+	}
+	r.Mul(r, big.NewRat(m.Minor, 100))
+	return m.with(roundRat(r).Int64())
+}
+
+// RoundTo rounds to the nearest multiple of unit, e.g. the nearest 1,000 IDR.
+func (m Money) RoundTo(unit Money) Money {
+	if unit.Minor <= 0 {
+		return m
+	}
+	r := big.NewRat(m.Minor, unit.Minor)
+	return m.with(roundRat(r).Int64() * unit.Minor)
+}
+
+func (m Money) Cmp(o Money) int {
+	m.mustMatch(o)
+	switch {
+	case m.Minor < o.Minor:
+		return -1
+	case m.Minor > o.Minor:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) LessThan(o Money) bool    { return m.Cmp(o) < 0 }
+func (m Money) GreaterThan(o Money) bool { return m.Cmp(o) > 0 }
+func (m Money) IsZero() bool             { return m.Minor == 0 }
+func (m Money) IsPositive() bool         { return m.Minor > 0 }
+func (m Money) IsNegative() bool         { return m.Minor < 0 }
+
+// MinMoney returns the smaller of two amounts.
+func MinMoney(a, b Money) Money {
+	if b.LessThan(a) {
+		return b
+	}
+	return a
+}
+
+// String formats the amount as a plain decimal, e.g. "1500000.00".
+func (m Money) String() string {
+	minor := m.Minor
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/minorPerUnit, minor%minorPerUnit)
+}
+
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON encodes the amount as a decimal string so no client has to
+// round-trip it through a float.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.String(), Currency: m.currency()})
+}
+
+// UnmarshalJSON accepts {"amount": "...", "currency": "..."} as well as a bare
+// number or decimal string in the default currency. Amounts are stored without
+// their currency, so any other currency is rejected.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = []byte(strings.TrimSpace(string(data)))
+	if string(data) == "null" {
+		*m = Money{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '{' {
+		var obj struct {
+			Amount   json.RawMessage `json:"amount"`
+			Currency string          `json:"currency"`
+		}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		if err := m.UnmarshalJSON(obj.Amount); err != nil {
+			return err
+		}
+		if obj.Currency != "" && !strings.EqualFold(obj.Currency, DefaultCurrency) {
+			return Invalid("unsupported_currency", "currency %s is not supported, amounts are in %s", obj.Currency, DefaultCurrency)
+		}
+		return nil
+	}
+
+	text := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value stores the amount in a numeric column.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan reads a numeric column. The currency is not stored per row and comes
+// back as DefaultCurrency.
+func (m *Money) Scan(src interface{}) error {
+	var (
+		parsed Money
+		err    error
+	)
+	switch v := src.(type) {
+	case nil:
+		parsed = Money{Currency: DefaultCurrency}
+	case []byte:
+		parsed, err = ParseMoney(string(v))
+	case string:
+		parsed, err = ParseMoney(v)
+	case int64:
+		parsed = NewMoney(v)
+	case float64:
+		r := new(big.Rat).SetFloat64(v)
+		if r == nil {
+			return fmt.Errorf("invalid money amount %v", v)
+		}
+		parsed, err = moneyFromRat(r)
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,84 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"errors"
+	"ezkost/internal/domain/entity"
+	"testing"
+)
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		want     string
+		wantCode string
+	}{
+		{"number", `1500000`, "1500000.00", ""},
+		{"decimal string", `"1250.505"`, "1250.51", ""},
+		{"object", `{"amount": "99.90", "currency": "IDR"}`, "99.90", ""},
+		{"object in lower case", `{"amount": 10, "currency": "idr"}`, "10.00", ""},
+		{"object without currency", `{"amount": "10"}`, "10.00", ""},
+		{"other currency", `{"amount": "10", "currency": "USD"}`, "", "unsupported_currency"},
+		{"not a number", `"ten"`, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m entity.Money
+			err := json.Unmarshal([]byte(tt.body), &m)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("decoded %s, want an error", m)
+				}
+				var domainErr *entity.Error
+				if tt.wantCode != "" && (!errors.As(err, &domainErr) || domainErr.Kind != entity.ErrValidation || domainErr.Code != tt.wantCode) {
+					t.Fatalf("got error %v, want %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.String() != tt.want || m.Currency != entity.DefaultCurrency {
+				t.Errorf("decoded %s %s, want %s %s", m, m.Currency, tt.want, entity.DefaultCurrency)
+			}
+		})
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"1500000", "1500000.00"},
+		{" 1250.50 ", "1250.50"},
+		{"-75.005", "-75.01"},
+		{"0.004", "0.00"},
+		{"1/3", ""},
+		{"1e6", ""},
+		{"1.5E2", ""},
+		{"+10", ""},
+		{".5", ""},
+		{"10.", ""},
+		{"1,000", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			m, err := entity.ParseMoney(tt.in)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("parsed %q as %s, want an error", tt.in, m)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if m.String() != tt.want {
+				t.Errorf("parsed %q as %s, want %s", tt.in, m, tt.want)
+			}
+		})
+	}
+}
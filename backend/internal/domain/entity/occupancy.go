@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// Occupancy is one stay of a tenant in a room. EndDate is nil while the
+// tenant still lives there.
+type Occupancy struct {
+	ID        uint
+	RoomID    uint
+	TenantID  uint
+	StartDate time.Time
+	EndDate   *time.Time
+	Reason    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Room      *Room
+	Tenant    *Tenant
+}
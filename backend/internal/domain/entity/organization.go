@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// Organization is one kost business. Users belong to exactly one organization
+// and only ever see its properties, rooms, tenants and money. RequireTwoFactor
+// makes every user enroll in two-factor authentication before they can log in.
+type Organization struct {
+	ID               uint
+	Name             string
+	RequireTwoFactor bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// PasswordReset lets a user who forgot their password choose a new one. The
+// token is mailed to the user and only its hash is stored.
+type PasswordReset struct {
+	ID        uint
+	UserID    uint
+	TokenHash string `json:"-"`
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
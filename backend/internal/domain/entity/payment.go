@@ -4,13 +4,70 @@ import "time"
 
 type Payment struct {
 	ID            uint
+	PropertyID    uint
 	TenantID      uint
-	Amount        float64
+	Amount        Money
 	DueDate       time.Time
 	PaidAt        *time.Time
 	Status        string
 	PaymentMethod string
+	Period        string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	Tenant        Tenant
+	LineItems     []PaymentLineItem
+	Transactions  []PaymentTransaction
+}
+
+// TotalDue is the base amount plus every line item that has not been waived.
+func (p *Payment) TotalDue() Money {
+	total := p.Amount
+	for _, item := range p.LineItems {
+		if !item.Waived {
+			total = total.Add(item.Amount)
+		}
+	}
+	return total
+}
+
+// AmountPaid is the sum of every transaction recorded against the bill.
+func (p *Payment) AmountPaid() Money {
+	paid := p.Amount.with(0)
+	for _, t := range p.Transactions {
+		paid = paid.Add(t.Amount)
+	}
+	return paid
+}
+
+// Balance is what is still owed on the bill, never negative.
+func (p *Payment) Balance() Money {
+	balance := p.TotalDue().Sub(p.AmountPaid())
+	if balance.IsNegative() {
+		return balance.with(0)
+	}
+	return balance
+}
+
+type PaymentLineItem struct {
+	ID           uint
+	PaymentID    uint
+	Type         string
+	Description  string
+	Amount       Money
+	Waived       bool
+	WaivedBy     *uint
+	WaivedAt     *time.Time
+	WaiverReason string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type PaymentTransaction struct {
+	ID        uint
+	PaymentID uint
+	Amount    Money
+	Method    string
+	PaidAt    time.Time
+	Note      string
+	CreatedAt time.Time
 }
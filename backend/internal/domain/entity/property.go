@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// Property is a kost building. Phone, Email and ReceiptPrefix are its own
+// settings, printed on and used to number its receipts.
+type Property struct {
+	ID            uint
+	Name          string
+	Address       string
+	Floors        int
+	Phone         string
+	Email         string
+	ReceiptPrefix string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type Receipt struct {
+	ID         uint
+	PropertyID uint
+	PaymentID  uint
+	Year       int
+	Sequence   int
+	Number     string
+	IssuedAt   time.Time
+	CreatedAt  time.Time
+}
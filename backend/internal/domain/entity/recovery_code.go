@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// RecoveryCode lets a user with two-factor authentication log in once
+// without their authenticator. Only its hash is stored.
+type RecoveryCode struct {
+	ID        uint
+	UserID    uint
+	CodeHash  string `json:"-"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
@@ -4,8 +4,10 @@ import "time"
 
 type Room struct {
 	ID         uint
+	PropertyID uint
 	RoomNumber string
-	Price      float64
+	Floor      int
+	Price      Money
 	Status     string
 	Facilities string
 	Notes      string
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// Session is one login of a user, e.g. one phone. Its refresh tokens form a
+// family: each refresh uses up the current token and issues the next one.
+// Access tokens name their session, so revoking it logs the device out.
+type Session struct {
+	ID            uint
+	UserID        uint
+	RevokedAt     *time.Time
+	RevokedReason string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// RefreshToken is a single-use token of a session. Only its hash is stored.
+type RefreshToken struct {
+	ID        uint
+	SessionID uint
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
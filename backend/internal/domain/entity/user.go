@@ -2,12 +2,26 @@ package entity
 
 import "time"
 
+// User is a login of an organization. Status is "active" or "inactive";
+// inactive users cannot log in. TOTPSecret is set when the user starts
+// enrolling in two-factor authentication, which only takes effect once
+// TwoFactorEnabled. TOTPLastStep is the time step of the last accepted code,
+// so a code cannot be used twice. FailedLogins counts failures since the last
+// successful login; once there are too many the account is locked until
+// LockedUntil.
 type User struct {
-	ID           uint
-	Name         string
-	Email        string
-	PasswordHash string
-	Role         string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID               uint
+	OrganizationID   uint
+	Name             string
+	Email            string
+	PasswordHash     string `json:"-"`
+	Role             string
+	Status           string
+	TwoFactorEnabled bool
+	TOTPSecret       string `json:"-"`
+	TOTPLastStep     int64  `json:"-"`
+	FailedLogins     int
+	LockedUntil      *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 }
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+type UtilityTariff struct {
+	ID                    uint
+	Utility               string
+	Unit                  string
+	FixedCharge           Money
+	SpikeThresholdPercent float64
+	Tiers                 []TariffTier
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// TariffTier charges Rate per unit up to UpTo units of consumption in total;
+// an UpTo of zero means no upper limit.
+type TariffTier struct {
+	ID       uint
+	TariffID uint
+	UpTo     float64
+	Rate     Money
+}
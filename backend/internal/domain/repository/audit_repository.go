@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+// AuditFilter selects audit entries; zero fields do not filter. From is
+// inclusive and To exclusive.
+type AuditFilter struct {
+	EntityType string
+	EntityID   uint
+	UserID     uint
+	Action     string
+	From       time.Time
+	To         time.Time
+	Limit      int
+}
+
+// AuditRepository only appends and reads: the audit trail is never changed.
+type AuditRepository interface {
+	Create(entry *entity.AuditEntry) error
+	// Find returns the newest entries matching the filter first.
+	Find(filter AuditFilter) ([]entity.AuditEntry, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+)
+
+type DepositRepository interface {
+	Create(deposit *entity.Deposit) error
+	FindByTenantID(tenantID uint) (*entity.Deposit, error)
+	Update(deposit *entity.Deposit) error
+	AddEntry(entry *entity.DepositEntry) error
+}
@@ -5,11 +5,26 @@ import (
 	"time"
 )
 
+// ExpenseQuery selects expenses; zero fields do not filter. From is
+// inclusive and To exclusive.
+type ExpenseQuery struct {
+	PropertyID uint
+	From       time.Time
+	To         time.Time
+	Page
+}
+
+// ExpenseSortFields are the fields expenses can be sorted by. Expenses are
+// listed newest first unless sorted otherwise.
+var ExpenseSortFields = []string{"id", "expense_date", "amount", "description", "created_at"}
+
+// A propertyID of 0 means every property.
 type ExpenseRepository interface {
 	Create(expense *entity.Expense) error
-	FindAll() ([]entity.Expense, error)
+	// Find returns a page of the matching expenses and how many match in all.
+	Find(query ExpenseQuery) ([]entity.Expense, int64, error)
 	FindByID(id uint) (*entity.Expense, error)
 	Update(expense *entity.Expense) error
 	Delete(id uint) error
-	SumByPeriod(start, end time.Time) (float64, error)
+	SumByPeriod(start, end time.Time, propertyID uint) (entity.Money, error)
 }
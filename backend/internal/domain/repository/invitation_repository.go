@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type InvitationRepository interface {
+	Create(invitation *entity.Invitation) error
+	// FindPending lists invitations that were neither accepted nor expired
+	// at now.
+	FindPending(now time.Time) ([]entity.Invitation, error)
+	FindByID(id uint) (*entity.Invitation, error)
+	// FindByTokenHash returns nil, nil when no invitation has the hash.
+	FindByTokenHash(hash string) (*entity.Invitation, error)
+	Update(invitation *entity.Invitation) error
+	Delete(id uint) error
+}
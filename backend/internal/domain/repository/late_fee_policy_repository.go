@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+)
+
+type LateFeePolicyRepository interface {
+	// Get returns the policy of the property, or a disabled default when
+	// none is saved yet.
+	Get(propertyID uint) (*entity.LateFeePolicy, error)
+	// Save stores the policy of policy.PropertyID.
+	Save(policy *entity.LateFeePolicy) error
+}
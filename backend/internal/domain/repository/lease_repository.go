@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type LeaseRepository interface {
+	Create(lease *entity.Lease) error
+	FindAll() ([]entity.Lease, error)
+	FindByID(id uint) (*entity.Lease, error)
+	FindByTenantID(tenantID uint) ([]entity.Lease, error)
+	// FindForTenantOn returns the latest lease of the tenant started on or
+	// before date, or nil when the tenant has none.
+	FindForTenantOn(tenantID uint, date time.Time) (*entity.Lease, error)
+	FindExpiring(from, to time.Time) ([]entity.Lease, error)
+	Update(lease *entity.Lease) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+)
+
+type LetterheadRepository interface {
+	Get() (*entity.Letterhead, error)
+	Save(letterhead *entity.Letterhead) error
+}
@@ -0,0 +1,7 @@
+package repository
+
+import "ezkost/internal/domain/entity"
+
+type LoginAttemptRepository interface {
+	Create(attempt *entity.LoginAttempt) error
+}
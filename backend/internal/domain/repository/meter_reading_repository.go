@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+)
+
+type MeterReadingRepository interface {
+	Create(reading *entity.MeterReading) error
+	Update(reading *entity.MeterReading) error
+	FindByRoomID(roomID uint) ([]entity.MeterReading, error)
+	FindPrevious(roomID uint, utility, period string) (*entity.MeterReading, error)
+	FindUnbilled(roomID uint, period string) ([]entity.MeterReading, error)
+	FindFlagged() ([]entity.MeterReading, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+)
+
+type OccupancyRepository interface {
+	Create(occupancy *entity.Occupancy) error
+	FindByRoomID(roomID uint) ([]entity.Occupancy, error)
+	// FindOpenByTenantID returns the tenant's current stay, or nil when the
+	// tenant is not living in any room.
+	FindOpenByTenantID(tenantID uint) (*entity.Occupancy, error)
+	Update(occupancy *entity.Occupancy) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+)
+
+type OrganizationRepository interface {
+	Create(organization *entity.Organization) error
+	FindAll() ([]entity.Organization, error)
+	FindByID(id uint) (*entity.Organization, error)
+	Update(organization *entity.Organization) error
+}
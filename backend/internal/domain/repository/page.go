@@ -0,0 +1,16 @@
+package repository
+
+// Sort orders a list by one field, e.g. "due_date". Lists are sorted by each
+// field in turn, then by id.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Page selects part of a list: Limit rows after skipping Offset, in Sort
+// order. A Limit of 0 returns every row.
+type Page struct {
+	Limit  int
+	Offset int
+	Sort   []Sort
+}
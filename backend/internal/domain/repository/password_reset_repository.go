@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type PasswordResetRepository interface {
+	Create(reset *entity.PasswordReset) error
+	// FindByTokenHash returns nil, nil when no reset has the hash.
+	FindByTokenHash(hash string) (*entity.PasswordReset, error)
+	// Use marks a reset used and reports false if it already was.
+	Use(id uint, at time.Time) (bool, error)
+}
@@ -5,13 +5,39 @@ import (
 	"time"
 )
 
+// PaymentQuery selects payments; zero fields do not filter. RoomID matches
+// payments of the tenants now in the room, and Method payments marked with
+// or paid through a transaction with that method. DueFrom is inclusive and
+// DueTo exclusive.
+type PaymentQuery struct {
+	PropertyID uint
+	TenantID   uint
+	RoomID     uint
+	Status     string
+	Method     string
+	DueFrom    time.Time
+	DueTo      time.Time
+	Page
+}
+
+// PaymentSortFields are the fields payments can be sorted by.
+var PaymentSortFields = []string{"id", "due_date", "amount", "status", "period", "paid_at", "created_at"}
+
+// A propertyID of 0 means every property.
 type PaymentRepository interface {
 	Create(payment *entity.Payment) error
-	FindAll() ([]entity.Payment, error)
+	// Find returns a page of the matching payments and how many match in all.
+	Find(query PaymentQuery) ([]entity.Payment, int64, error)
 	FindByID(id uint) (*entity.Payment, error)
 	FindByTenantID(tenantID uint) ([]entity.Payment, error)
 	FindOverdue(now time.Time) ([]entity.Payment, error)
+	ExistsForPeriod(tenantID uint, period string) (bool, error)
+	FindByTenantAndPeriod(tenantID uint, period string) (*entity.Payment, error)
 	Update(payment *entity.Payment) error
-	CountOverdue(now time.Time) (int64, error)
-	SumPaidByPeriod(start, end time.Time) (float64, error)
+	CountOverdue(now time.Time, propertyID uint) (int64, error)
+	SumPaidByPeriod(start, end time.Time, propertyID uint) (entity.Money, error)
+	AddLineItem(item *entity.PaymentLineItem) error
+	FindLineItemByID(id uint) (*entity.PaymentLineItem, error)
+	UpdateLineItem(item *entity.PaymentLineItem) error
+	AddTransaction(transaction *entity.PaymentTransaction) error
 }
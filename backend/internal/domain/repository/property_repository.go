@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+)
+
+type PropertyRepository interface {
+	Create(property *entity.Property) error
+	FindAll() ([]entity.Property, error)
+	FindByID(id uint) (*entity.Property, error)
+	Update(property *entity.Property) error
+	Delete(id uint) error
+	CountRooms(id uint) (int64, error)
+}
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+)
+
+type ReceiptRepository interface {
+	FindByPaymentID(paymentID uint) (*entity.Receipt, error)
+	Issue(receipt *entity.Receipt, prefix string) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type RecoveryCodeRepository interface {
+	// Replace discards the user's codes and stores the given ones.
+	Replace(userID uint, codes []entity.RecoveryCode) error
+	// Use marks the user's unused code with the hash used and reports
+	// whether there was one.
+	Use(userID uint, hash string, at time.Time) (bool, error)
+}
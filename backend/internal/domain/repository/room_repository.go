@@ -4,13 +4,25 @@ import (
 	"ezkost/internal/domain/entity"
 )
 
+// RoomQuery selects rooms; zero fields do not filter.
+type RoomQuery struct {
+	PropertyID uint
+	Status     string
+	Page
+}
+
+// RoomSortFields are the fields rooms can be sorted by.
+var RoomSortFields = []string{"id", "room_number", "floor", "price", "status", "created_at"}
+
+// A propertyID of 0 means every property.
 type RoomRepository interface {
 	Create(room *entity.Room) error
-	FindAll() ([]entity.Room, error)
+	// Find returns a page of the matching rooms and how many match in all.
+	Find(query RoomQuery) ([]entity.Room, int64, error)
 	FindByID(id uint) (*entity.Room, error)
 	Update(room *entity.Room) error
 	UpdateStatus(id uint, status string) error
 	Delete(id uint) error
-	Count() (int64, error)
-	CountByStatus(status string) (int64, error)
+	Count(propertyID uint) (int64, error)
+	CountByStatus(status string, propertyID uint) (int64, error)
 }
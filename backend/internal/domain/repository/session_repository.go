@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type SessionRepository interface {
+	Create(session *entity.Session) error
+	FindByID(id uint) (*entity.Session, error)
+	// Revoke revokes one session; RevokeByUserID every open session of a
+	// user and RevokeOthers every one but keepID.
+	Revoke(id uint, at time.Time, reason string) error
+	RevokeByUserID(userID uint, at time.Time, reason string) error
+	RevokeOthers(userID, keepID uint, at time.Time, reason string) error
+
+	AddRefreshToken(token *entity.RefreshToken) error
+	// FindRefreshToken returns nil, nil when no token has the hash.
+	FindRefreshToken(hash string) (*entity.RefreshToken, error)
+	// UseRefreshToken marks a token used and reports false if it already
+	// was, so each token can only be exchanged once.
+	UseRefreshToken(id uint, at time.Time) (bool, error)
+}
@@ -4,11 +4,27 @@ import (
 	"ezkost/internal/domain/entity"
 )
 
+// TenantQuery selects tenants; zero fields do not filter. Search matches
+// part of the name, ignoring case.
+type TenantQuery struct {
+	PropertyID uint
+	Status     string
+	Search     string
+	Page
+}
+
+// TenantSortFields are the fields tenants can be sorted by.
+var TenantSortFields = []string{"id", "name", "start_date", "end_date", "status", "created_at"}
+
+// A propertyID of 0 means every property.
 type TenantRepository interface {
 	Create(tenant *entity.Tenant) error
-	FindAll() ([]entity.Tenant, error)
+	// Find returns a page of the matching tenants and how many match in all.
+	Find(query TenantQuery) ([]entity.Tenant, int64, error)
 	FindByID(id uint) (*entity.Tenant, error)
+	FindByStatus(status string) ([]entity.Tenant, error)
 	Update(tenant *entity.Tenant) error
 	Delete(id uint) error
-	CountByStatus(status string) (int64, error)
+	CountByStatus(status string, propertyID uint) (int64, error)
+	AdjustCredit(id uint, delta entity.Money) error
 }
@@ -0,0 +1,34 @@
+package repository
+
+// Repositories bundles every repository so a unit of work can hand out a set
+// bound to one transaction.
+type Repositories struct {
+	Organizations   OrganizationRepository
+	Users           UserRepository
+	Properties      PropertyRepository
+	Rooms           RoomRepository
+	Tenants         TenantRepository
+	Payments        PaymentRepository
+	Expenses        ExpenseRepository
+	Deposits        DepositRepository
+	LateFeePolicies LateFeePolicyRepository
+	MeterReadings   MeterReadingRepository
+	UtilityTariffs  UtilityTariffRepository
+	Letterheads     LetterheadRepository
+	Receipts        ReceiptRepository
+	Leases          LeaseRepository
+	Occupancies     OccupancyRepository
+	Invitations     InvitationRepository
+	Audit           AuditRepository
+	Sessions        SessionRepository
+	PasswordResets  PasswordResetRepository
+	RecoveryCodes   RecoveryCodeRepository
+	LoginAttempts   LoginAttemptRepository
+}
+
+// UnitOfWork runs several repository calls atomically.
+type UnitOfWork interface {
+	// Do calls fn with repositories bound to a single transaction. The
+	// transaction is committed when fn returns nil and rolled back otherwise.
+	Do(fn func(repos *Repositories) error) error
+}
@@ -6,6 +6,7 @@ import (
 
 type UserRepository interface {
 	Create(user *entity.User) error
+	FindAll() ([]entity.User, error)
 	FindByEmail(email string) (*entity.User, error)
 	FindByID(id uint) (*entity.User, error)
 	Update(user *entity.User) error
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+)
+
+type UtilityTariffRepository interface {
+	FindAll() ([]entity.UtilityTariff, error)
+	FindByUtility(utility string) (*entity.UtilityTariff, error)
+	Save(tariff *entity.UtilityTariff) error
+}
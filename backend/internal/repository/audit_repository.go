@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+
+	"gorm.io/gorm"
+)
+
+// Audit Repository Implementation
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) repository.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) Create(entry *entity.AuditEntry) error {
+	m := &model.AuditEntry{}
+	m.FromEntity(entry)
+	if err := r.db.Create(m).Error; err != nil {
+		return err
+	}
+	*entry = *m.ToEntity()
+	return nil
+}
+
+func (r *auditRepository) Find(filter repository.AuditFilter) ([]entity.AuditEntry, error) {
+	query := r.db.Model(&model.AuditEntry{})
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	var models []model.AuditEntry
+	if err := query.Order("created_at DESC, id DESC").Limit(filter.Limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]entity.AuditEntry, len(models))
+	for i, m := range models {
+		entities[i] = *m.ToEntity()
+	}
+	return entities, nil
+}
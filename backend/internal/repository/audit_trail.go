@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/repository/model"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Actions of audit entries written for changes to data
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+)
+
+// ErrAuditAppendOnly is returned for any attempt to change or remove audit
+// entries.
+var ErrAuditAppendOnly = entity.Forbidden("audit_append_only", "audit entries are append-only")
+
+type actorKey struct{}
+
+const auditBeforeKey = "ezkost:audit_before"
+
+// redactedColumns are never copied into audit entries; a change to them is
+// recorded without the values.
+var redactedColumns = map[string]bool{
+	"password_hash":  true,
+	"token_hash":     true,
+	"totp_secret":    true,
+	"totp_last_step": true,
+}
+
+// ignoredColumns change with every write and would only add noise.
+var ignoredColumns = map[string]bool{
+	"updated_at": true,
+}
+
+// ForActor returns db acting for actor. Once RegisterAuditTrail is installed,
+// every row of organization data created, updated or deleted through it is
+// recorded in the audit trail, in the same transaction as the change.
+func ForActor(db *gorm.DB, actor entity.Actor) *gorm.DB {
+	return db.WithContext(context.WithValue(db.Statement.Context, actorKey{}, actor))
+}
+
+// RegisterAuditTrail installs the callbacks that record changes made through
+// ForActor, and that refuse to update or delete audit entries.
+func RegisterAuditTrail(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().After("gorm:create").Before("gorm:commit_or_rollback_transaction").Register("ezkost:audit_create", auditCreate); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("ezkost:audit_update_before", func(db *gorm.DB) {
+		if refuseAuditChange(db) {
+			return
+		}
+		loadBefore(db)
+	}); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Before("gorm:commit_or_rollback_transaction").Register("ezkost:audit_update", auditUpdate); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("ezkost:audit_delete_before", func(db *gorm.DB) {
+		if refuseAuditChange(db) {
+			return
+		}
+		loadBefore(db)
+	}); err != nil {
+		return err
+	}
+	return callbacks.Delete().After("gorm:delete").Before("gorm:commit_or_rollback_transaction").Register("ezkost:audit_delete", auditDelete)
+}
+
+func refuseAuditChange(db *gorm.DB) bool {
+	if db.Statement.Table != (model.AuditEntry{}).TableName() {
+		return false
+	}
+	db.AddError(ErrAuditAppendOnly)
+	return true
+}
+
+// audited returns the actor of db if the statement changes organization data.
+func audited(db *gorm.DB) (entity.Actor, bool) {
+	actor, ok := db.Statement.Context.Value(actorKey{}).(entity.Actor)
+	s := db.Statement.Schema
+	if !ok || s == nil || s.PrioritizedPrimaryField == nil || s.LookUpField("OrganizationID") == nil {
+		return entity.Actor{}, false
+	}
+	return actor, s.Table != (model.AuditEntry{}).TableName()
+}
+
+func auditCreate(db *gorm.DB) {
+	actor, ok := audited(db)
+	if !ok || db.Error != nil || db.Statement.RowsAffected == 0 {
+		return
+	}
+
+	var entries []model.AuditEntry
+	eachRow(db.Statement.ReflectValue, func(row reflect.Value) {
+		entries = append(entries, newAuditEntry(db, actor, AuditCreate, row, nil, columnValues(db, row)))
+	})
+	writeAuditEntries(db, entries)
+}
+
+// loadBefore reads the rows an update or delete is about to change, using
+// the statement's conditions and the primary key of the model it was given.
+func loadBefore(db *gorm.DB) {
+	if _, ok := audited(db); !ok || db.Error != nil {
+		return
+	}
+
+	stmt := db.Statement
+	query := db.Session(&gorm.Session{NewDB: true})
+	conditioned := false
+	if where, ok := stmt.Clauses["WHERE"]; ok {
+		query = query.Clauses(where.Expression)
+		conditioned = true
+	}
+	if stmt.ReflectValue.Kind() == reflect.Struct {
+		pk := stmt.Schema.PrioritizedPrimaryField
+		if value, zero := pk.ValueOf(stmt.Context, stmt.ReflectValue); !zero {
+			query = query.Where(clause.Eq{Column: clause.Column{Table: stmt.Table, Name: pk.DBName}, Value: value})
+			conditioned = true
+		}
+	}
+	if !conditioned {
+		return
+	}
+
+	rows := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+	if err := query.Find(rows.Interface()).Error; err != nil {
+		db.AddError(err)
+		return
+	}
+	db.InstanceSet(auditBeforeKey, rows.Elem())
+}
+
+func beforeRows(db *gorm.DB) (reflect.Value, bool) {
+	v, ok := db.InstanceGet(auditBeforeKey)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	rows := v.(reflect.Value)
+	return rows, rows.Len() > 0
+}
+
+// auditUpdate reads the changed rows again and records the columns that
+// differ.
+func auditUpdate(db *gorm.DB) {
+	actor, ok := audited(db)
+	if !ok || db.Error != nil {
+		return
+	}
+	before, ok := beforeRows(db)
+	if !ok {
+		return
+	}
+
+	pk := db.Statement.Schema.PrioritizedPrimaryField
+	ids := make([]interface{}, before.Len())
+	for i := range ids {
+		ids[i], _ = pk.ValueOf(db.Statement.Context, before.Index(i))
+	}
+	after := reflect.New(before.Type())
+	err := db.Session(&gorm.Session{NewDB: true}).
+		Where(clause.IN{Column: clause.Column{Table: db.Statement.Table, Name: pk.DBName}, Values: ids}).
+		Find(after.Interface()).Error
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	afterByID := make(map[interface{}]reflect.Value)
+	eachRow(after.Elem(), func(row reflect.Value) {
+		id, _ := pk.ValueOf(db.Statement.Context, row)
+		afterByID[id] = row
+	})
+
+	var entries []model.AuditEntry
+	eachRow(before, func(row reflect.Value) {
+		id, _ := pk.ValueOf(db.Statement.Context, row)
+		changed, ok := afterByID[id]
+		if !ok {
+			return
+		}
+		oldValues, newValues := diffValues(columnValues(db, row), columnValues(db, changed))
+		if len(newValues) > 0 {
+			entries = append(entries, newAuditEntry(db, actor, AuditUpdate, row, oldValues, newValues))
+		}
+	})
+	writeAuditEntries(db, entries)
+}
+
+func auditDelete(db *gorm.DB) {
+	actor, ok := audited(db)
+	if !ok || db.Error != nil || db.Statement.RowsAffected == 0 {
+		return
+	}
+	before, ok := beforeRows(db)
+	if !ok {
+		return
+	}
+
+	var entries []model.AuditEntry
+	eachRow(before, func(row reflect.Value) {
+		entries = append(entries, newAuditEntry(db, actor, AuditDelete, row, columnValues(db, row), nil))
+	})
+	writeAuditEntries(db, entries)
+}
+
+func eachRow(rows reflect.Value, fn func(row reflect.Value)) {
+	switch rows.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rows.Len(); i++ {
+			fn(reflect.Indirect(rows.Index(i)))
+		}
+	case reflect.Struct:
+		fn(rows)
+	}
+}
+
+// columnValues maps the columns of a row to their JSON encoding.
+func columnValues(db *gorm.DB, row reflect.Value) map[string]json.RawMessage {
+	values := make(map[string]json.RawMessage)
+	for _, field := range db.Statement.Schema.Fields {
+		if field.DBName == "" || ignoredColumns[field.DBName] {
+			continue
+		}
+		value, zero := field.ValueOf(db.Statement.Context, row)
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		if redactedColumns[field.DBName] && !zero {
+			encoded = redacted(encoded)
+		}
+		values[field.DBName] = encoded
+	}
+	return values
+}
+
+// redacted replaces a secret with a fingerprint, so a change still shows in
+// the diff without revealing either value.
+func redacted(value []byte) json.RawMessage {
+	sum := sha256.Sum256(value)
+	return json.RawMessage(`"[redacted:` + hex.EncodeToString(sum[:4]) + `]"`)
+}
+
+func diffValues(before, after map[string]json.RawMessage) (map[string]json.RawMessage, map[string]json.RawMessage) {
+	oldValues := make(map[string]json.RawMessage)
+	newValues := make(map[string]json.RawMessage)
+	for column, value := range after {
+		if string(before[column]) != string(value) {
+			oldValues[column] = before[column]
+			newValues[column] = value
+		}
+	}
+	return oldValues, newValues
+}
+
+func newAuditEntry(db *gorm.DB, actor entity.Actor, action string, row reflect.Value, before, after map[string]json.RawMessage) model.AuditEntry {
+	id, _ := db.Statement.Schema.PrioritizedPrimaryField.ValueOf(db.Statement.Context, row)
+	return model.AuditEntry{
+		UserID:     actor.UserID,
+		Role:       actor.Role,
+		Action:     action,
+		EntityType: entityType(db, db.Statement.Schema),
+		EntityID:   toUint(id),
+		IP:         actor.IP,
+		Before:     encodeColumns(before),
+		After:      encodeColumns(after),
+		CreatedAt:  time.Now(),
+	}
+}
+
+// entityType names audited rows after their model, e.g. "payment_line_item".
+func entityType(db *gorm.DB, s *schema.Schema) string {
+	return db.NamingStrategy.ColumnName("", s.Name)
+}
+
+func encodeColumns(values map[string]json.RawMessage) string {
+	if values == nil {
+		return ""
+	}
+	encoded, _ := json.Marshal(values)
+	return string(encoded)
+}
+
+func toUint(v interface{}) uint {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uint(rv.Uint())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint(rv.Int())
+	}
+	return 0
+}
+
+// writeAuditEntries adds the entries through the statement's connection, so
+// they are committed or rolled back together with the change.
+func writeAuditEntries(db *gorm.DB, entries []model.AuditEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	if err := db.Session(&gorm.Session{NewDB: true}).Create(&entries).Error; err != nil {
+		db.AddError(err)
+	}
+}
@@ -0,0 +1,92 @@
+package repository_test
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	domainrepository "ezkost/internal/domain/repository"
+	"ezkost/internal/repository"
+	"ezkost/internal/repository/model"
+	"ezkost/internal/usecase"
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestAuditTrail(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, open func(t *testing.T) *gorm.DB) {
+		db := repository.ForActor(createOrganization(t, open(t), "Kos Melati"), entity.Actor{UserID: 7, Role: "owner", IP: "10.0.0.1"})
+		usecases := usecase.NewUsecases(repository.NewUnitOfWork(db), repository.NewRepositories(db), usecase.UsecaseOptions{})
+
+		property := &entity.Property{Name: "Kos Melati", Address: "Jl. Melati 1", Floors: 1}
+		mustDo(t, usecases.Property.Create(property))
+		expense := &entity.Expense{PropertyID: property.ID, Description: "Listrik", Amount: entity.NewMoney(500000), ExpenseDate: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)}
+		mustDo(t, usecases.Expense.Create(expense))
+		expense.Amount = entity.NewMoney(550000)
+		mustDo(t, usecases.Expense.Update(expense))
+		mustDo(t, usecases.Expense.Delete(expense.ID))
+
+		entries, err := usecases.Audit.Search(domainrepository.AuditFilter{EntityType: "expense", EntityID: expense.ID})
+		mustDo(t, err)
+		var actions []string
+		for _, entry := range entries {
+			actions = append(actions, entry.Action)
+			if entry.UserID != 7 || entry.Role != "owner" || entry.IP != "10.0.0.1" {
+				t.Errorf("entry = %+v, want it made by user 7 from 10.0.0.1", entry)
+			}
+		}
+		if want := []string{repository.AuditDelete, repository.AuditUpdate, repository.AuditCreate}; !reflect.DeepEqual(actions, want) {
+			t.Fatalf("actions = %v, want %v", actions, want)
+		}
+	})
+}
+
+func TestAuditTrailAppendOnly(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, open func(t *testing.T) *gorm.DB) {
+		db := repository.ForActor(createOrganization(t, open(t), "Kos Melati"), entity.Actor{UserID: 7, Role: "owner"})
+		audit := usecase.NewUsecases(repository.NewUnitOfWork(db), repository.NewRepositories(db), usecase.UsecaseOptions{}).Audit
+		mustDo(t, audit.Record(&entity.AuditEntry{UserID: 7, Action: usecase.AuditAccessDenied, Detail: "users:write"}))
+		before, err := audit.Search(domainrepository.AuditFilter{})
+		mustDo(t, err)
+		id := before[0].ID
+
+		tests := []struct {
+			name   string
+			change func() error
+		}{
+			{"update", func() error {
+				return db.Model(&model.AuditEntry{}).Where("id = ?", id).Update("detail", "rooms:read").Error
+			}},
+			{"save", func() error {
+				return db.Save(&model.AuditEntry{ID: id, UserID: 8, Action: usecase.AuditAccessDenied}).Error
+			}},
+			{"delete", func() error {
+				return db.Delete(&model.AuditEntry{}, id).Error
+			}},
+			{"delete all", func() error {
+				return db.Where("1 = 1").Delete(&model.AuditEntry{}).Error
+			}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if err := tt.change(); !errors.Is(err, repository.ErrAuditAppendOnly) {
+					t.Errorf("got error %v, want %v", err, repository.ErrAuditAppendOnly)
+				}
+				after, err := audit.Search(domainrepository.AuditFilter{})
+				mustDo(t, err)
+				if !reflect.DeepEqual(after, before) {
+					t.Errorf("entries = %+v, want %+v", after, before)
+				}
+			})
+		}
+
+		// Recording an entry with the ID of another adds a new one
+		mustDo(t, audit.Record(&entity.AuditEntry{ID: id, UserID: 8, Action: usecase.AuditAccessDenied, Detail: "rooms:delete"}))
+		after, err := audit.Search(domainrepository.AuditFilter{})
+		mustDo(t, err)
+		if len(after) != 2 || !reflect.DeepEqual(after[1], before[0]) {
+			t.Errorf("entries = %+v, want the first kept as it was", after)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+
+	"gorm.io/gorm"
+)
+
+// Deposit Repository Implementation
+type depositRepository struct {
+	db *gorm.DB
+}
+
+func NewDepositRepository(db *gorm.DB) repository.DepositRepository {
+	return &depositRepository{db: db}
+}
+
+func (r *depositRepository) Create(deposit *entity.Deposit) error {
+	m := &model.Deposit{}
+	m.FromEntity(deposit)
+	if err := r.db.Omit("Tenant").Create(m).Error; err != nil {
+		return err
+	}
+	*deposit = *m.ToEntity()
+	return nil
+}
+
+func (r *depositRepository) FindByTenantID(tenantID uint) (*entity.Deposit, error) {
+	var m model.Deposit
+	if err := r.db.Preload("Entries", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC, id ASC")
+	}).Where("tenant_id = ?", tenantID).First(&m).Error; err != nil {
+		return nil, err
+	}
+	return m.ToEntity(), nil
+}
+
+func (r *depositRepository) Update(deposit *entity.Deposit) error {
+	m := &model.Deposit{}
+	m.FromEntity(deposit)
+	return r.db.Omit("Tenant", "Entries", "CreatedAt").Save(m).Error
+}
+
+func (r *depositRepository) AddEntry(entry *entity.DepositEntry) error {
+	m := &model.DepositEntry{}
+	m.FromEntity(entry)
+	if err := r.db.Create(m).Error; err != nil {
+		return err
+	}
+	*entry = *m.ToEntity()
+	return nil
+}
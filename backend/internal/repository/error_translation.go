@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// pgErrors maps the Postgres error codes callers can act on to the GORM
+// errors they mean. Data exceptions (class 22, e.g. a string too long for
+// its column) are values the schema rejects, like a failed check.
+var pgErrors = map[string]error{
+	"23505": gorm.ErrDuplicatedKey,
+	"23503": gorm.ErrForeignKeyViolated,
+	"23502": gorm.ErrCheckConstraintViolated,
+	"23514": gorm.ErrCheckConstraintViolated,
+}
+
+// RegisterErrorTranslation installs the callbacks that pass every statement's
+// error through TranslateError, so repositories return domain errors.
+func RegisterErrorTranslation(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().After("*").Register("ezkost:translate_create", translateErrors(false)); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("*").Register("ezkost:translate_query", translateErrors(false)); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("*").Register("ezkost:translate_update", translateErrors(false)); err != nil {
+		return err
+	}
+	if err := callbacks.Row().After("*").Register("ezkost:translate_row", translateErrors(false)); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().After("*").Register("ezkost:translate_raw", translateErrors(false)); err != nil {
+		return err
+	}
+	return callbacks.Delete().After("*").Register("ezkost:translate_delete", translateErrors(true))
+}
+
+// translateErrors lets the dialect name its driver's error first, as SQLite
+// errors are only recognized by their code.
+func translateErrors(deleting bool) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error == nil {
+			return
+		}
+		err := db.Error
+		if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+			if translated := translator.Translate(err); !errors.Is(err, translated) {
+				err = fmt.Errorf("%w: %w", translated, err)
+			}
+		}
+		db.Error = TranslateError(err, db.Statement.Table, deleting)
+	}
+}
+
+// TranslateError turns a database error into a domain error: a missing row
+// becomes entity.ErrNotFound, a duplicate or a broken reference
+// entity.ErrConflict, and a value the schema rejects entity.ErrValidation.
+// table names the rows the statement worked on, and deleting tells a row that
+// is still in use apart from a reference to a row that does not exist. The
+// database error stays wrapped; other errors are returned as they are.
+func TranslateError(err error, table string, deleting bool) error {
+	var domainErr *entity.Error
+	if err == nil || errors.As(err, &domainErr) {
+		return err
+	}
+
+	kind := err
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if mapped, ok := pgErrors[pgErr.Code]; ok {
+			kind = mapped
+		} else if strings.HasPrefix(pgErr.Code, "22") {
+			kind = gorm.ErrCheckConstraintViolated
+		}
+	}
+
+	row := rowName(table)
+	switch {
+	case errors.Is(kind, gorm.ErrRecordNotFound):
+		domainErr = &entity.Error{Kind: entity.ErrNotFound, Code: "not_found", Message: row + " not found"}
+	case errors.Is(kind, gorm.ErrDuplicatedKey):
+		domainErr = &entity.Error{Kind: entity.ErrConflict, Code: "duplicate", Message: row + " already exists"}
+	case errors.Is(kind, gorm.ErrForeignKeyViolated) && deleting:
+		domainErr = &entity.Error{Kind: entity.ErrConflict, Code: "in_use", Message: row + " is still in use"}
+	case errors.Is(kind, gorm.ErrForeignKeyViolated):
+		domainErr = &entity.Error{Kind: entity.ErrConflict, Code: "missing_reference", Message: row + " refers to a record that does not exist"}
+	case errors.Is(kind, gorm.ErrCheckConstraintViolated):
+		domainErr = &entity.Error{Kind: entity.ErrValidation, Code: "invalid_value", Message: row + " has a missing or invalid value"}
+	default:
+		return err
+	}
+	domainErr.Err = err
+	return domainErr
+}
+
+// matchedRow returns the error of an update or delete, or a not found error
+// when it matched no row: the id does not exist or belongs to another
+// organization.
+func matchedRow(result *gorm.DB) error {
+	if result.Error == nil && result.RowsAffected == 0 {
+		return TranslateError(gorm.ErrRecordNotFound, result.Statement.Table, false)
+	}
+	return result.Error
+}
+
+// rowName names one row of a table for messages, e.g. "payment line item"
+// for payment_line_items.
+func rowName(table string) string {
+	switch {
+	case table == "":
+		return "record"
+	case strings.HasSuffix(table, "ies"):
+		table = strings.TrimSuffix(table, "ies") + "y"
+	case strings.HasSuffix(table, "s"):
+		table = strings.TrimSuffix(table, "s")
+	}
+	return strings.ReplaceAll(table, "_", " ")
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Expense Repository Implementation
@@ -28,17 +29,37 @@ func (r *expenseRepository) Create(expense *entity.Expense) error {
 	return nil
 }
 
-func (r *expenseRepository) FindAll() ([]entity.Expense, error) {
+// Find lists the newest expenses first unless the query sorts otherwise.
+func (r *expenseRepository) Find(query repository.ExpenseQuery) ([]entity.Expense, int64, error) {
+	var total int64
+	if err := r.matching(query).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if len(query.Sort) == 0 {
+		query.Sort = []repository.Sort{{Field: "expense_date", Desc: true}}
+	}
 	var models []model.Expense
-	if err := r.db.Order("expense_date DESC").Find(&models).Error; err != nil {
-		return nil, err
+	if err := paginate(r.matching(query), query.Page).Find(&models).Error; err != nil {
+		return nil, 0, err
 	}
 
 	entities := make([]entity.Expense, len(models))
 	for i, m := range models {
 		entities[i] = *m.ToEntity()
 	}
-	return entities, nil
+	return entities, total, nil
+}
+
+func (r *expenseRepository) matching(query repository.ExpenseQuery) *gorm.DB {
+	db := byProperty(r.db.Model(&model.Expense{}), "property_id", query.PropertyID)
+	if !query.From.IsZero() {
+		db = db.Where("expense_date >= ?", query.From)
+	}
+	if !query.To.IsZero() {
+		db = db.Where("expense_date < ?", query.To)
+	}
+	return db
 }
 
 func (r *expenseRepository) FindByID(id uint) (*entity.Expense, error) {
@@ -52,18 +73,19 @@ func (r *expenseRepository) FindByID(id uint) (*entity.Expense, error) {
 func (r *expenseRepository) Update(expense *entity.Expense) error {
 	m := &model.Expense{}
 	m.FromEntity(expense)
-	return r.db.Save(m).Error
+	return matchedRow(r.db.Model(m).Where("id = ?", m.ID).
+		Select("*").Omit("CreatedAt", clause.Associations).Updates(m))
 }
 
 func (r *expenseRepository) Delete(id uint) error {
-	return r.db.Delete(&model.Expense{}, id).Error
+	return matchedRow(r.db.Delete(&model.Expense{}, id))
 }
 
-func (r *expenseRepository) SumByPeriod(start, end time.Time) (float64, error) {
+func (r *expenseRepository) SumByPeriod(start, end time.Time, propertyID uint) (entity.Money, error) {
 	var result struct {
-		Total float64
+		Total entity.Money
 	}
-	err := r.db.Model(&model.Expense{}).
+	err := byProperty(r.db.Model(&model.Expense{}), "property_id", propertyID).
 		Select("COALESCE(SUM(amount), 0) as total").
 		Where("expense_date >= ? AND expense_date < ?", start, end).
 		Scan(&result).Error
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Invitation Repository Implementation
+type invitationRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationRepository(db *gorm.DB) repository.InvitationRepository {
+	return &invitationRepository{db: db}
+}
+
+func (r *invitationRepository) Create(invitation *entity.Invitation) error {
+	m := &model.Invitation{}
+	m.FromEntity(invitation)
+	if err := r.db.Create(m).Error; err != nil {
+		return err
+	}
+	*invitation = *m.ToEntity()
+	return nil
+}
+
+func (r *invitationRepository) FindPending(now time.Time) ([]entity.Invitation, error) {
+	var models []model.Invitation
+	err := r.db.Where("accepted_at IS NULL AND expires_at > ?", now).
+		Order("created_at DESC").Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]entity.Invitation, len(models))
+	for i, m := range models {
+		entities[i] = *m.ToEntity()
+	}
+	return entities, nil
+}
+
+func (r *invitationRepository) FindByID(id uint) (*entity.Invitation, error) {
+	var m model.Invitation
+	if err := r.db.First(&m, id).Error; err != nil {
+		return nil, err
+	}
+	return m.ToEntity(), nil
+}
+
+func (r *invitationRepository) FindByTokenHash(hash string) (*entity.Invitation, error) {
+	var models []model.Invitation
+	if err := r.db.Where("token_hash = ?", hash).Limit(1).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return models[0].ToEntity(), nil
+}
+
+func (r *invitationRepository) Update(invitation *entity.Invitation) error {
+	m := &model.Invitation{}
+	m.FromEntity(invitation)
+	return r.db.Omit("CreatedAt").Save(m).Error
+}
+
+func (r *invitationRepository) Delete(id uint) error {
+	return r.db.Delete(&model.Invitation{}, id).Error
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+
+	"gorm.io/gorm"
+)
+
+// Late Fee Policy Repository Implementation
+type lateFeePolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewLateFeePolicyRepository(db *gorm.DB) repository.LateFeePolicyRepository {
+	return &lateFeePolicyRepository{db: db}
+}
+
+// Get returns the stored policy of the property, or a disabled default when
+// none is saved yet.
+func (r *lateFeePolicyRepository) Get(propertyID uint) (*entity.LateFeePolicy, error) {
+	var models []model.LateFeePolicy
+	if err := r.db.Where("property_id = ?", propertyID).Limit(1).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return &entity.LateFeePolicy{PropertyID: propertyID, Type: "flat"}, nil
+	}
+	return models[0].ToEntity(), nil
+}
+
+func (r *lateFeePolicyRepository) Save(policy *entity.LateFeePolicy) error {
+	m := &model.LateFeePolicy{}
+	m.FromEntity(policy)
+	if err := r.db.Save(m).Error; err != nil {
+		return err
+	}
+	*policy = *m.ToEntity()
+	return nil
+}
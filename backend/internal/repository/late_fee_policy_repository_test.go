@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/repository"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestLateFeePolicyRepository(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, open func(t *testing.T) *gorm.DB) {
+		policies := repository.NewLateFeePolicyRepository(createOrganization(t, open(t), "Kos Melati"))
+
+		flat := &entity.LateFeePolicy{PropertyID: 1, Type: "flat", Amount: entity.NewMoney(50000), Enabled: true}
+		perDay := &entity.LateFeePolicy{PropertyID: 2, Type: "per_day", Amount: entity.NewMoney(1000), GraceDays: 3}
+		for _, policy := range []*entity.LateFeePolicy{flat, perDay} {
+			if err := policies.Save(policy); err != nil {
+				t.Fatal(err)
+			}
+		}
+		flat.Amount = entity.NewMoney(75000)
+		if err := policies.Save(flat); err != nil {
+			t.Fatal(err)
+		}
+
+		tests := []struct {
+			propertyID uint
+			wantID     uint
+			wantType   string
+			wantAmount string
+		}{
+			{1, flat.ID, "flat", "75000.00"},
+			{2, perDay.ID, "per_day", "1000.00"},
+			{3, 0, "flat", "0.00"},
+		}
+		for _, tt := range tests {
+			policy, err := policies.Get(tt.propertyID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if policy.ID != tt.wantID || policy.PropertyID != tt.propertyID || policy.Type != tt.wantType || policy.Amount.String() != tt.wantAmount {
+				t.Errorf("policy of property %d = %+v", tt.propertyID, policy)
+			}
+		}
+
+		duplicate := &entity.LateFeePolicy{PropertyID: 2, Type: "flat"}
+		if err := policies.Save(duplicate); err == nil {
+			t.Error("second policy for property 2 was stored")
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Lease Repository Implementation
+type leaseRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaseRepository(db *gorm.DB) repository.LeaseRepository {
+	return &leaseRepository{db: db}
+}
+
+func (r *leaseRepository) Create(lease *entity.Lease) error {
+	m := &model.Lease{}
+	m.FromEntity(lease)
+	if err := r.db.Omit("Tenant", "Room").Create(m).Error; err != nil {
+		return err
+	}
+	*lease = *m.ToEntity()
+	return nil
+}
+
+func (r *leaseRepository) FindAll() ([]entity.Lease, error) {
+	var models []model.Lease
+	if err := r.db.Preload("Tenant").Preload("Room").
+		Order("start_date DESC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return toLeaseEntities(models), nil
+}
+
+func (r *leaseRepository) FindByID(id uint) (*entity.Lease, error) {
+	var m model.Lease
+	if err := r.db.Preload("Tenant").Preload("Room").First(&m, id).Error; err != nil {
+		return nil, err
+	}
+	return m.ToEntity(), nil
+}
+
+func (r *leaseRepository) FindByTenantID(tenantID uint) ([]entity.Lease, error) {
+	var models []model.Lease
+	if err := r.db.Preload("Room").
+		Where("tenant_id = ?", tenantID).
+		Order("start_date DESC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return toLeaseEntities(models), nil
+}
+
+func (r *leaseRepository) FindForTenantOn(tenantID uint, date time.Time) (*entity.Lease, error) {
+	var models []model.Lease
+	if err := r.db.Where("tenant_id = ? AND start_date <= ?", tenantID, date).
+		Order("start_date DESC, id DESC").
+		Limit(1).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return models[0].ToEntity(), nil
+}
+
+func (r *leaseRepository) FindExpiring(from, to time.Time) ([]entity.Lease, error) {
+	var models []model.Lease
+	if err := r.db.Preload("Tenant").Preload("Room").
+		Where("status = ? AND end_date >= ? AND end_date < ?", "active", from, to).
+		Order("end_date ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return toLeaseEntities(models), nil
+}
+
+func (r *leaseRepository) Update(lease *entity.Lease) error {
+	m := &model.Lease{}
+	m.FromEntity(lease)
+	return r.db.Omit("Tenant", "Room", "CreatedAt").Save(m).Error
+}
+
+func toLeaseEntities(models []model.Lease) []entity.Lease {
+	entities := make([]entity.Lease, len(models))
+	for i, m := range models {
+		entities[i] = *m.ToEntity()
+	}
+	return entities
+}
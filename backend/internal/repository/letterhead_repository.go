@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+
+	"gorm.io/gorm"
+)
+
+// Letterhead Repository Implementation
+type letterheadRepository struct {
+	db *gorm.DB
+}
+
+func NewLetterheadRepository(db *gorm.DB) repository.LetterheadRepository {
+	return &letterheadRepository{db: db}
+}
+
+// Get returns the stored letterhead, or an empty one when none is saved yet.
+func (r *letterheadRepository) Get() (*entity.Letterhead, error) {
+	var models []model.Letterhead
+	if err := r.db.Order("id ASC").Limit(1).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return &entity.Letterhead{}, nil
+	}
+	return models[0].ToEntity(), nil
+}
+
+func (r *letterheadRepository) Save(letterhead *entity.Letterhead) error {
+	m := &model.Letterhead{}
+	m.FromEntity(letterhead)
+	if err := r.db.Save(m).Error; err != nil {
+		return err
+	}
+	*letterhead = *m.ToEntity()
+	return nil
+}
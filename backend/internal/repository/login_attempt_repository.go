@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+
+	"gorm.io/gorm"
+)
+
+// Login Attempt Repository Implementation
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) repository.LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+func (r *loginAttemptRepository) Create(attempt *entity.LoginAttempt) error {
+	m := &model.LoginAttempt{}
+	m.FromEntity(attempt)
+	if err := r.db.Create(m).Error; err != nil {
+		return err
+	}
+	*attempt = *m.ToEntity()
+	return nil
+}
@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"cmp"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Expense Repository Implementation
+type expenseRepository struct {
+	store   *Store
+	journal *journal
+}
+
+func NewExpenseRepository(store *Store) repository.ExpenseRepository {
+	return &expenseRepository{store: store}
+}
+
+func (r *expenseRepository) withJournal(j *journal) (any, *Store) {
+	bound := *r
+	bound.journal = j
+	return &bound, r.store
+}
+
+func (r *expenseRepository) Create(expense *entity.Expense) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	m := model.Expense{}
+	m.FromEntity(expense)
+	if err := r.store.insertExpense(r.journal, &m); err != nil {
+		return err
+	}
+	*expense = *m.ToEntity()
+	return nil
+}
+
+var expenseSortFields = map[string]func(a, b model.Expense) int{
+	"id":           func(a, b model.Expense) int { return cmp.Compare(a.ID, b.ID) },
+	"expense_date": func(a, b model.Expense) int { return a.ExpenseDate.Compare(b.ExpenseDate) },
+	"amount":       func(a, b model.Expense) int { return compareMoney(a.Amount, b.Amount) },
+	"description":  func(a, b model.Expense) int { return strings.Compare(a.Description, b.Description) },
+	"created_at":   func(a, b model.Expense) int { return a.CreatedAt.Compare(b.CreatedAt) },
+}
+
+// Find lists the newest expenses first unless the query sorts otherwise.
+func (r *expenseRepository) Find(query repository.ExpenseQuery) ([]entity.Expense, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := []model.Expense{}
+	for _, id := range sortedIDs(r.store.expenses) {
+		m := r.store.expenses[id]
+		if query.PropertyID != 0 && m.PropertyID != query.PropertyID {
+			continue
+		}
+		if !query.From.IsZero() && m.ExpenseDate.Before(query.From) {
+			continue
+		}
+		if !query.To.IsZero() && !m.ExpenseDate.Before(query.To) {
+			continue
+		}
+		matched = append(matched, m)
+	}
+
+	if len(query.Sort) == 0 {
+		query.Sort = []repository.Sort{{Field: "expense_date", Desc: true}}
+	}
+	entities := []entity.Expense{}
+	for _, m := range paginate(matched, query.Page, expenseSortFields) {
+		entities = append(entities, *m.ToEntity())
+	}
+	return entities, int64(len(matched)), nil
+}
+
+func (r *expenseRepository) FindByID(id uint) (*entity.Expense, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	m, ok := r.store.expenses[id]
+	if !ok {
+		return nil, dbError(gorm.ErrRecordNotFound, "expenses", false)
+	}
+	return m.ToEntity(), nil
+}
+
+// Update saves every column but the creation time.
+func (r *expenseRepository) Update(expense *entity.Expense) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	m := model.Expense{}
+	m.FromEntity(expense)
+	old, ok := r.store.expenses[m.ID]
+	if !ok {
+		return dbError(gorm.ErrRecordNotFound, "expenses", false)
+	}
+	m.Amount = stored(m.Amount)
+	m.CreatedAt = old.CreatedAt
+	m.UpdatedAt = time.Now()
+	put(r.journal, r.store.expenses, m.ID, m)
+	return nil
+}
+
+func (r *expenseRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.expenses[id]; !ok {
+		return dbError(gorm.ErrRecordNotFound, "expenses", false)
+	}
+	remove(r.journal, r.store.expenses, id)
+	return nil
+}
+
+func (r *expenseRepository) SumByPeriod(start, end time.Time, propertyID uint) (entity.Money, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	total := entity.NewMoney(0)
+	for _, m := range r.store.expenses {
+		if (propertyID == 0 || m.PropertyID == propertyID) && inPeriod(m.ExpenseDate, start, end) {
+			total = total.Add(m.Amount)
+		}
+	}
+	return total, nil
+}
+
+func (s *Store) insertExpense(j *journal, m *model.Expense) error {
+	if _, ok := s.expenses[m.ID]; ok && m.ID != 0 {
+		return dbError(gorm.ErrDuplicatedKey, "expenses", false)
+	}
+	now := time.Now()
+	m.ID = s.nextID("expenses", m.ID)
+	m.Amount = stored(m.Amount)
+	m.CreatedAt, m.UpdatedAt = now, now
+	put(j, s.expenses, m.ID, *m)
+	return nil
+}
@@ -0,0 +1,176 @@
+package memory_test
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/memory"
+	"ezkost/internal/repository/repositorytest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRepositories(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) *repository.Repositories {
+		return memory.NewRepositories(memory.NewStore())
+	})
+}
+
+// snapshot holds every row of the in-memory repositories.
+type snapshot struct {
+	rooms    []entity.Room
+	tenants  []entity.Tenant
+	payments []entity.Payment
+	expenses []entity.Expense
+	users    []entity.User
+}
+
+func state(t *testing.T, repos *repository.Repositories) snapshot {
+	t.Helper()
+	rooms, _, err := repos.Rooms.Find(repository.RoomQuery{})
+	must(t, err)
+	tenants, _, err := repos.Tenants.Find(repository.TenantQuery{})
+	must(t, err)
+	payments, _, err := repos.Payments.Find(repository.PaymentQuery{})
+	must(t, err)
+	expenses, _, err := repos.Expenses.Find(repository.ExpenseQuery{})
+	must(t, err)
+	users, err := repos.Users.FindAll()
+	must(t, err)
+	return snapshot{rooms, tenants, payments, expenses, users}
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnitOfWorkRollback(t *testing.T) {
+	failed := errors.New("failed")
+	tests := []struct {
+		name string
+		work func(repos *repository.Repositories) error
+	}{
+		{"create", func(repos *repository.Repositories) error {
+			room := &entity.Room{PropertyID: 1, RoomNumber: "102", Price: entity.NewMoney(1500000)}
+			if err := repos.Rooms.Create(room); err != nil {
+				return err
+			}
+			tenant := &entity.Tenant{PropertyID: 1, Name: "Ani", Phone: "0813", RoomID: &room.ID, StartDate: time.Now()}
+			if err := repos.Tenants.Create(tenant); err != nil {
+				return err
+			}
+			return repos.Payments.Create(&entity.Payment{TenantID: tenant.ID, Amount: entity.NewMoney(1500000), DueDate: time.Now(), Period: "2026-02"})
+		}},
+		{"update", func(repos *repository.Repositories) error {
+			room, err := repos.Rooms.FindByID(1)
+			if err != nil {
+				return err
+			}
+			room.Price = entity.NewMoney(1750000)
+			if err := repos.Rooms.Update(room); err != nil {
+				return err
+			}
+			if err := repos.Rooms.UpdateStatus(1, "maintenance"); err != nil {
+				return err
+			}
+			return repos.Tenants.AdjustCredit(1, entity.NewMoney(50000))
+		}},
+		{"pay", func(repos *repository.Repositories) error {
+			payment, err := repos.Payments.FindByID(1)
+			if err != nil {
+				return err
+			}
+			if err := repos.Payments.AddTransaction(&entity.PaymentTransaction{PaymentID: 1, Amount: entity.NewMoney(1500000), Method: "cash", PaidAt: time.Now()}); err != nil {
+				return err
+			}
+			if err := repos.Payments.AddLineItem(&entity.PaymentLineItem{PaymentID: 1, Type: "late_fee", Description: "Late fee", Amount: entity.NewMoney(50000)}); err != nil {
+				return err
+			}
+			now := time.Now()
+			payment.PaidAt = &now
+			return repos.Payments.Update(payment)
+		}},
+		{"delete", func(repos *repository.Repositories) error {
+			if err := repos.Expenses.Delete(1); err != nil {
+				return err
+			}
+			return repos.Users.Delete(1)
+		}},
+		{"create then delete", func(repos *repository.Repositories) error {
+			expense := &entity.Expense{PropertyID: 1, Description: "Water", Amount: entity.NewMoney(100000), ExpenseDate: time.Now()}
+			if err := repos.Expenses.Create(expense); err != nil {
+				return err
+			}
+			return repos.Expenses.Delete(expense.ID)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := memory.NewRepositories(memory.NewStore())
+			room := &entity.Room{PropertyID: 1, RoomNumber: "101", Price: entity.NewMoney(1500000)}
+			must(t, repos.Rooms.Create(room))
+			budi := &entity.Tenant{PropertyID: 1, Name: "Budi", Phone: "0812", RoomID: &room.ID, StartDate: time.Now()}
+			must(t, repos.Tenants.Create(budi))
+			must(t, repos.Payments.Create(&entity.Payment{TenantID: budi.ID, Amount: entity.NewMoney(1500000), DueDate: time.Now(), Period: "2026-01"}))
+			must(t, repos.Expenses.Create(&entity.Expense{PropertyID: 1, Description: "Electricity", Amount: entity.NewMoney(500000), ExpenseDate: time.Now()}))
+			must(t, repos.Users.Create(&entity.User{Name: "Sari", Email: "sari@example.com", Role: "owner"}))
+			before := state(t, repos)
+
+			err := memory.NewUnitOfWork(repos).Do(func(tx *repository.Repositories) error {
+				if err := tt.work(tx); err != nil {
+					return err
+				}
+				return failed
+			})
+			if !errors.Is(err, failed) {
+				t.Fatalf("got error %v, want %v", err, failed)
+			}
+			if after := state(t, repos); !reflect.DeepEqual(after, before) {
+				t.Errorf("after the failed unit of work:\n%+v\nwant:\n%+v", after, before)
+			}
+		})
+	}
+}
+
+func TestUnitOfWorkRollbackKeepsOutsideWrites(t *testing.T) {
+	repos := memory.NewRepositories(memory.NewStore())
+	must(t, repos.Rooms.Create(&entity.Room{PropertyID: 1, RoomNumber: "101", Price: entity.NewMoney(1500000)}))
+	failed := errors.New("failed")
+
+	err := memory.NewUnitOfWork(repos).Do(func(tx *repository.Repositories) error {
+		must(t, tx.Rooms.Create(&entity.Room{PropertyID: 1, RoomNumber: "102", Price: entity.NewMoney(1500000)}))
+		must(t, tx.Rooms.UpdateStatus(1, "maintenance"))
+		// Another request writes while the unit of work runs
+		must(t, repos.Rooms.Create(&entity.Room{PropertyID: 1, RoomNumber: "103", Price: entity.NewMoney(1500000)}))
+		must(t, repos.Expenses.Create(&entity.Expense{PropertyID: 1, Description: "Electricity", Amount: entity.NewMoney(500000), ExpenseDate: time.Now()}))
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("got error %v, want %v", err, failed)
+	}
+
+	rooms, _, err := repos.Rooms.Find(repository.RoomQuery{})
+	must(t, err)
+	if len(rooms) != 2 || rooms[0].RoomNumber != "101" || rooms[0].Status != "empty" || rooms[1].RoomNumber != "103" {
+		t.Errorf("rooms = %+v, want 101 unchanged and 103 written outside the unit of work", rooms)
+	}
+	expenses, _, err := repos.Expenses.Find(repository.ExpenseQuery{})
+	must(t, err)
+	if len(expenses) != 1 {
+		t.Errorf("expenses = %+v, want the one written outside the unit of work", expenses)
+	}
+}
+
+func TestUnitOfWorkCommit(t *testing.T) {
+	repos := memory.NewRepositories(memory.NewStore())
+	must(t, memory.NewUnitOfWork(repos).Do(func(tx *repository.Repositories) error {
+		return tx.Rooms.Create(&entity.Room{PropertyID: 1, RoomNumber: "101", Price: entity.NewMoney(1500000)})
+	}))
+	if n, err := repos.Rooms.Count(0); err != nil || n != 1 {
+		t.Errorf("%d rooms (%v), want the committed one", n, err)
+	}
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"cmp"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"slices"
+	"strings"
+	"time"
+)
+
+// paginate sorts rows, which must be in id order, by the page's sort fields
+// and returns the rows of the page. fields compares two rows by each field
+// the repository can sort by.
+func paginate[T any](rows []T, page repository.Page, fields map[string]func(a, b T) int) []T {
+	slices.SortStableFunc(rows, func(a, b T) int {
+		for _, sort := range page.Sort {
+			compare, ok := fields[sort.Field]
+			if !ok {
+				continue
+			}
+			c := compare(a, b)
+			if sort.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+
+	rows = rows[min(page.Offset, len(rows)):]
+	if page.Limit > 0 && page.Limit < len(rows) {
+		rows = rows[:page.Limit]
+	}
+	return rows
+}
+
+// compareTimes orders unset times after set ones, like Postgres orders NULLs.
+func compareTimes(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return a.Compare(*b)
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// compareMoney orders amounts as a numeric column does.
+func compareMoney(a, b entity.Money) int {
+	return cmp.Compare(a.Minor, b.Minor)
+}
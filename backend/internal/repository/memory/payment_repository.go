@@ -0,0 +1,391 @@
+package memory
+
+import (
+	"cmp"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Payment Repository Implementation
+type paymentRepository struct {
+	store   *Store
+	journal *journal
+}
+
+func NewPaymentRepository(store *Store) repository.PaymentRepository {
+	return &paymentRepository{store: store}
+}
+
+func (r *paymentRepository) withJournal(j *journal) (any, *Store) {
+	bound := *r
+	bound.journal = j
+	return &bound, r.store
+}
+
+func (r *paymentRepository) Create(payment *entity.Payment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	m := model.Payment{}
+	m.FromEntity(payment)
+	if err := r.store.insertPayment(r.journal, &m); err != nil {
+		return err
+	}
+	c := copyPayment(m)
+	*payment = *c.ToEntity()
+	return nil
+}
+
+var paymentSortFields = map[string]func(a, b model.Payment) int{
+	"id":         func(a, b model.Payment) int { return cmp.Compare(a.ID, b.ID) },
+	"due_date":   func(a, b model.Payment) int { return a.DueDate.Compare(b.DueDate) },
+	"amount":     func(a, b model.Payment) int { return compareMoney(a.Amount, b.Amount) },
+	"status":     func(a, b model.Payment) int { return strings.Compare(a.Status, b.Status) },
+	"period":     func(a, b model.Payment) int { return strings.Compare(a.Period, b.Period) },
+	"paid_at":    func(a, b model.Payment) int { return compareTimes(a.PaidAt, b.PaidAt) },
+	"created_at": func(a, b model.Payment) int { return a.CreatedAt.Compare(b.CreatedAt) },
+}
+
+func (r *paymentRepository) Find(query repository.PaymentQuery) ([]entity.Payment, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := []model.Payment{}
+	for _, id := range sortedIDs(r.store.payments) {
+		if m := r.store.payments[id]; r.store.paymentMatches(m, query) {
+			matched = append(matched, m)
+		}
+	}
+
+	entities := []entity.Payment{}
+	for _, m := range paginate(matched, query.Page, paymentSortFields) {
+		entities = append(entities, r.store.loadPayment(m, true))
+	}
+	return entities, int64(len(matched)), nil
+}
+
+func (r *paymentRepository) FindByID(id uint) (*entity.Payment, error) {
+	payments, err := r.find(func(m model.Payment) bool { return m.ID == id }, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(payments) == 0 {
+		return nil, dbError(gorm.ErrRecordNotFound, "payments", false)
+	}
+	return &payments[0], nil
+}
+
+func (r *paymentRepository) FindByTenantID(tenantID uint) ([]entity.Payment, error) {
+	return r.find(func(m model.Payment) bool { return m.TenantID == tenantID }, false)
+}
+
+func (r *paymentRepository) FindOverdue(now time.Time) ([]entity.Payment, error) {
+	return r.find(func(m model.Payment) bool { return isOverdue(m, now) }, true)
+}
+
+func (r *paymentRepository) ExistsForPeriod(tenantID uint, period string) (bool, error) {
+	payments, err := r.find(func(m model.Payment) bool {
+		return m.TenantID == tenantID && m.Period == period
+	}, false)
+	return len(payments) > 0, err
+}
+
+// FindByTenantAndPeriod returns nil without an error when the tenant has not
+// been billed for the period yet.
+func (r *paymentRepository) FindByTenantAndPeriod(tenantID uint, period string) (*entity.Payment, error) {
+	payments, err := r.find(func(m model.Payment) bool {
+		return m.TenantID == tenantID && m.Period == period
+	}, false)
+	if err != nil || len(payments) == 0 {
+		return nil, err
+	}
+	return &payments[0], nil
+}
+
+// Update saves every column but the creation time. A payment with PaidAt set becomes "paid" or "late", as the
+// model's BeforeUpdate hook does.
+func (r *paymentRepository) Update(payment *entity.Payment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	m := model.Payment{}
+	m.FromEntity(payment)
+	m.BeforeUpdate(nil)
+	old, ok := r.store.payments[m.ID]
+	if !ok {
+		return dbError(gorm.ErrRecordNotFound, "payments", false)
+	}
+	if err := r.store.checkPayment(m); err != nil {
+		return err
+	}
+	m = copyPayment(m)
+	m.Amount = stored(m.Amount)
+	m.CreatedAt = old.CreatedAt
+	m.UpdatedAt = time.Now()
+	put(r.journal, r.store.payments, m.ID, m)
+	return nil
+}
+
+func (r *paymentRepository) CountOverdue(now time.Time, propertyID uint) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, m := range r.store.payments {
+		if (propertyID == 0 || m.PropertyID == propertyID) && isOverdue(m, now) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// SumPaidByPeriod totals the money received in the period. Transactions that
+// only move an existing tenant credit onto a bill are not new income.
+func (r *paymentRepository) SumPaidByPeriod(start, end time.Time, propertyID uint) (entity.Money, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	total := entity.NewMoney(0)
+	for _, t := range r.store.transactions {
+		payment, ok := r.store.payments[t.PaymentID]
+		if !ok || (propertyID != 0 && payment.PropertyID != propertyID) {
+			continue
+		}
+		if t.Method != "credit" && inPeriod(t.PaidAt, start, end) {
+			total = total.Add(t.Amount)
+		}
+	}
+	return total, nil
+}
+
+func (r *paymentRepository) AddLineItem(item *entity.PaymentLineItem) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	m := model.PaymentLineItem{}
+	m.FromEntity(item)
+	if err := r.store.insertLineItem(r.journal, &m); err != nil {
+		return err
+	}
+	c := copyLineItem(m)
+	*item = *c.ToEntity()
+	return nil
+}
+
+func (r *paymentRepository) FindLineItemByID(id uint) (*entity.PaymentLineItem, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	m, ok := r.store.lineItems[id]
+	if !ok {
+		return nil, dbError(gorm.ErrRecordNotFound, "payment_line_items", false)
+	}
+	m = copyLineItem(m)
+	return m.ToEntity(), nil
+}
+
+func (r *paymentRepository) UpdateLineItem(item *entity.PaymentLineItem) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	m := model.PaymentLineItem{}
+	m.FromEntity(item)
+	if _, ok := r.store.lineItems[m.ID]; !ok {
+		return r.store.insertLineItem(r.journal, &m)
+	}
+	if _, ok := r.store.payments[m.PaymentID]; !ok {
+		return dbError(gorm.ErrForeignKeyViolated, "payment_line_items", false)
+	}
+	m = copyLineItem(m)
+	m.Amount = stored(m.Amount)
+	m.UpdatedAt = time.Now()
+	put(r.journal, r.store.lineItems, m.ID, m)
+	return nil
+}
+
+func (r *paymentRepository) AddTransaction(transaction *entity.PaymentTransaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	m := model.PaymentTransaction{}
+	m.FromEntity(transaction)
+	if _, ok := r.store.transactions[m.ID]; ok && m.ID != 0 {
+		return dbError(gorm.ErrDuplicatedKey, "payment_transactions", false)
+	}
+	if _, ok := r.store.payments[m.PaymentID]; !ok {
+		return dbError(gorm.ErrForeignKeyViolated, "payment_transactions", false)
+	}
+	m.ID = r.store.nextID("payment_transactions", m.ID)
+	m.Amount = stored(m.Amount)
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	put(r.journal, r.store.transactions, m.ID, m)
+	*transaction = *m.ToEntity()
+	return nil
+}
+
+// find returns the matching payments with their line items and
+// transactions, and with their tenant and the tenant's room if withTenant.
+func (r *paymentRepository) find(match func(m model.Payment) bool, withTenant bool) ([]entity.Payment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	entities := []entity.Payment{}
+	for _, id := range sortedIDs(r.store.payments) {
+		if m := r.store.payments[id]; match(m) {
+			entities = append(entities, r.store.loadPayment(m, withTenant))
+		}
+	}
+	return entities, nil
+}
+
+// loadPayment preloads a payment's line items and transactions, and its
+// tenant and the tenant's room if withTenant.
+func (s *Store) loadPayment(m model.Payment, withTenant bool) entity.Payment {
+	m = copyPayment(m)
+	if tenant, ok := s.tenants[m.TenantID]; ok && withTenant {
+		m.Tenant = s.tenantWithRoom(tenant)
+	}
+	m.LineItems = []model.PaymentLineItem{}
+	for _, itemID := range sortedIDs(s.lineItems) {
+		if item := s.lineItems[itemID]; item.PaymentID == m.ID {
+			m.LineItems = append(m.LineItems, copyLineItem(item))
+		}
+	}
+	m.Transactions = []model.PaymentTransaction{}
+	for _, transactionID := range sortedIDs(s.transactions) {
+		if t := s.transactions[transactionID]; t.PaymentID == m.ID {
+			m.Transactions = append(m.Transactions, t)
+		}
+	}
+	return *m.ToEntity()
+}
+
+func (s *Store) paymentMatches(m model.Payment, query repository.PaymentQuery) bool {
+	if query.PropertyID != 0 && m.PropertyID != query.PropertyID {
+		return false
+	}
+	if query.TenantID != 0 && m.TenantID != query.TenantID {
+		return false
+	}
+	if query.RoomID != 0 {
+		tenant, ok := s.tenants[m.TenantID]
+		if !ok || tenant.RoomID == nil || *tenant.RoomID != query.RoomID {
+			return false
+		}
+	}
+	if query.Status != "" && m.Status != query.Status {
+		return false
+	}
+	if query.Method != "" && m.PaymentMethod != query.Method && !s.paidWith(m.ID, query.Method) {
+		return false
+	}
+	if !query.DueFrom.IsZero() && m.DueDate.Before(query.DueFrom) {
+		return false
+	}
+	if !query.DueTo.IsZero() && !m.DueDate.Before(query.DueTo) {
+		return false
+	}
+	return true
+}
+
+func (s *Store) paidWith(paymentID uint, method string) bool {
+	for _, t := range s.transactions {
+		if t.PaymentID == paymentID && t.Method == method {
+			return true
+		}
+	}
+	return false
+}
+
+// paymentsOfTenant preloads a tenant's payments, without their relations.
+func (s *Store) paymentsOfTenant(tenantID uint) []model.Payment {
+	payments := []model.Payment{}
+	for _, id := range sortedIDs(s.payments) {
+		if m := s.payments[id]; m.TenantID == tenantID {
+			payments = append(payments, copyPayment(m))
+		}
+	}
+	return payments
+}
+
+func (s *Store) insertPayment(j *journal, m *model.Payment) error {
+	if _, ok := s.payments[m.ID]; ok && m.ID != 0 {
+		return dbError(gorm.ErrDuplicatedKey, "payments", false)
+	}
+	if err := s.checkPayment(*m); err != nil {
+		return err
+	}
+	if m.Status == "" {
+		m.Status = "unpaid"
+	}
+	now := time.Now()
+	*m = copyPayment(*m)
+	m.ID = s.nextID("payments", m.ID)
+	m.Amount = stored(m.Amount)
+	m.CreatedAt, m.UpdatedAt = now, now
+	put(j, s.payments, m.ID, *m)
+	return nil
+}
+
+// checkPayment enforces the payment's tenant and one bill per tenant and
+// period.
+func (s *Store) checkPayment(m model.Payment) error {
+	if _, ok := s.tenants[m.TenantID]; !ok {
+		return dbError(gorm.ErrForeignKeyViolated, "payments", false)
+	}
+	if m.Period == "" {
+		return nil
+	}
+	for id, other := range s.payments {
+		if id != m.ID && other.TenantID == m.TenantID && other.Period == m.Period {
+			return dbError(gorm.ErrDuplicatedKey, "payments", false)
+		}
+	}
+	return nil
+}
+
+func (s *Store) insertLineItem(j *journal, m *model.PaymentLineItem) error {
+	if _, ok := s.lineItems[m.ID]; ok && m.ID != 0 {
+		return dbError(gorm.ErrDuplicatedKey, "payment_line_items", false)
+	}
+	if _, ok := s.payments[m.PaymentID]; !ok {
+		return dbError(gorm.ErrForeignKeyViolated, "payment_line_items", false)
+	}
+	now := time.Now()
+	*m = copyLineItem(*m)
+	m.ID = s.nextID("payment_line_items", m.ID)
+	m.Amount = stored(m.Amount)
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = now
+	}
+	m.UpdatedAt = now
+	put(j, s.lineItems, m.ID, *m)
+	return nil
+}
+
+func isOverdue(m model.Payment, now time.Time) bool {
+	return (m.Status == "unpaid" || m.Status == "partial") && m.DueDate.Before(now)
+}
+
+// copyPayment returns a row that shares no memory with the stored one and
+// has no relations loaded.
+func copyPayment(m model.Payment) model.Payment {
+	m.PaidAt = copyTime(m.PaidAt)
+	m.Tenant = model.Tenant{}
+	m.LineItems = nil
+	m.Transactions = nil
+	return m
+}
+
+func copyLineItem(m model.PaymentLineItem) model.PaymentLineItem {
+	m.WaivedBy = copyUint(m.WaivedBy)
+	m.WaivedAt = copyTime(m.WaivedAt)
+	return m
+}
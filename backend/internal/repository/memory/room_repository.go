@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"cmp"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Room Repository Implementation
+type roomRepository struct {
+	store   *Store
+	journal *journal
+}
+
+func NewRoomRepository(store *Store) repository.RoomRepository {
+	return &roomRepository{store: store}
+}
+
+func (r *roomRepository) withJournal(j *journal) (any, *Store) {
+	bound := *r
+	bound.journal = j
+	return &bound, r.store
+}
+
+func (r *roomRepository) Create(room *entity.Room) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	m := model.Room{}
+	m.FromEntity(room)
+	if m.Status == "" {
+		m.Status = "empty"
+	}
+	if err := r.store.insertRoom(r.journal, &m); err != nil {
+		return err
+	}
+	*room = *m.ToEntity()
+	return nil
+}
+
+var roomSortFields = map[string]func(a, b model.Room) int{
+	"id":          func(a, b model.Room) int { return cmp.Compare(a.ID, b.ID) },
+	"room_number": func(a, b model.Room) int { return strings.Compare(a.RoomNumber, b.RoomNumber) },
+	"floor":       func(a, b model.Room) int { return cmp.Compare(a.Floor, b.Floor) },
+	"price":       func(a, b model.Room) int { return compareMoney(a.Price, b.Price) },
+	"status":      func(a, b model.Room) int { return strings.Compare(a.Status, b.Status) },
+	"created_at":  func(a, b model.Room) int { return a.CreatedAt.Compare(b.CreatedAt) },
+}
+
+func (r *roomRepository) Find(query repository.RoomQuery) ([]entity.Room, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := []model.Room{}
+	for _, id := range sortedIDs(r.store.rooms) {
+		m := r.store.rooms[id]
+		if query.PropertyID != 0 && m.PropertyID != query.PropertyID {
+			continue
+		}
+		if query.Status != "" && m.Status != query.Status {
+			continue
+		}
+		matched = append(matched, m)
+	}
+
+	entities := []entity.Room{}
+	for _, m := range paginate(matched, query.Page, roomSortFields) {
+		m = r.store.roomWithTenant(m)
+		entities = append(entities, *m.ToEntity())
+	}
+	return entities, int64(len(matched)), nil
+}
+
+func (r *roomRepository) FindByID(id uint) (*entity.Room, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	m, ok := r.store.rooms[id]
+	if !ok {
+		return nil, dbError(gorm.ErrRecordNotFound, "rooms", false)
+	}
+	m = r.store.roomWithTenant(m)
+	return m.ToEntity(), nil
+}
+
+// Update saves every column but the creation time.
+func (r *roomRepository) Update(room *entity.Room) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	m := model.Room{}
+	m.FromEntity(room)
+	old, ok := r.store.rooms[m.ID]
+	if !ok {
+		return dbError(gorm.ErrRecordNotFound, "rooms", false)
+	}
+	if err := r.store.checkRoomNumber(m); err != nil {
+		return err
+	}
+	m.Price = stored(m.Price)
+	m.CreatedAt = old.CreatedAt
+	m.UpdatedAt = time.Now()
+	put(r.journal, r.store.rooms, m.ID, m)
+	return nil
+}
+
+func (r *roomRepository) UpdateStatus(id uint, status string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if m, ok := r.store.rooms[id]; ok {
+		m.Status = status
+		m.UpdatedAt = time.Now()
+		put(r.journal, r.store.rooms, id, m)
+	}
+	return nil
+}
+
+func (r *roomRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, t := range r.store.tenants {
+		if t.RoomID != nil && *t.RoomID == id {
+			return dbError(gorm.ErrForeignKeyViolated, "rooms", true)
+		}
+	}
+	if _, ok := r.store.rooms[id]; !ok {
+		return dbError(gorm.ErrRecordNotFound, "rooms", false)
+	}
+	remove(r.journal, r.store.rooms, id)
+	return nil
+}
+
+func (r *roomRepository) Count(propertyID uint) (int64, error) {
+	return r.count(propertyID, func(model.Room) bool { return true })
+}
+
+func (r *roomRepository) CountByStatus(status string, propertyID uint) (int64, error) {
+	return r.count(propertyID, func(m model.Room) bool { return m.Status == status })
+}
+
+func (r *roomRepository) count(propertyID uint, match func(m model.Room) bool) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, m := range r.store.rooms {
+		if (propertyID == 0 || m.PropertyID == propertyID) && match(m) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *Store) insertRoom(j *journal, m *model.Room) error {
+	if _, ok := s.rooms[m.ID]; ok && m.ID != 0 {
+		return dbError(gorm.ErrDuplicatedKey, "rooms", false)
+	}
+	if err := s.checkRoomNumber(*m); err != nil {
+		return err
+	}
+	now := time.Now()
+	m.ID = s.nextID("rooms", m.ID)
+	m.Price = stored(m.Price)
+	m.CreatedAt, m.UpdatedAt = now, now
+	put(j, s.rooms, m.ID, *m)
+	return nil
+}
+
+// checkRoomNumber enforces the unique room number per property.
+func (s *Store) checkRoomNumber(m model.Room) error {
+	for id, other := range s.rooms {
+		if id != m.ID && other.PropertyID == m.PropertyID && other.RoomNumber == m.RoomNumber {
+			return dbError(gorm.ErrDuplicatedKey, "rooms", false)
+		}
+	}
+	return nil
+}
+
+// roomWithTenant preloads the room's tenant. Like GORM, the last tenant
+// pointing at the room wins when there are several.
+func (s *Store) roomWithTenant(m model.Room) model.Room {
+	for _, id := range sortedIDs(s.tenants) {
+		t := s.tenants[id]
+		if t.RoomID != nil && *t.RoomID == m.ID {
+			t = copyTenant(t)
+			m.Tenant = &t
+		}
+	}
+	return m
+}
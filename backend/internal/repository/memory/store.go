@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"ezkost/internal/domain/entity"
+	sqlrepository "ezkost/internal/repository"
+	"ezkost/internal/repository/model"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store holds the rows of the in-memory repositories. Repositories created on
+// the same store see each other's rows, so they can fill in relations the way
+// the GORM repositories preload them, and enforce the same foreign keys. A
+// store holds the data of one organization.
+//
+// Rows are kept as GORM models and converted with their ToEntity and
+// FromEntity methods, so exactly the columns the database would keep survive
+// a write.
+type Store struct {
+	mu           sync.RWMutex
+	lastID       map[string]uint
+	rooms        map[uint]model.Room
+	tenants      map[uint]model.Tenant
+	payments     map[uint]model.Payment
+	lineItems    map[uint]model.PaymentLineItem
+	transactions map[uint]model.PaymentTransaction
+	expenses     map[uint]model.Expense
+	users        map[uint]model.User
+}
+
+func NewStore() *Store {
+	return &Store{
+		lastID:       make(map[string]uint),
+		rooms:        make(map[uint]model.Room),
+		tenants:      make(map[uint]model.Tenant),
+		payments:     make(map[uint]model.Payment),
+		lineItems:    make(map[uint]model.PaymentLineItem),
+		transactions: make(map[uint]model.PaymentTransaction),
+		expenses:     make(map[uint]model.Expense),
+		users:        make(map[uint]model.User),
+	}
+}
+
+// nextID hands out the ids of a table. Like a Postgres sequence, an id is
+// never handed out twice, even when the unit of work that took it failed.
+func (s *Store) nextID(table string, id uint) uint {
+	if id == 0 {
+		id = s.lastID[table] + 1
+	}
+	if id > s.lastID[table] {
+		s.lastID[table] = id
+	}
+	return id
+}
+
+// journal records the rows a unit of work overwrites, so that a failed
+// unit of work can put back exactly what it changed. Writes made through
+// repositories outside the unit of work are not recorded and survive the
+// rollback. A nil journal records nothing.
+type journal struct {
+	stores []*Store
+	undo   []func()
+}
+
+// put writes row to table, recording the row it replaces.
+func put[T any](j *journal, table map[uint]T, id uint, row T) {
+	record(j, table, id)
+	table[id] = row
+}
+
+// remove deletes a row from table, recording it.
+func remove[T any](j *journal, table map[uint]T, id uint) {
+	record(j, table, id)
+	delete(table, id)
+}
+
+func record[T any](j *journal, table map[uint]T, id uint) {
+	if j == nil {
+		return
+	}
+	old, existed := table[id]
+	j.undo = append(j.undo, func() {
+		if existed {
+			table[id] = old
+		} else {
+			delete(table, id)
+		}
+	})
+}
+
+// bind returns a copy of repo recording its writes in j, or repo itself
+// when it does not live in a store.
+func bind[T any](repo T, j *journal) T {
+	if r, ok := any(repo).(journaled); ok {
+		bound, store := r.withJournal(j)
+		if !slices.Contains(j.stores, store) {
+			j.stores = append(j.stores, store)
+		}
+		return bound.(T)
+	}
+	return repo
+}
+
+// rollback undoes the recorded writes, newest first.
+func (j *journal) rollback() {
+	for _, s := range j.stores {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+	}
+	for i := len(j.undo) - 1; i >= 0; i-- {
+		j.undo[i]()
+	}
+	j.undo = nil
+}
+
+// sortedIDs returns the ids of a table in insertion order, which is the
+// order the database returns rows in when a query does not ask for one.
+func sortedIDs[T any](table map[uint]T) []uint {
+	ids := make([]uint, 0, len(table))
+	for id := range table {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// stored returns an amount as it reads back from a numeric(18,2) column.
+func stored(m entity.Money) entity.Money {
+	value, _ := m.Value()
+	var read entity.Money
+	read.Scan(value)
+	return read
+}
+
+func copyUint(p *uint) *uint {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func copyTime(p *time.Time) *time.Time {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func inPeriod(t, start, end time.Time) bool {
+	return !t.Before(start) && t.Before(end)
+}
+
+// dbError returns the domain error the GORM repositories return when the
+// database fails a statement on table with err.
+func dbError(err error, table string, deleting bool) error {
+	return sqlrepository.TranslateError(err, table, deleting)
+}
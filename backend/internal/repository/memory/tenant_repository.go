@@ -0,0 +1,219 @@
+package memory
+
+import (
+	"cmp"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Tenant Repository Implementation
+type tenantRepository struct {
+	store   *Store
+	journal *journal
+}
+
+func NewTenantRepository(store *Store) repository.TenantRepository {
+	return &tenantRepository{store: store}
+}
+
+func (r *tenantRepository) withJournal(j *journal) (any, *Store) {
+	bound := *r
+	bound.journal = j
+	return &bound, r.store
+}
+
+func (r *tenantRepository) Create(tenant *entity.Tenant) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	m := model.Tenant{}
+	m.FromEntity(tenant)
+	if err := r.store.insertTenant(r.journal, &m); err != nil {
+		return err
+	}
+	c := copyTenant(m)
+	*tenant = *c.ToEntity()
+	return nil
+}
+
+var tenantSortFields = map[string]func(a, b model.Tenant) int{
+	"id":         func(a, b model.Tenant) int { return cmp.Compare(a.ID, b.ID) },
+	"name":       func(a, b model.Tenant) int { return strings.Compare(a.Name, b.Name) },
+	"start_date": func(a, b model.Tenant) int { return a.StartDate.Compare(b.StartDate) },
+	"end_date":   func(a, b model.Tenant) int { return compareTimes(a.EndDate, b.EndDate) },
+	"status":     func(a, b model.Tenant) int { return strings.Compare(a.Status, b.Status) },
+	"created_at": func(a, b model.Tenant) int { return a.CreatedAt.Compare(b.CreatedAt) },
+}
+
+func (r *tenantRepository) Find(query repository.TenantQuery) ([]entity.Tenant, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := []model.Tenant{}
+	for _, id := range sortedIDs(r.store.tenants) {
+		m := r.store.tenants[id]
+		if query.PropertyID != 0 && m.PropertyID != query.PropertyID {
+			continue
+		}
+		if query.Status != "" && m.Status != query.Status {
+			continue
+		}
+		if query.Search != "" && !containsFold(m.Name, query.Search) {
+			continue
+		}
+		matched = append(matched, m)
+	}
+
+	entities := []entity.Tenant{}
+	for _, m := range paginate(matched, query.Page, tenantSortFields) {
+		m = r.store.tenantWithRoom(m)
+		m.Payments = r.store.paymentsOfTenant(m.ID)
+		entities = append(entities, *m.ToEntity())
+	}
+	return entities, int64(len(matched)), nil
+}
+
+func (r *tenantRepository) FindByID(id uint) (*entity.Tenant, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	m, ok := r.store.tenants[id]
+	if !ok {
+		return nil, dbError(gorm.ErrRecordNotFound, "tenants", false)
+	}
+	m = r.store.tenantWithRoom(m)
+	m.Payments = r.store.paymentsOfTenant(m.ID)
+	return m.ToEntity(), nil
+}
+
+func (r *tenantRepository) FindByStatus(status string) ([]entity.Tenant, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	entities := []entity.Tenant{}
+	for _, id := range sortedIDs(r.store.tenants) {
+		if m := r.store.tenants[id]; m.Status == status {
+			m = r.store.tenantWithRoom(m)
+			entities = append(entities, *m.ToEntity())
+		}
+	}
+	return entities, nil
+}
+
+// Update never touches the credit balance; use AdjustCredit for that.
+func (r *tenantRepository) Update(tenant *entity.Tenant) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	m := model.Tenant{}
+	m.FromEntity(tenant)
+	old, ok := r.store.tenants[m.ID]
+	if !ok {
+		return dbError(gorm.ErrRecordNotFound, "tenants", false)
+	}
+	if err := r.store.checkTenantRoom(m); err != nil {
+		return err
+	}
+	m = copyTenant(m)
+	m.CreditBalance = old.CreditBalance
+	m.CreatedAt = old.CreatedAt
+	m.UpdatedAt = time.Now()
+	put(r.journal, r.store.tenants, m.ID, m)
+	return nil
+}
+
+func (r *tenantRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, p := range r.store.payments {
+		if p.TenantID == id {
+			return dbError(gorm.ErrForeignKeyViolated, "tenants", true)
+		}
+	}
+	if _, ok := r.store.tenants[id]; !ok {
+		return dbError(gorm.ErrRecordNotFound, "tenants", false)
+	}
+	remove(r.journal, r.store.tenants, id)
+	return nil
+}
+
+func (r *tenantRepository) CountByStatus(status string, propertyID uint) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, m := range r.store.tenants {
+		if (propertyID == 0 || m.PropertyID == propertyID) && m.Status == status {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *tenantRepository) AdjustCredit(id uint, delta entity.Money) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if m, ok := r.store.tenants[id]; ok {
+		m.CreditBalance = stored(m.CreditBalance.Add(delta))
+		m.UpdatedAt = time.Now()
+		put(r.journal, r.store.tenants, id, m)
+	}
+	return nil
+}
+
+func (s *Store) insertTenant(j *journal, m *model.Tenant) error {
+	if _, ok := s.tenants[m.ID]; ok && m.ID != 0 {
+		return dbError(gorm.ErrDuplicatedKey, "tenants", false)
+	}
+	if err := s.checkTenantRoom(*m); err != nil {
+		return err
+	}
+	if m.Status == "" {
+		m.Status = "active"
+	}
+	now := time.Now()
+	*m = copyTenant(*m)
+	m.ID = s.nextID("tenants", m.ID)
+	m.CreditBalance = stored(m.CreditBalance)
+	m.CreatedAt, m.UpdatedAt = now, now
+	put(j, s.tenants, m.ID, *m)
+	return nil
+}
+
+func (s *Store) checkTenantRoom(m model.Tenant) error {
+	if m.RoomID == nil {
+		return nil
+	}
+	if _, ok := s.rooms[*m.RoomID]; !ok {
+		return dbError(gorm.ErrForeignKeyViolated, "tenants", false)
+	}
+	return nil
+}
+
+// tenantWithRoom preloads the tenant's room, without the room's own tenant.
+func (s *Store) tenantWithRoom(m model.Tenant) model.Tenant {
+	m = copyTenant(m)
+	if m.RoomID != nil {
+		if room, ok := s.rooms[*m.RoomID]; ok {
+			m.Room = &room
+		}
+	}
+	return m
+}
+
+// copyTenant returns a row that shares no memory with the stored one and
+// has no relations loaded.
+func copyTenant(m model.Tenant) model.Tenant {
+	m.RoomID = copyUint(m.RoomID)
+	m.EndDate = copyTime(m.EndDate)
+	m.Room = nil
+	m.Payments = nil
+	return m
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"ezkost/internal/domain/repository"
+	"sync"
+)
+
+// journaled is implemented by the in-memory repositories. withJournal returns
+// a copy of the repository that records its writes in j, and its store.
+type journaled interface {
+	withJournal(j *journal) (any, *Store)
+}
+
+// Unit Of Work Implementation
+type unitOfWork struct {
+	mu    sync.Mutex
+	repos *repository.Repositories
+}
+
+// NewUnitOfWork runs units of work one at a time against repos. When a unit
+// of work fails, the rows it wrote through the in-memory repositories are put
+// back as they were; repositories from elsewhere keep whatever was written
+// before the failure.
+func NewUnitOfWork(repos *repository.Repositories) repository.UnitOfWork {
+	return &unitOfWork{repos: repos}
+}
+
+func (u *unitOfWork) Do(fn func(repos *repository.Repositories) error) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	j := &journal{}
+	repos := *u.repos
+	repos.Users = bind(repos.Users, j)
+	repos.Rooms = bind(repos.Rooms, j)
+	repos.Tenants = bind(repos.Tenants, j)
+	repos.Payments = bind(repos.Payments, j)
+	repos.Expenses = bind(repos.Expenses, j)
+
+	if err := fn(&repos); err != nil {
+		j.rollback()
+		return err
+	}
+	return nil
+}
+
+// NewRepositories builds the in-memory repositories on store. Only Users,
+// Rooms, Tenants, Payments and Expenses are set; callers fill in the others.
+func NewRepositories(store *Store) *repository.Repositories {
+	return &repository.Repositories{
+		Users:    NewUserRepository(store),
+		Rooms:    NewRoomRepository(store),
+		Tenants:  NewTenantRepository(store),
+		Payments: NewPaymentRepository(store),
+		Expenses: NewExpenseRepository(store),
+	}
+}
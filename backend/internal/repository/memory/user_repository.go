@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// User Repository Implementation
+type userRepository struct {
+	store   *Store
+	journal *journal
+}
+
+func NewUserRepository(store *Store) repository.UserRepository {
+	return &userRepository{store: store}
+}
+
+func (r *userRepository) withJournal(j *journal) (any, *Store) {
+	bound := *r
+	bound.journal = j
+	return &bound, r.store
+}
+
+func (r *userRepository) Create(user *entity.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	m := model.User{}
+	m.FromEntity(user)
+	if err := r.store.insertUser(r.journal, &m); err != nil {
+		return err
+	}
+	c := copyUser(m)
+	*user = *c.ToEntity()
+	return nil
+}
+
+func (r *userRepository) FindAll() ([]entity.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	entities := []entity.User{}
+	for _, id := range sortedIDs(r.store.users) {
+		m := copyUser(r.store.users[id])
+		entities = append(entities, *m.ToEntity())
+	}
+	sort.SliceStable(entities, func(i, j int) bool { return entities[i].Name < entities[j].Name })
+	return entities, nil
+}
+
+func (r *userRepository) FindByEmail(email string) (*entity.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, id := range sortedIDs(r.store.users) {
+		if m := copyUser(r.store.users[id]); m.Email == email {
+			return m.ToEntity(), nil
+		}
+	}
+	return nil, dbError(gorm.ErrRecordNotFound, "users", false)
+}
+
+func (r *userRepository) FindByID(id uint) (*entity.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	m, ok := r.store.users[id]
+	if !ok {
+		return nil, dbError(gorm.ErrRecordNotFound, "users", false)
+	}
+	m = copyUser(m)
+	return m.ToEntity(), nil
+}
+
+// Update saves every column like GORM's Save, inserting the user when it
+// does not exist.
+func (r *userRepository) Update(user *entity.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	m := model.User{}
+	m.FromEntity(user)
+	if _, ok := r.store.users[m.ID]; !ok {
+		return r.store.insertUser(r.journal, &m)
+	}
+	if err := r.store.checkEmail(m); err != nil {
+		return err
+	}
+	m = copyUser(m)
+	m.UpdatedAt = time.Now()
+	put(r.journal, r.store.users, m.ID, m)
+	return nil
+}
+
+func (r *userRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	remove(r.journal, r.store.users, id)
+	return nil
+}
+
+func (s *Store) insertUser(j *journal, m *model.User) error {
+	if _, ok := s.users[m.ID]; ok && m.ID != 0 {
+		return dbError(gorm.ErrDuplicatedKey, "users", false)
+	}
+	if err := s.checkEmail(*m); err != nil {
+		return err
+	}
+	if m.Role == "" {
+		m.Role = "staff"
+	}
+	if m.Status == "" {
+		m.Status = "active"
+	}
+	now := time.Now()
+	*m = copyUser(*m)
+	m.ID = s.nextID("users", m.ID)
+	m.CreatedAt, m.UpdatedAt = now, now
+	put(j, s.users, m.ID, *m)
+	return nil
+}
+
+// checkEmail enforces that an email belongs to one user across every
+// organization.
+func (s *Store) checkEmail(m model.User) error {
+	for id, other := range s.users {
+		if id != m.ID && other.Email == m.Email {
+			return dbError(gorm.ErrDuplicatedKey, "users", false)
+		}
+	}
+	return nil
+}
+
+func copyUser(m model.User) model.User {
+	m.LockedUntil = copyTime(m.LockedUntil)
+	return m
+}
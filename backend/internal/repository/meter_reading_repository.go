@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+
+	"gorm.io/gorm"
+)
+
+// Meter Reading Repository Implementation
+type meterReadingRepository struct {
+	db *gorm.DB
+}
+
+func NewMeterReadingRepository(db *gorm.DB) repository.MeterReadingRepository {
+	return &meterReadingRepository{db: db}
+}
+
+func (r *meterReadingRepository) Create(reading *entity.MeterReading) error {
+	m := &model.MeterReading{}
+	m.FromEntity(reading)
+	if err := r.db.Omit("Room").Create(m).Error; err != nil {
+		return err
+	}
+	*reading = *m.ToEntity()
+	return nil
+}
+
+func (r *meterReadingRepository) Update(reading *entity.MeterReading) error {
+	m := &model.MeterReading{}
+	m.FromEntity(reading)
+	return r.db.Omit("Room").Save(m).Error
+}
+
+func (r *meterReadingRepository) FindByRoomID(roomID uint) ([]entity.MeterReading, error) {
+	var models []model.MeterReading
+	if err := r.db.Where("room_id = ?", roomID).
+		Order("period DESC, utility ASC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return toMeterReadingEntities(models), nil
+}
+
+// FindPrevious returns the latest reading before period, or nil when this is
+// the first reading for the room and utility.
+func (r *meterReadingRepository) FindPrevious(roomID uint, utility, period string) (*entity.MeterReading, error) {
+	var models []model.MeterReading
+	if err := r.db.Where("room_id = ? AND utility = ? AND period < ?", roomID, utility, period).
+		Order("period DESC").
+		Limit(1).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return models[0].ToEntity(), nil
+}
+
+func (r *meterReadingRepository) FindUnbilled(roomID uint, period string) ([]entity.MeterReading, error) {
+	var models []model.MeterReading
+	if err := r.db.Where("room_id = ? AND period = ? AND payment_id IS NULL AND charge > 0", roomID, period).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return toMeterReadingEntities(models), nil
+}
+
+func (r *meterReadingRepository) FindFlagged() ([]entity.MeterReading, error) {
+	var models []model.MeterReading
+	if err := r.db.Where("flag <> ?", "").
+		Order("period DESC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return toMeterReadingEntities(models), nil
+}
+
+func toMeterReadingEntities(models []model.MeterReading) []entity.MeterReading {
+	entities := make([]entity.MeterReading, len(models))
+	for i, m := range models {
+		entities[i] = *m.ToEntity()
+	}
+	return entities
+}
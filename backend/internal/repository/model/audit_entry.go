@@ -0,0 +1,63 @@
+package model
+
+import (
+	"encoding/json"
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type AuditEntry struct {
+	ID             uint   `gorm:"primaryKey"`
+	OrganizationID uint   `gorm:"index"`
+	UserID         uint   `gorm:"index"`
+	Role           string `gorm:"size:20"`
+	Action         string `gorm:"size:50;not null;index"`
+	EntityType     string `gorm:"size:50;index:idx_audit_entries_entity"`
+	EntityID       uint   `gorm:"index:idx_audit_entries_entity"`
+	IP             string `gorm:"size:45"`
+	Detail         string `gorm:"type:text"`
+	Before         string `gorm:"type:text"`
+	After          string `gorm:"type:text"`
+	CreatedAt      time.Time
+}
+
+func (AuditEntry) TableName() string {
+	return "audit_entries"
+}
+
+func (m *AuditEntry) ToEntity() *entity.AuditEntry {
+	return &entity.AuditEntry{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		Role:       m.Role,
+		Action:     m.Action,
+		EntityType: m.EntityType,
+		EntityID:   m.EntityID,
+		IP:         m.IP,
+		Detail:     m.Detail,
+		Before:     rawJSON(m.Before),
+		After:      rawJSON(m.After),
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func (m *AuditEntry) FromEntity(e *entity.AuditEntry) {
+	m.ID = e.ID
+	m.UserID = e.UserID
+	m.Role = e.Role
+	m.Action = e.Action
+	m.EntityType = e.EntityType
+	m.EntityID = e.EntityID
+	m.IP = e.IP
+	m.Detail = e.Detail
+	m.Before = string(e.Before)
+	m.After = string(e.After)
+	m.CreatedAt = e.CreatedAt
+}
+
+func rawJSON(s string) json.RawMessage {
+	if s == "" {
+		return nil
+	}
+	return json.RawMessage(s)
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type Deposit struct {
+	ID             uint   `gorm:"primaryKey"`
+	OrganizationID uint   `gorm:"index"`
+	TenantID       uint   `gorm:"not null;uniqueIndex"`
+	Status         string `gorm:"size:20;not null;default:'held'"`
+	ClosedAt       *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Tenant         Tenant         `gorm:"foreignKey:TenantID"`
+	Entries        []DepositEntry `gorm:"foreignKey:DepositID"`
+}
+
+func (Deposit) TableName() string {
+	return "deposits"
+}
+
+func (m *Deposit) ToEntity() *entity.Deposit {
+	deposit := &entity.Deposit{
+		ID:        m.ID,
+		TenantID:  m.TenantID,
+		Status:    m.Status,
+		ClosedAt:  m.ClosedAt,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if m.Entries != nil {
+		deposit.Entries = make([]entity.DepositEntry, len(m.Entries))
+		for i, e := range m.Entries {
+			deposit.Entries[i] = *e.ToEntity()
+		}
+	}
+	return deposit
+}
+
+func (m *Deposit) FromEntity(e *entity.Deposit) {
+	m.ID = e.ID
+	m.TenantID = e.TenantID
+	m.Status = e.Status
+	m.ClosedAt = e.ClosedAt
+}
+
+type DepositEntry struct {
+	ID             uint         `gorm:"primaryKey"`
+	OrganizationID uint         `gorm:"index"`
+	DepositID      uint         `gorm:"not null;index"`
+	Type           string       `gorm:"size:20;not null"`
+	Amount         entity.Money `gorm:"type:numeric(18,2);not null"`
+	Reason         string       `gorm:"type:text"`
+	PaymentID      *uint        `gorm:"index"`
+	CreatedAt      time.Time
+}
+
+func (DepositEntry) TableName() string {
+	return "deposit_entries"
+}
+
+func (m *DepositEntry) ToEntity() *entity.DepositEntry {
+	return &entity.DepositEntry{
+		ID:        m.ID,
+		DepositID: m.DepositID,
+		Type:      m.Type,
+		Amount:    m.Amount,
+		Reason:    m.Reason,
+		PaymentID: m.PaymentID,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func (m *DepositEntry) FromEntity(e *entity.DepositEntry) {
+	m.ID = e.ID
+	m.DepositID = e.DepositID
+	m.Type = e.Type
+	m.Amount = e.Amount
+	m.Reason = e.Reason
+	m.PaymentID = e.PaymentID
+	m.CreatedAt = e.CreatedAt
+}
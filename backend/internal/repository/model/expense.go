@@ -6,12 +6,14 @@ import (
 )
 
 type Expense struct {
-	ID          uint      `gorm:"primaryKey"`
-	Description string    `gorm:"size:255;not null"`
-	Amount      float64   `gorm:"not null"`
-	ExpenseDate time.Time `gorm:"not null"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID             uint         `gorm:"primaryKey"`
+	OrganizationID uint         `gorm:"index"`
+	PropertyID     uint         `gorm:"index"`
+	Description    string       `gorm:"size:255;not null"`
+	Amount         entity.Money `gorm:"type:numeric(18,2);not null"`
+	ExpenseDate    time.Time    `gorm:"not null"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 func (Expense) TableName() string {
@@ -21,6 +23,7 @@ func (Expense) TableName() string {
 func (m *Expense) ToEntity() *entity.Expense {
 	return &entity.Expense{
 		ID:          m.ID,
+		PropertyID:  m.PropertyID,
 		Description: m.Description,
 		Amount:      m.Amount,
 		ExpenseDate: m.ExpenseDate,
@@ -31,6 +34,7 @@ func (m *Expense) ToEntity() *entity.Expense {
 
 func (m *Expense) FromEntity(e *entity.Expense) {
 	m.ID = e.ID
+	m.PropertyID = e.PropertyID
 	m.Description = e.Description
 	m.Amount = e.Amount
 	m.ExpenseDate = e.ExpenseDate
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type Invitation struct {
+	ID             uint      `gorm:"primaryKey"`
+	OrganizationID uint      `gorm:"index"`
+	Email          string    `gorm:"size:100;not null"`
+	Role           string    `gorm:"size:20;not null"`
+	TokenHash      string    `gorm:"size:64;not null;uniqueIndex"`
+	InvitedBy      uint      `gorm:"not null"`
+	ExpiresAt      time.Time `gorm:"not null"`
+	AcceptedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (Invitation) TableName() string {
+	return "invitations"
+}
+
+func (m *Invitation) ToEntity() *entity.Invitation {
+	return &entity.Invitation{
+		ID:             m.ID,
+		OrganizationID: m.OrganizationID,
+		Email:          m.Email,
+		Role:           m.Role,
+		TokenHash:      m.TokenHash,
+		InvitedBy:      m.InvitedBy,
+		ExpiresAt:      m.ExpiresAt,
+		AcceptedAt:     m.AcceptedAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+func (m *Invitation) FromEntity(e *entity.Invitation) {
+	m.ID = e.ID
+	m.OrganizationID = e.OrganizationID
+	m.Email = e.Email
+	m.Role = e.Role
+	m.TokenHash = e.TokenHash
+	m.InvitedBy = e.InvitedBy
+	m.ExpiresAt = e.ExpiresAt
+	m.AcceptedAt = e.AcceptedAt
+	m.CreatedAt = e.CreatedAt
+	m.UpdatedAt = e.UpdatedAt
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type LateFeePolicy struct {
+	ID             uint         `gorm:"primaryKey"`
+	OrganizationID uint         `gorm:"index;uniqueIndex:idx_late_fee_policies_organization_property"`
+	PropertyID     uint         `gorm:"uniqueIndex:idx_late_fee_policies_organization_property"`
+	Type           string       `gorm:"size:20;not null;default:'flat'"`
+	Amount         entity.Money `gorm:"type:numeric(18,2);not null;default:0"`
+	Percentage     float64      `gorm:"not null;default:0"`
+	Cap            entity.Money `gorm:"type:numeric(18,2);not null;default:0"`
+	GraceDays      int          `gorm:"not null;default:0"`
+	Enabled        bool         `gorm:"not null;default:false"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (LateFeePolicy) TableName() string {
+	return "late_fee_policies"
+}
+
+func (m *LateFeePolicy) ToEntity() *entity.LateFeePolicy {
+	return &entity.LateFeePolicy{
+		ID:         m.ID,
+		PropertyID: m.PropertyID,
+		Type:       m.Type,
+		Amount:     m.Amount,
+		Percentage: m.Percentage,
+		Cap:        m.Cap,
+		GraceDays:  m.GraceDays,
+		Enabled:    m.Enabled,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+func (m *LateFeePolicy) FromEntity(e *entity.LateFeePolicy) {
+	m.ID = e.ID
+	m.PropertyID = e.PropertyID
+	m.Type = e.Type
+	m.Amount = e.Amount
+	m.Percentage = e.Percentage
+	m.Cap = e.Cap
+	m.GraceDays = e.GraceDays
+	m.Enabled = e.Enabled
+	m.CreatedAt = e.CreatedAt
+}
@@ -0,0 +1,79 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type Lease struct {
+	ID                uint         `gorm:"primaryKey"`
+	OrganizationID    uint         `gorm:"index"`
+	TenantID          uint         `gorm:"not null;index"`
+	RoomID            uint         `gorm:"not null;index"`
+	StartDate         time.Time    `gorm:"not null"`
+	EndDate           time.Time    `gorm:"not null;index"`
+	TermMonths        int          `gorm:"not null"`
+	Rent              entity.Money `gorm:"type:numeric(18,2);not null"`
+	BillingCycle      string       `gorm:"size:20;not null;default:'monthly'"`
+	Deposit           entity.Money `gorm:"type:numeric(18,2);not null;default:0"`
+	NoticeDays        int          `gorm:"not null;default:0"`
+	Status            string       `gorm:"size:20;not null;default:'active'"`
+	TerminatedAt      *time.Time
+	TerminationReason string `gorm:"type:text"`
+	RenewedFromID     *uint
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Tenant            *Tenant `gorm:"foreignKey:TenantID"`
+	Room              *Room   `gorm:"foreignKey:RoomID"`
+}
+
+func (Lease) TableName() string {
+	return "leases"
+}
+
+func (m *Lease) ToEntity() *entity.Lease {
+	lease := &entity.Lease{
+		ID:                m.ID,
+		TenantID:          m.TenantID,
+		RoomID:            m.RoomID,
+		StartDate:         m.StartDate,
+		EndDate:           m.EndDate,
+		TermMonths:        m.TermMonths,
+		Rent:              m.Rent,
+		BillingCycle:      m.BillingCycle,
+		Deposit:           m.Deposit,
+		NoticeDays:        m.NoticeDays,
+		Status:            m.Status,
+		TerminatedAt:      m.TerminatedAt,
+		TerminationReason: m.TerminationReason,
+		RenewedFromID:     m.RenewedFromID,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+	}
+	if m.Tenant != nil {
+		lease.Tenant = m.Tenant.ToEntity()
+	}
+	if m.Room != nil {
+		lease.Room = m.Room.ToEntity()
+	}
+	return lease
+}
+
+func (m *Lease) FromEntity(e *entity.Lease) {
+	m.ID = e.ID
+	m.TenantID = e.TenantID
+	m.RoomID = e.RoomID
+	m.StartDate = e.StartDate
+	m.EndDate = e.EndDate
+	m.TermMonths = e.TermMonths
+	m.Rent = e.Rent
+	m.BillingCycle = e.BillingCycle
+	m.Deposit = e.Deposit
+	m.NoticeDays = e.NoticeDays
+	m.Status = e.Status
+	m.TerminatedAt = e.TerminatedAt
+	m.TerminationReason = e.TerminationReason
+	m.RenewedFromID = e.RenewedFromID
+	m.CreatedAt = e.CreatedAt
+	m.UpdatedAt = e.UpdatedAt
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type Letterhead struct {
+	ID             uint   `gorm:"primaryKey"`
+	OrganizationID uint   `gorm:"index"`
+	Name           string `gorm:"size:100;not null"`
+	Address        string `gorm:"type:text"`
+	Phone          string `gorm:"size:20"`
+	Email          string `gorm:"size:100"`
+	Footer         string `gorm:"type:text"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (Letterhead) TableName() string {
+	return "letterheads"
+}
+
+func (m *Letterhead) ToEntity() *entity.Letterhead {
+	return &entity.Letterhead{
+		ID:        m.ID,
+		Name:      m.Name,
+		Address:   m.Address,
+		Phone:     m.Phone,
+		Email:     m.Email,
+		Footer:    m.Footer,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func (m *Letterhead) FromEntity(e *entity.Letterhead) {
+	m.ID = e.ID
+	m.Name = e.Name
+	m.Address = e.Address
+	m.Phone = e.Phone
+	m.Email = e.Email
+	m.Footer = e.Footer
+	m.CreatedAt = e.CreatedAt
+}
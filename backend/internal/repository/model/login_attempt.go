@@ -0,0 +1,39 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type LoginAttempt struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"index"`
+	Email     string `gorm:"size:100;index"`
+	IP        string `gorm:"size:45"`
+	Reason    string `gorm:"size:30;not null"`
+	CreatedAt time.Time
+}
+
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+func (m *LoginAttempt) ToEntity() *entity.LoginAttempt {
+	return &entity.LoginAttempt{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Email:     m.Email,
+		IP:        m.IP,
+		Reason:    m.Reason,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func (m *LoginAttempt) FromEntity(e *entity.LoginAttempt) {
+	m.ID = e.ID
+	m.UserID = e.UserID
+	m.Email = e.Email
+	m.IP = e.IP
+	m.Reason = e.Reason
+	m.CreatedAt = e.CreatedAt
+}
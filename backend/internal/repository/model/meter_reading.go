@@ -0,0 +1,58 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type MeterReading struct {
+	ID             uint         `gorm:"primaryKey"`
+	OrganizationID uint         `gorm:"index"`
+	RoomID         uint         `gorm:"not null;uniqueIndex:idx_meter_readings_room_utility_period"`
+	Utility        string       `gorm:"size:20;not null;uniqueIndex:idx_meter_readings_room_utility_period"`
+	Period         string       `gorm:"size:7;not null;uniqueIndex:idx_meter_readings_room_utility_period"`
+	Reading        float64      `gorm:"not null"`
+	Consumption    float64      `gorm:"not null;default:0"`
+	Charge         entity.Money `gorm:"type:numeric(18,2);not null;default:0"`
+	Flag           string       `gorm:"size:20;index"`
+	PaymentID      *uint        `gorm:"index"`
+	RecordedBy     uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Room           Room `gorm:"foreignKey:RoomID"`
+}
+
+func (MeterReading) TableName() string {
+	return "meter_readings"
+}
+
+func (m *MeterReading) ToEntity() *entity.MeterReading {
+	return &entity.MeterReading{
+		ID:          m.ID,
+		RoomID:      m.RoomID,
+		Utility:     m.Utility,
+		Period:      m.Period,
+		Reading:     m.Reading,
+		Consumption: m.Consumption,
+		Charge:      m.Charge,
+		Flag:        m.Flag,
+		PaymentID:   m.PaymentID,
+		RecordedBy:  m.RecordedBy,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func (m *MeterReading) FromEntity(e *entity.MeterReading) {
+	m.ID = e.ID
+	m.RoomID = e.RoomID
+	m.Utility = e.Utility
+	m.Period = e.Period
+	m.Reading = e.Reading
+	m.Consumption = e.Consumption
+	m.Charge = e.Charge
+	m.Flag = e.Flag
+	m.PaymentID = e.PaymentID
+	m.RecordedBy = e.RecordedBy
+	m.CreatedAt = e.CreatedAt
+}
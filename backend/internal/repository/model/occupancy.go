@@ -0,0 +1,55 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type Occupancy struct {
+	ID             uint      `gorm:"primaryKey"`
+	OrganizationID uint      `gorm:"index"`
+	RoomID         uint      `gorm:"not null;index"`
+	TenantID       uint      `gorm:"not null;index"`
+	StartDate      time.Time `gorm:"not null"`
+	EndDate        *time.Time
+	Reason         string `gorm:"type:text"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Room           *Room   `gorm:"foreignKey:RoomID"`
+	Tenant         *Tenant `gorm:"foreignKey:TenantID"`
+}
+
+func (Occupancy) TableName() string {
+	return "occupancies"
+}
+
+func (m *Occupancy) ToEntity() *entity.Occupancy {
+	occupancy := &entity.Occupancy{
+		ID:        m.ID,
+		RoomID:    m.RoomID,
+		TenantID:  m.TenantID,
+		StartDate: m.StartDate,
+		EndDate:   m.EndDate,
+		Reason:    m.Reason,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if m.Room != nil {
+		occupancy.Room = m.Room.ToEntity()
+	}
+	if m.Tenant != nil {
+		occupancy.Tenant = m.Tenant.ToEntity()
+	}
+	return occupancy
+}
+
+func (m *Occupancy) FromEntity(e *entity.Occupancy) {
+	m.ID = e.ID
+	m.RoomID = e.RoomID
+	m.TenantID = e.TenantID
+	m.StartDate = e.StartDate
+	m.EndDate = e.EndDate
+	m.Reason = e.Reason
+	m.CreatedAt = e.CreatedAt
+	m.UpdatedAt = e.UpdatedAt
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type Organization struct {
+	ID               uint   `gorm:"primaryKey"`
+	Name             string `gorm:"size:100;not null"`
+	RequireTwoFactor bool   `gorm:"not null;default:false"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+func (m *Organization) ToEntity() *entity.Organization {
+	return &entity.Organization{
+		ID:               m.ID,
+		Name:             m.Name,
+		RequireTwoFactor: m.RequireTwoFactor,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+func (m *Organization) FromEntity(e *entity.Organization) {
+	m.ID = e.ID
+	m.Name = e.Name
+	m.RequireTwoFactor = e.RequireTwoFactor
+	m.CreatedAt = e.CreatedAt
+	m.UpdatedAt = e.UpdatedAt
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type PasswordReset struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (PasswordReset) TableName() string {
+	return "password_resets"
+}
+
+func (m *PasswordReset) ToEntity() *entity.PasswordReset {
+	return &entity.PasswordReset{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		TokenHash: m.TokenHash,
+		ExpiresAt: m.ExpiresAt,
+		UsedAt:    m.UsedAt,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func (m *PasswordReset) FromEntity(e *entity.PasswordReset) {
+	m.ID = e.ID
+	m.UserID = e.UserID
+	m.TokenHash = e.TokenHash
+	m.ExpiresAt = e.ExpiresAt
+	m.UsedAt = e.UsedAt
+	m.CreatedAt = e.CreatedAt
+}
@@ -8,16 +8,21 @@ import (
 )
 
 type Payment struct {
-	ID            uint      `gorm:"primaryKey"`
-	TenantID      uint      `gorm:"not null;index"`
-	Amount        float64   `gorm:"not null"`
-	DueDate       time.Time `gorm:"not null"`
-	PaidAt        *time.Time
-	Status        string `gorm:"size:20;not null;default:'unpaid'"`
-	PaymentMethod string `gorm:"size:20"`
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	Tenant        Tenant `gorm:"foreignKey:TenantID"`
+	ID             uint         `gorm:"primaryKey"`
+	OrganizationID uint         `gorm:"index"`
+	PropertyID     uint         `gorm:"index"`
+	TenantID       uint         `gorm:"not null;index;uniqueIndex:idx_payments_tenant_period,where:period <> ''"`
+	Amount         entity.Money `gorm:"type:numeric(18,2);not null"`
+	DueDate        time.Time    `gorm:"not null"`
+	PaidAt         *time.Time
+	Status         string `gorm:"size:20;not null;default:'unpaid'"`
+	PaymentMethod  string `gorm:"size:20"`
+	Period         string `gorm:"size:7;uniqueIndex:idx_payments_tenant_period,where:period <> ''"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Tenant         Tenant               `gorm:"foreignKey:TenantID"`
+	LineItems      []PaymentLineItem    `gorm:"foreignKey:PaymentID"`
+	Transactions   []PaymentTransaction `gorm:"foreignKey:PaymentID"`
 }
 
 func (Payment) TableName() string {
@@ -25,28 +30,46 @@ func (Payment) TableName() string {
 }
 
 func (m *Payment) ToEntity() *entity.Payment {
-	return &entity.Payment{
+	payment := &entity.Payment{
 		ID:            m.ID,
+		PropertyID:    m.PropertyID,
 		TenantID:      m.TenantID,
 		Amount:        m.Amount,
 		DueDate:       m.DueDate,
 		PaidAt:        m.PaidAt,
 		Status:        m.Status,
 		PaymentMethod: m.PaymentMethod,
+		Period:        m.Period,
 		CreatedAt:     m.CreatedAt,
 		UpdatedAt:     m.UpdatedAt,
 		Tenant:        *m.Tenant.ToEntity(),
 	}
+	if m.LineItems != nil {
+		payment.LineItems = make([]entity.PaymentLineItem, len(m.LineItems))
+		for i, item := range m.LineItems {
+			payment.LineItems[i] = *item.ToEntity()
+		}
+	}
+	if m.Transactions != nil {
+		payment.Transactions = make([]entity.PaymentTransaction, len(m.Transactions))
+		for i, t := range m.Transactions {
+			payment.Transactions[i] = *t.ToEntity()
+		}
+	}
+	return payment
 }
 
 func (m *Payment) FromEntity(e *entity.Payment) {
 	m.ID = e.ID
+	m.PropertyID = e.PropertyID
 	m.TenantID = e.TenantID
 	m.Amount = e.Amount
 	m.DueDate = e.DueDate
 	m.PaidAt = e.PaidAt
 	m.Status = e.Status
 	m.PaymentMethod = e.PaymentMethod
+	m.Period = e.Period
+	m.CreatedAt = e.CreatedAt
 }
 
 func (p *Payment) BeforeUpdate(tx *gorm.DB) error {
@@ -60,3 +83,88 @@ func (p *Payment) BeforeUpdate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+type PaymentLineItem struct {
+	ID             uint         `gorm:"primaryKey"`
+	OrganizationID uint         `gorm:"index"`
+	PaymentID      uint         `gorm:"not null;index"`
+	Type           string       `gorm:"size:20;not null"`
+	Description    string       `gorm:"size:255"`
+	Amount         entity.Money `gorm:"type:numeric(18,2);not null"`
+	Waived         bool         `gorm:"not null;default:false"`
+	WaivedBy       *uint
+	WaivedAt       *time.Time
+	WaiverReason   string `gorm:"type:text"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (PaymentLineItem) TableName() string {
+	return "payment_line_items"
+}
+
+func (m *PaymentLineItem) ToEntity() *entity.PaymentLineItem {
+	return &entity.PaymentLineItem{
+		ID:           m.ID,
+		PaymentID:    m.PaymentID,
+		Type:         m.Type,
+		Description:  m.Description,
+		Amount:       m.Amount,
+		Waived:       m.Waived,
+		WaivedBy:     m.WaivedBy,
+		WaivedAt:     m.WaivedAt,
+		WaiverReason: m.WaiverReason,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func (m *PaymentLineItem) FromEntity(e *entity.PaymentLineItem) {
+	m.ID = e.ID
+	m.PaymentID = e.PaymentID
+	m.Type = e.Type
+	m.Description = e.Description
+	m.Amount = e.Amount
+	m.Waived = e.Waived
+	m.WaivedBy = e.WaivedBy
+	m.WaivedAt = e.WaivedAt
+	m.WaiverReason = e.WaiverReason
+	m.CreatedAt = e.CreatedAt
+}
+
+type PaymentTransaction struct {
+	ID             uint         `gorm:"primaryKey"`
+	OrganizationID uint         `gorm:"index"`
+	PaymentID      uint         `gorm:"not null;index"`
+	Amount         entity.Money `gorm:"type:numeric(18,2);not null"`
+	Method         string       `gorm:"size:20"`
+	PaidAt         time.Time    `gorm:"not null;index"`
+	Note           string       `gorm:"type:text"`
+	CreatedAt      time.Time
+}
+
+func (PaymentTransaction) TableName() string {
+	return "payment_transactions"
+}
+
+func (m *PaymentTransaction) ToEntity() *entity.PaymentTransaction {
+	return &entity.PaymentTransaction{
+		ID:        m.ID,
+		PaymentID: m.PaymentID,
+		Amount:    m.Amount,
+		Method:    m.Method,
+		PaidAt:    m.PaidAt,
+		Note:      m.Note,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func (m *PaymentTransaction) FromEntity(e *entity.PaymentTransaction) {
+	m.ID = e.ID
+	m.PaymentID = e.PaymentID
+	m.Amount = e.Amount
+	m.Method = e.Method
+	m.PaidAt = e.PaidAt
+	m.Note = e.Note
+	m.CreatedAt = e.CreatedAt
+}
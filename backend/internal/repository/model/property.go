@@ -0,0 +1,49 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type Property struct {
+	ID             uint   `gorm:"primaryKey"`
+	OrganizationID uint   `gorm:"index"`
+	Name           string `gorm:"size:100;not null"`
+	Address        string `gorm:"type:text"`
+	Floors         int    `gorm:"not null;default:1"`
+	Phone          string `gorm:"size:20"`
+	Email          string `gorm:"size:100"`
+	ReceiptPrefix  string `gorm:"size:10;not null;default:'RCP'"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (Property) TableName() string {
+	return "properties"
+}
+
+func (m *Property) ToEntity() *entity.Property {
+	return &entity.Property{
+		ID:            m.ID,
+		Name:          m.Name,
+		Address:       m.Address,
+		Floors:        m.Floors,
+		Phone:         m.Phone,
+		Email:         m.Email,
+		ReceiptPrefix: m.ReceiptPrefix,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+func (m *Property) FromEntity(e *entity.Property) {
+	m.ID = e.ID
+	m.Name = e.Name
+	m.Address = e.Address
+	m.Floors = e.Floors
+	m.Phone = e.Phone
+	m.Email = e.Email
+	m.ReceiptPrefix = e.ReceiptPrefix
+	m.CreatedAt = e.CreatedAt
+	m.UpdatedAt = e.UpdatedAt
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type Receipt struct {
+	ID             uint      `gorm:"primaryKey"`
+	OrganizationID uint      `gorm:"index"`
+	PropertyID     uint      `gorm:"uniqueIndex:idx_receipts_property_year_sequence"`
+	PaymentID      uint      `gorm:"not null;uniqueIndex"`
+	Year           int       `gorm:"not null;uniqueIndex:idx_receipts_property_year_sequence"`
+	Sequence       int       `gorm:"not null;uniqueIndex:idx_receipts_property_year_sequence"`
+	Number         string    `gorm:"size:30;not null;index"`
+	IssuedAt       time.Time `gorm:"not null"`
+	CreatedAt      time.Time
+}
+
+func (Receipt) TableName() string {
+	return "receipts"
+}
+
+func (m *Receipt) ToEntity() *entity.Receipt {
+	return &entity.Receipt{
+		ID:         m.ID,
+		PropertyID: m.PropertyID,
+		PaymentID:  m.PaymentID,
+		Year:       m.Year,
+		Sequence:   m.Sequence,
+		Number:     m.Number,
+		IssuedAt:   m.IssuedAt,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func (m *Receipt) FromEntity(e *entity.Receipt) {
+	m.ID = e.ID
+	m.PropertyID = e.PropertyID
+	m.PaymentID = e.PaymentID
+	m.Year = e.Year
+	m.Sequence = e.Sequence
+	m.Number = e.Number
+	m.IssuedAt = e.IssuedAt
+	m.CreatedAt = e.CreatedAt
+}
+
+// ReceiptCounter holds the last receipt number handed out by a property in a
+// year.
+type ReceiptCounter struct {
+	PropertyID uint `gorm:"primaryKey;autoIncrement:false"`
+	Year       int  `gorm:"primaryKey;autoIncrement:false"`
+	LastValue  int  `gorm:"not null;default:0"`
+}
+
+func (ReceiptCounter) TableName() string {
+	return "receipt_counters"
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type RecoveryCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	CodeHash  string `gorm:"size:64;not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (RecoveryCode) TableName() string {
+	return "recovery_codes"
+}
+
+func (m *RecoveryCode) ToEntity() *entity.RecoveryCode {
+	return &entity.RecoveryCode{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		CodeHash:  m.CodeHash,
+		UsedAt:    m.UsedAt,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func (m *RecoveryCode) FromEntity(e *entity.RecoveryCode) {
+	m.ID = e.ID
+	m.UserID = e.UserID
+	m.CodeHash = e.CodeHash
+	m.UsedAt = e.UsedAt
+	m.CreatedAt = e.CreatedAt
+}
@@ -6,15 +6,18 @@ import (
 )
 
 type Room struct {
-	ID         uint    `gorm:"primaryKey"`
-	RoomNumber string  `gorm:"size:20;unique;not null"`
-	Price      float64 `gorm:"not null"`
-	Status     string  `gorm:"size:20;not null;default:'empty'"`
-	Facilities string  `gorm:"type:text"`
-	Notes      string  `gorm:"type:text"`
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	Tenant     *Tenant `gorm:"foreignKey:RoomID"`
+	ID             uint         `gorm:"primaryKey"`
+	OrganizationID uint         `gorm:"index"`
+	PropertyID     uint         `gorm:"index;uniqueIndex:idx_rooms_property_room_number"`
+	RoomNumber     string       `gorm:"size:20;not null;uniqueIndex:idx_rooms_property_room_number"`
+	Floor          int          `gorm:"not null;default:0"`
+	Price          entity.Money `gorm:"type:numeric(18,2);not null"`
+	Status         string       `gorm:"size:20;not null;default:'empty'"`
+	Facilities     string       `gorm:"type:text"`
+	Notes          string       `gorm:"type:text"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Tenant         *Tenant `gorm:"foreignKey:RoomID"`
 }
 
 func (Room) TableName() string {
@@ -24,7 +27,9 @@ func (Room) TableName() string {
 func (m *Room) ToEntity() *entity.Room {
 	room := &entity.Room{
 		ID:         m.ID,
+		PropertyID: m.PropertyID,
 		RoomNumber: m.RoomNumber,
+		Floor:      m.Floor,
 		Price:      m.Price,
 		Status:     m.Status,
 		Facilities: m.Facilities,
@@ -40,7 +45,9 @@ func (m *Room) ToEntity() *entity.Room {
 
 func (m *Room) FromEntity(e *entity.Room) {
 	m.ID = e.ID
+	m.PropertyID = e.PropertyID
 	m.RoomNumber = e.RoomNumber
+	m.Floor = e.Floor
 	m.Price = e.Price
 	m.Status = e.Status
 	m.Facilities = e.Facilities
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type Session struct {
+	ID            uint `gorm:"primaryKey"`
+	UserID        uint `gorm:"not null;index"`
+	RevokedAt     *time.Time
+	RevokedReason string `gorm:"size:50"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
+
+func (m *Session) ToEntity() *entity.Session {
+	return &entity.Session{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		RevokedAt:     m.RevokedAt,
+		RevokedReason: m.RevokedReason,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+func (m *Session) FromEntity(e *entity.Session) {
+	m.ID = e.ID
+	m.UserID = e.UserID
+	m.RevokedAt = e.RevokedAt
+	m.RevokedReason = e.RevokedReason
+	m.CreatedAt = e.CreatedAt
+	m.UpdatedAt = e.UpdatedAt
+}
+
+type RefreshToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	SessionID uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+func (m *RefreshToken) ToEntity() *entity.RefreshToken {
+	return &entity.RefreshToken{
+		ID:        m.ID,
+		SessionID: m.SessionID,
+		TokenHash: m.TokenHash,
+		ExpiresAt: m.ExpiresAt,
+		UsedAt:    m.UsedAt,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func (m *RefreshToken) FromEntity(e *entity.RefreshToken) {
+	m.ID = e.ID
+	m.SessionID = e.SessionID
+	m.TokenHash = e.TokenHash
+	m.ExpiresAt = e.ExpiresAt
+	m.UsedAt = e.UsedAt
+	m.CreatedAt = e.CreatedAt
+}
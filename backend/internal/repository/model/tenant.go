@@ -6,17 +6,20 @@ import (
 )
 
 type Tenant struct {
-	ID        uint      `gorm:"primaryKey"`
-	Name      string    `gorm:"size:100;not null"`
-	Phone     string    `gorm:"size:20;not null"`
-	RoomID    *uint     `gorm:"index"`
-	StartDate time.Time `gorm:"not null"`
-	EndDate   *time.Time
-	Status    string `gorm:"size:20;not null;default:'active'"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Room      *Room     `gorm:"foreignKey:RoomID"`
-	Payments  []Payment `gorm:"foreignKey:TenantID"`
+	ID             uint      `gorm:"primaryKey"`
+	OrganizationID uint      `gorm:"index"`
+	PropertyID     uint      `gorm:"index"`
+	Name           string    `gorm:"size:100;not null"`
+	Phone          string    `gorm:"size:20;not null"`
+	RoomID         *uint     `gorm:"index"`
+	StartDate      time.Time `gorm:"not null"`
+	EndDate        *time.Time
+	Status         string       `gorm:"size:20;not null;default:'active'"`
+	CreditBalance  entity.Money `gorm:"type:numeric(18,2);not null;default:0"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	Room           *Room     `gorm:"foreignKey:RoomID"`
+	Payments       []Payment `gorm:"foreignKey:TenantID"`
 }
 
 func (Tenant) TableName() string {
@@ -25,15 +28,17 @@ func (Tenant) TableName() string {
 
 func (m *Tenant) ToEntity() *entity.Tenant {
 	tenant := &entity.Tenant{
-		ID:        m.ID,
-		Name:      m.Name,
-		Phone:     m.Phone,
-		RoomID:    m.RoomID,
-		StartDate: m.StartDate,
-		EndDate:   m.EndDate,
-		Status:    m.Status,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
+		ID:            m.ID,
+		PropertyID:    m.PropertyID,
+		Name:          m.Name,
+		Phone:         m.Phone,
+		RoomID:        m.RoomID,
+		StartDate:     m.StartDate,
+		EndDate:       m.EndDate,
+		Status:        m.Status,
+		CreditBalance: m.CreditBalance,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
 	}
 	if m.Room != nil {
 		tenant.Room = m.Room.ToEntity()
@@ -49,10 +54,12 @@ func (m *Tenant) ToEntity() *entity.Tenant {
 
 func (m *Tenant) FromEntity(e *entity.Tenant) {
 	m.ID = e.ID
+	m.PropertyID = e.PropertyID
 	m.Name = e.Name
 	m.Phone = e.Phone
 	m.RoomID = e.RoomID
 	m.StartDate = e.StartDate
 	m.EndDate = e.EndDate
 	m.Status = e.Status
+	m.CreditBalance = e.CreditBalance
 }
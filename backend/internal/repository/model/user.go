@@ -7,13 +7,20 @@ import (
 
 // GORM Models (Database Layer)
 type User struct {
-	ID           uint   `gorm:"primaryKey"`
-	Name         string `gorm:"size:100;not null"`
-	Email        string `gorm:"size:100;unique;not null"`
-	PasswordHash string `gorm:"size:255;not null"`
-	Role         string `gorm:"size:20;not null;default:'staff'"`
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID               uint   `gorm:"primaryKey"`
+	OrganizationID   uint   `gorm:"index"`
+	Name             string `gorm:"size:100;not null"`
+	Email            string `gorm:"size:100;unique;not null"`
+	PasswordHash     string `gorm:"size:255;not null"`
+	Role             string `gorm:"size:20;not null;default:'staff'"`
+	Status           string `gorm:"size:20;not null;default:'active'"`
+	TwoFactorEnabled bool   `gorm:"not null;default:false"`
+	TOTPSecret       string `gorm:"column:totp_secret;size:64"`
+	TOTPLastStep     int64  `gorm:"column:totp_last_step;not null;default:0"`
+	FailedLogins     int    `gorm:"not null;default:0"`
+	LockedUntil      *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 }
 
 func (User) TableName() string {
@@ -22,20 +29,34 @@ func (User) TableName() string {
 
 func (m *User) ToEntity() *entity.User {
 	return &entity.User{
-		ID:           m.ID,
-		Name:         m.Name,
-		Email:        m.Email,
-		PasswordHash: m.PasswordHash,
-		Role:         m.Role,
-		CreatedAt:    m.CreatedAt,
-		UpdatedAt:    m.UpdatedAt,
+		ID:               m.ID,
+		OrganizationID:   m.OrganizationID,
+		Name:             m.Name,
+		Email:            m.Email,
+		PasswordHash:     m.PasswordHash,
+		Role:             m.Role,
+		Status:           m.Status,
+		TwoFactorEnabled: m.TwoFactorEnabled,
+		TOTPSecret:       m.TOTPSecret,
+		TOTPLastStep:     m.TOTPLastStep,
+		FailedLogins:     m.FailedLogins,
+		LockedUntil:      m.LockedUntil,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
 	}
 }
 
 func (m *User) FromEntity(e *entity.User) {
 	m.ID = e.ID
+	m.OrganizationID = e.OrganizationID
 	m.Name = e.Name
 	m.Email = e.Email
 	m.PasswordHash = e.PasswordHash
 	m.Role = e.Role
+	m.Status = e.Status
+	m.TwoFactorEnabled = e.TwoFactorEnabled
+	m.TOTPSecret = e.TOTPSecret
+	m.TOTPLastStep = e.TOTPLastStep
+	m.FailedLogins = e.FailedLogins
+	m.LockedUntil = e.LockedUntil
 }
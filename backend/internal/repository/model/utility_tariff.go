@@ -0,0 +1,82 @@
+package model
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+type UtilityTariff struct {
+	ID                    uint         `gorm:"primaryKey"`
+	OrganizationID        uint         `gorm:"uniqueIndex:idx_utility_tariffs_organization_utility"`
+	Utility               string       `gorm:"size:20;not null;uniqueIndex:idx_utility_tariffs_organization_utility"`
+	Unit                  string       `gorm:"size:10"`
+	FixedCharge           entity.Money `gorm:"type:numeric(18,2);not null;default:0"`
+	SpikeThresholdPercent float64      `gorm:"not null;default:0"`
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	Tiers                 []TariffTier `gorm:"foreignKey:TariffID"`
+}
+
+func (UtilityTariff) TableName() string {
+	return "utility_tariffs"
+}
+
+func (m *UtilityTariff) ToEntity() *entity.UtilityTariff {
+	tariff := &entity.UtilityTariff{
+		ID:                    m.ID,
+		Utility:               m.Utility,
+		Unit:                  m.Unit,
+		FixedCharge:           m.FixedCharge,
+		SpikeThresholdPercent: m.SpikeThresholdPercent,
+		CreatedAt:             m.CreatedAt,
+		UpdatedAt:             m.UpdatedAt,
+	}
+	if m.Tiers != nil {
+		tariff.Tiers = make([]entity.TariffTier, len(m.Tiers))
+		for i, t := range m.Tiers {
+			tariff.Tiers[i] = *t.ToEntity()
+		}
+	}
+	return tariff
+}
+
+func (m *UtilityTariff) FromEntity(e *entity.UtilityTariff) {
+	m.ID = e.ID
+	m.Utility = e.Utility
+	m.Unit = e.Unit
+	m.FixedCharge = e.FixedCharge
+	m.SpikeThresholdPercent = e.SpikeThresholdPercent
+	m.CreatedAt = e.CreatedAt
+	m.Tiers = make([]TariffTier, len(e.Tiers))
+	for i := range e.Tiers {
+		m.Tiers[i].FromEntity(&e.Tiers[i])
+	}
+}
+
+type TariffTier struct {
+	ID             uint         `gorm:"primaryKey"`
+	OrganizationID uint         `gorm:"index"`
+	TariffID       uint         `gorm:"not null;index"`
+	UpTo           float64      `gorm:"not null;default:0"`
+	Rate           entity.Money `gorm:"type:numeric(18,2);not null"`
+}
+
+func (TariffTier) TableName() string {
+	return "tariff_tiers"
+}
+
+func (m *TariffTier) ToEntity() *entity.TariffTier {
+	return &entity.TariffTier{
+		ID:       m.ID,
+		TariffID: m.TariffID,
+		UpTo:     m.UpTo,
+		Rate:     m.Rate,
+	}
+}
+
+func (m *TariffTier) FromEntity(e *entity.TariffTier) {
+	m.ID = e.ID
+	m.TariffID = e.TariffID
+	m.UpTo = e.UpTo
+	m.Rate = e.Rate
+}
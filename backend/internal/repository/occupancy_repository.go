@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+
+	"gorm.io/gorm"
+)
+
+// Occupancy Repository Implementation
+type occupancyRepository struct {
+	db *gorm.DB
+}
+
+func NewOccupancyRepository(db *gorm.DB) repository.OccupancyRepository {
+	return &occupancyRepository{db: db}
+}
+
+func (r *occupancyRepository) Create(occupancy *entity.Occupancy) error {
+	m := &model.Occupancy{}
+	m.FromEntity(occupancy)
+	if err := r.db.Omit("Room", "Tenant").Create(m).Error; err != nil {
+		return err
+	}
+	*occupancy = *m.ToEntity()
+	return nil
+}
+
+func (r *occupancyRepository) FindByRoomID(roomID uint) ([]entity.Occupancy, error) {
+	var models []model.Occupancy
+	if err := r.db.Preload("Tenant").
+		Where("room_id = ?", roomID).
+		Order("start_date DESC, id DESC").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]entity.Occupancy, len(models))
+	for i, m := range models {
+		entities[i] = *m.ToEntity()
+	}
+	return entities, nil
+}
+
+func (r *occupancyRepository) FindOpenByTenantID(tenantID uint) (*entity.Occupancy, error) {
+	var models []model.Occupancy
+	if err := r.db.Where("tenant_id = ? AND end_date IS NULL", tenantID).
+		Order("start_date DESC, id DESC").
+		Limit(1).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return models[0].ToEntity(), nil
+}
+
+func (r *occupancyRepository) Update(occupancy *entity.Occupancy) error {
+	m := &model.Occupancy{}
+	m.FromEntity(occupancy)
+	return r.db.Omit("Room", "Tenant", "CreatedAt").Save(m).Error
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+
+	"gorm.io/gorm"
+)
+
+// Organization Repository Implementation
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) repository.OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+func (r *organizationRepository) Create(organization *entity.Organization) error {
+	m := &model.Organization{}
+	m.FromEntity(organization)
+	if err := r.db.Create(m).Error; err != nil {
+		return err
+	}
+	*organization = *m.ToEntity()
+	return nil
+}
+
+func (r *organizationRepository) FindAll() ([]entity.Organization, error) {
+	var models []model.Organization
+	if err := r.db.Order("id ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]entity.Organization, len(models))
+	for i, m := range models {
+		entities[i] = *m.ToEntity()
+	}
+	return entities, nil
+}
+
+func (r *organizationRepository) FindByID(id uint) (*entity.Organization, error) {
+	var m model.Organization
+	if err := r.db.First(&m, id).Error; err != nil {
+		return nil, err
+	}
+	return m.ToEntity(), nil
+}
+
+func (r *organizationRepository) Update(organization *entity.Organization) error {
+	m := &model.Organization{}
+	m.FromEntity(organization)
+	return r.db.Omit("CreatedAt").Save(m).Error
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+type organizationKey struct{}
+
+// ForOrganization returns db scoped to one organization. Once
+// RegisterOrganizationScope is installed, every query, update and delete made
+// through it is limited to rows of that organization, and every row it
+// creates or saves is stamped with it. Models without an OrganizationID field
+// are left alone.
+func ForOrganization(db *gorm.DB, organizationID uint) *gorm.DB {
+	return db.WithContext(context.WithValue(db.Statement.Context, organizationKey{}, organizationID))
+}
+
+// RegisterOrganizationScope installs the callbacks that enforce
+// ForOrganization. Connections that were not scoped are not affected.
+func RegisterOrganizationScope(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("ezkost:organization_create", stampOrganization); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("ezkost:organization_query", whereOrganization); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("ezkost:organization_row", whereOrganization); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("ezkost:organization_update", func(db *gorm.DB) {
+		stampOrganization(db)
+		whereOrganization(db)
+	}); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("ezkost:organization_delete", whereOrganization)
+}
+
+// organizationField returns the organization db is scoped to and the model's
+// OrganizationID field, if both exist.
+func organizationField(db *gorm.DB) (uint, *schema.Field, bool) {
+	organizationID, ok := db.Statement.Context.Value(organizationKey{}).(uint)
+	if !ok || db.Statement.Schema == nil {
+		return 0, nil, false
+	}
+	field := db.Statement.Schema.LookUpField("OrganizationID")
+	return organizationID, field, field != nil
+}
+
+func organizationColumn(db *gorm.DB, field *schema.Field) clause.Column {
+	return clause.Column{Table: db.Statement.Table, Name: field.DBName}
+}
+
+func whereOrganization(db *gorm.DB) {
+	organizationID, field, ok := organizationField(db)
+	if !ok {
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: organizationColumn(db, field), Value: organizationID},
+	}})
+}
+
+// stampOrganization sets OrganizationID on the rows being written. An upsert,
+// which is how Save retries a row its update did not find, only overwrites
+// rows of the same organization.
+func stampOrganization(db *gorm.DB) {
+	organizationID, field, ok := organizationField(db)
+	if !ok {
+		return
+	}
+
+	rows := db.Statement.ReflectValue
+	switch rows.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rows.Len(); i++ {
+			setOrganization(db, field, reflect.Indirect(rows.Index(i)), organizationID)
+		}
+	case reflect.Struct:
+		setOrganization(db, field, rows, organizationID)
+	}
+
+	if c, ok := db.Statement.Clauses["ON CONFLICT"]; ok {
+		if onConflict, ok := c.Expression.(clause.OnConflict); ok && !onConflict.DoNothing {
+			onConflict.Where.Exprs = append(onConflict.Where.Exprs,
+				clause.Eq{Column: organizationColumn(db, field), Value: organizationID})
+			db.Statement.AddClause(onConflict)
+		}
+	}
+}
+
+func setOrganization(db *gorm.DB, field *schema.Field, row reflect.Value, organizationID uint) {
+	if !row.CanAddr() {
+		return
+	}
+	if err := field.Set(db.Statement.Context, row, organizationID); err != nil {
+		db.AddError(err)
+	}
+}
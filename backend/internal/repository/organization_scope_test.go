@@ -0,0 +1,304 @@
+package repository_test
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	domainrepository "ezkost/internal/domain/repository"
+	"ezkost/internal/repository"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// organizationData is what one organization stores in the isolation tests:
+// a property with a room and a tenant, whose overdue bill has a line item and a transfer,
+// and an expense.
+type organizationData struct {
+	property *entity.Property
+	room     *entity.Room
+	tenant   *entity.Tenant
+	payment  *entity.Payment
+	item     *entity.PaymentLineItem
+	expense  *entity.Expense
+	employee *entity.User
+}
+
+func seedOrganization(t *testing.T, repos *domainrepository.Repositories, name, amount string) *organizationData {
+	t.Helper()
+	price, err := entity.ParseMoney(amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &organizationData{property: &entity.Property{Name: "Kos " + name}}
+	mustDo(t, repos.Properties.Create(d.property))
+	d.room = &entity.Room{PropertyID: d.property.ID, RoomNumber: "101", Price: price}
+	d.expense = &entity.Expense{PropertyID: d.property.ID, Description: "Listrik " + name, Amount: price, ExpenseDate: time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)}
+	d.employee = &entity.User{Name: "Staff " + name, Email: name + "@example.com", PasswordHash: "hash"}
+	mustDo(t, repos.Rooms.Create(d.room))
+	d.tenant = &entity.Tenant{PropertyID: d.property.ID, Name: "Tenant " + name, Phone: "0812", RoomID: &d.room.ID, StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	mustDo(t, repos.Tenants.Create(d.tenant))
+	mustDo(t, repos.Tenants.AdjustCredit(d.tenant.ID, price))
+	d.payment = &entity.Payment{PropertyID: d.property.ID, TenantID: d.tenant.ID, Amount: price, DueDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Period: "2026-02"}
+	mustDo(t, repos.Payments.Create(d.payment))
+	d.item = &entity.PaymentLineItem{PaymentID: d.payment.ID, Type: "late_fee", Description: "Late fee", Amount: price}
+	mustDo(t, repos.Payments.AddLineItem(d.item))
+	mustDo(t, repos.Payments.AddTransaction(&entity.PaymentTransaction{PaymentID: d.payment.ID, Amount: price, Method: "transfer", PaidAt: time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC)}))
+	mustDo(t, repos.Expenses.Create(d.expense))
+	mustDo(t, repos.Users.Create(d.employee))
+	return d
+}
+
+func mustDo(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func wantNotFound(t *testing.T, what string, err error) {
+	t.Helper()
+	if !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("%s: got %v, want not found", what, err)
+	}
+}
+
+// openOrganizations returns the repositories of two organizations sharing
+// one database, each holding the same kind of data.
+func openOrganizations(t *testing.T, open func(t *testing.T) *gorm.DB) (a, b *domainrepository.Repositories, dataA, dataB *organizationData) {
+	t.Helper()
+	db := open(t)
+	a = repository.NewRepositories(createOrganization(t, db, "Kos Melati"))
+	b = repository.NewRepositories(createOrganization(t, db, "Kos Mawar"))
+	return a, b, seedOrganization(t, a, "melati", "1000"), seedOrganization(t, b, "mawar", "2000")
+}
+
+func TestOrganizationIsolationReads(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, open func(t *testing.T) *gorm.DB) {
+		a, _, own, other := openOrganizations(t, open)
+		now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+		t.Run("FindByID", func(t *testing.T) {
+			_, err := a.Properties.FindByID(other.property.ID)
+			wantNotFound(t, "property", err)
+			_, err = a.Rooms.FindByID(other.room.ID)
+			wantNotFound(t, "room", err)
+			_, err = a.Tenants.FindByID(other.tenant.ID)
+			wantNotFound(t, "tenant", err)
+			_, err = a.Payments.FindByID(other.payment.ID)
+			wantNotFound(t, "payment", err)
+			_, err = a.Payments.FindLineItemByID(other.item.ID)
+			wantNotFound(t, "line item", err)
+			_, err = a.Expenses.FindByID(other.expense.ID)
+			wantNotFound(t, "expense", err)
+			_, err = a.Users.FindByID(other.employee.ID)
+			wantNotFound(t, "user", err)
+			_, err = a.Users.FindByEmail(other.employee.Email)
+			wantNotFound(t, "user by email", err)
+		})
+
+		t.Run("Lists", func(t *testing.T) {
+			rooms, _, err := a.Rooms.Find(domainrepository.RoomQuery{})
+			mustDo(t, err)
+			wantIDs(t, "rooms", ids(rooms, func(r entity.Room) uint { return r.ID }), own.room.ID)
+			tenants, _, err := a.Tenants.Find(domainrepository.TenantQuery{Search: "tenant"})
+			mustDo(t, err)
+			wantIDs(t, "tenants", ids(tenants, func(t entity.Tenant) uint { return t.ID }), own.tenant.ID)
+			active, err := a.Tenants.FindByStatus("active")
+			mustDo(t, err)
+			wantIDs(t, "active tenants", ids(active, func(t entity.Tenant) uint { return t.ID }), own.tenant.ID)
+			expenses, _, err := a.Expenses.Find(domainrepository.ExpenseQuery{})
+			mustDo(t, err)
+			wantIDs(t, "expenses", ids(expenses, func(e entity.Expense) uint { return e.ID }), own.expense.ID)
+			users, err := a.Users.FindAll()
+			mustDo(t, err)
+			wantIDs(t, "users", ids(users, func(u entity.User) uint { return u.ID }), own.employee.ID)
+			overdue, err := a.Payments.FindOverdue(now)
+			mustDo(t, err)
+			wantIDs(t, "overdue", ids(overdue, func(p entity.Payment) uint { return p.ID }), own.payment.ID)
+			byTenant, err := a.Payments.FindByTenantID(other.tenant.ID)
+			mustDo(t, err)
+			wantIDs(t, "payments of the other tenant", ids(byTenant, func(p entity.Payment) uint { return p.ID }))
+			exists, err := a.Payments.ExistsForPeriod(other.tenant.ID, "2026-02")
+			mustDo(t, err)
+			if exists {
+				t.Error("the other tenant's bill counts as existing")
+			}
+		})
+
+		// The room and method filters look other tables up in subqueries
+		t.Run("PaymentFilters", func(t *testing.T) {
+			tests := []struct {
+				name  string
+				query domainrepository.PaymentQuery
+				want  []uint
+			}{
+				{"all", domainrepository.PaymentQuery{}, []uint{own.payment.ID}},
+				{"other tenant", domainrepository.PaymentQuery{TenantID: other.tenant.ID}, nil},
+				{"other room", domainrepository.PaymentQuery{RoomID: other.room.ID}, nil},
+				{"own room", domainrepository.PaymentQuery{RoomID: own.room.ID}, []uint{own.payment.ID}},
+				{"method", domainrepository.PaymentQuery{Method: "transfer"}, []uint{own.payment.ID}},
+			}
+			for _, tt := range tests {
+				payments, total, err := a.Payments.Find(tt.query)
+				mustDo(t, err)
+				wantIDs(t, tt.name, ids(payments, func(p entity.Payment) uint { return p.ID }), tt.want...)
+				if total != int64(len(tt.want)) {
+					t.Errorf("%s: total = %d, want %d", tt.name, total, len(tt.want))
+				}
+			}
+		})
+
+		t.Run("Totals", func(t *testing.T) {
+			from, to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), now
+			paid, err := a.Payments.SumPaidByPeriod(from, to, 0)
+			mustDo(t, err)
+			spent, err := a.Expenses.SumByPeriod(from, to, 0)
+			mustDo(t, err)
+			if paid.String() != "1000.00" || spent.String() != "1000.00" {
+				t.Errorf("paid %s and spent %s, want only the organization's 1000.00", paid, spent)
+			}
+
+			counts := []struct {
+				name  string
+				count func() (int64, error)
+			}{
+				{"rooms", func() (int64, error) { return a.Rooms.Count(0) }},
+				{"empty rooms", func() (int64, error) { return a.Rooms.CountByStatus("empty", 0) }},
+				{"active tenants", func() (int64, error) { return a.Tenants.CountByStatus("active", 0) }},
+				{"overdue", func() (int64, error) { return a.Payments.CountOverdue(now, 0) }},
+			}
+			for _, c := range counts {
+				got, err := c.count()
+				mustDo(t, err)
+				if got != 1 {
+					t.Errorf("%s = %d, want 1", c.name, got)
+				}
+			}
+		})
+
+		// Rows pointing at another organization's rows must not pull them in
+		t.Run("Preloads", func(t *testing.T) {
+			intruder := &entity.Tenant{PropertyID: own.property.ID, Name: "Intruder", Phone: "0812", RoomID: &other.room.ID, StartDate: now}
+			mustDo(t, a.Tenants.Create(intruder))
+			found, err := a.Tenants.FindByID(intruder.ID)
+			mustDo(t, err)
+			if found.Room != nil {
+				t.Errorf("tenant loaded the other organization's room %+v", found.Room)
+			}
+			intruderBill := &entity.Payment{PropertyID: own.property.ID, TenantID: intruder.ID, Amount: entity.NewMoney(1), DueDate: now}
+			mustDo(t, a.Payments.Create(intruderBill))
+			payment, err := a.Payments.FindByID(intruderBill.ID)
+			mustDo(t, err)
+			if payment.Tenant.ID != intruder.ID || payment.Tenant.Room != nil {
+				t.Errorf("payment tenant = %+v, want the intruder without the other organization's room", payment.Tenant)
+			}
+
+			bill := &entity.Payment{PropertyID: own.property.ID, TenantID: other.tenant.ID, Amount: entity.NewMoney(1), DueDate: now}
+			mustDo(t, a.Payments.Create(bill))
+			payment, err = a.Payments.FindByID(bill.ID)
+			mustDo(t, err)
+			if payment.Tenant.ID != 0 || payment.Tenant.Room != nil {
+				t.Errorf("payment loaded the other organization's tenant %+v", payment.Tenant)
+			}
+
+			room, err := a.Rooms.FindByID(own.room.ID)
+			mustDo(t, err)
+			if room.Tenant == nil || room.Tenant.ID != own.tenant.ID {
+				t.Errorf("room tenant = %+v, want the organization's own", room.Tenant)
+			}
+		})
+	})
+}
+
+func TestOrganizationIsolationWrites(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, open func(t *testing.T) *gorm.DB) {
+		a, b, _, other := openOrganizations(t, open)
+
+		// Every write is aimed at the other organization's rows. Those that
+		// name a row report it missing; nothing may change either way.
+		property := *other.property
+		property.Name = "Hijacked"
+		wantNotFound(t, "property update", a.Properties.Update(&property))
+		room := *other.room
+		room.RoomNumber = "666"
+		wantNotFound(t, "room update", a.Rooms.Update(&room))
+		_ = a.Rooms.UpdateStatus(other.room.ID, "maintenance")
+		tenant := *other.tenant
+		tenant.Name = "Hijacked"
+		wantNotFound(t, "tenant update", a.Tenants.Update(&tenant))
+		_ = a.Tenants.AdjustCredit(other.tenant.ID, entity.NewMoney(-2000))
+		payment := *other.payment
+		payment.Amount = entity.NewMoney(1)
+		payment.Status = "paid"
+		wantNotFound(t, "payment update", a.Payments.Update(&payment))
+		item := *other.item
+		item.Waived = true
+		_ = a.Payments.UpdateLineItem(&item)
+		expense := *other.expense
+		expense.Description = "Hijacked"
+		wantNotFound(t, "expense update", a.Expenses.Update(&expense))
+		user := *other.employee
+		user.Role = "owner"
+		_ = a.Users.Update(&user)
+
+		wantNotFound(t, "expense delete", a.Expenses.Delete(other.expense.ID))
+		_ = a.Users.Delete(other.employee.ID)
+		wantNotFound(t, "tenant delete", a.Tenants.Delete(other.tenant.ID))
+		wantNotFound(t, "room delete", a.Rooms.Delete(other.room.ID))
+		wantNotFound(t, "property delete", a.Properties.Delete(other.property.ID))
+
+		gotProperty, err := b.Properties.FindByID(other.property.ID)
+		mustDo(t, err)
+		if gotProperty.Name != "Kos mawar" {
+			t.Errorf("property = %+v, want it unchanged", gotProperty)
+		}
+		gotRoom, err := b.Rooms.FindByID(other.room.ID)
+		mustDo(t, err)
+		if gotRoom.RoomNumber != "101" || gotRoom.Status != other.room.Status {
+			t.Errorf("room = %+v, want it unchanged", gotRoom)
+		}
+		gotTenant, err := b.Tenants.FindByID(other.tenant.ID)
+		mustDo(t, err)
+		if gotTenant.Name != "Tenant mawar" || gotTenant.CreditBalance.String() != "2000.00" {
+			t.Errorf("tenant = %+v, want it unchanged", gotTenant)
+		}
+		gotPayment, err := b.Payments.FindByID(other.payment.ID)
+		mustDo(t, err)
+		if gotPayment.Amount.String() != "2000.00" || gotPayment.Status != "unpaid" || gotPayment.LineItems[0].Waived {
+			t.Errorf("payment = %+v, want it unchanged", gotPayment)
+		}
+		gotExpense, err := b.Expenses.FindByID(other.expense.ID)
+		mustDo(t, err)
+		if gotExpense.Description != "Listrik mawar" {
+			t.Errorf("expense = %+v, want it unchanged", gotExpense)
+		}
+		gotUser, err := b.Users.FindByID(other.employee.ID)
+		mustDo(t, err)
+		if gotUser.Role != "staff" {
+			t.Errorf("user = %+v, want it unchanged", gotUser)
+		}
+	})
+}
+
+func ids[T any](rows []T, id func(T) uint) []uint {
+	got := make([]uint, len(rows))
+	for i, row := range rows {
+		got[i] = id(row)
+	}
+	return got
+}
+
+func wantIDs(t *testing.T, name string, got []uint, want ...uint) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s: got ids %v, want %v", name, got, want)
+		return
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("%s: got ids %v, want %v", name, got, want)
+			return
+		}
+	}
+}
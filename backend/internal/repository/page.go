@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"ezkost/internal/domain/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// paginate orders db by the page's sort fields, then by id so pages never
+// overlap, and applies the page's limit and offset. Sort fields are column
+// names, checked against the repository's sort fields by the caller. Unset
+// values come last, or first when descending, as Postgres orders them; SQLite
+// would put them the other way round.
+func paginate(db *gorm.DB, page repository.Page) *gorm.DB {
+	order := make([]clause.Expression, 0, len(page.Sort)+1)
+	for _, sort := range page.Sort {
+		direction := "ASC NULLS LAST"
+		if sort.Desc {
+			direction = "DESC NULLS FIRST"
+		}
+		order = append(order, clause.Expr{
+			SQL:  "? " + direction,
+			Vars: []interface{}{clause.Column{Table: clause.CurrentTable, Name: sort.Field}},
+		})
+	}
+	order = append(order, clause.Expr{SQL: "?", Vars: []interface{}{clause.Column{Table: clause.CurrentTable, Name: "id"}}})
+	db = db.Clauses(clause.OrderBy{Expression: clause.CommaExpression{Exprs: order}})
+
+	if page.Limit > 0 {
+		db = db.Limit(page.Limit)
+	}
+	if page.Offset > 0 {
+		db = db.Offset(page.Offset)
+	}
+	return db
+}
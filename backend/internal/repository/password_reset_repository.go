@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Password Reset Repository Implementation
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) repository.PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+func (r *passwordResetRepository) Create(reset *entity.PasswordReset) error {
+	m := &model.PasswordReset{}
+	m.FromEntity(reset)
+	if err := r.db.Create(m).Error; err != nil {
+		return err
+	}
+	*reset = *m.ToEntity()
+	return nil
+}
+
+func (r *passwordResetRepository) FindByTokenHash(hash string) (*entity.PasswordReset, error) {
+	var models []model.PasswordReset
+	if err := r.db.Where("token_hash = ?", hash).Limit(1).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return models[0].ToEntity(), nil
+}
+
+func (r *passwordResetRepository) Use(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&model.PasswordReset{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	return result.RowsAffected == 1, result.Error
+}
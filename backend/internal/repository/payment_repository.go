@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Payment Repository Implementation
@@ -28,22 +29,50 @@ func (r *paymentRepository) Create(payment *entity.Payment) error {
 	return nil
 }
 
-func (r *paymentRepository) FindAll() ([]entity.Payment, error) {
+func (r *paymentRepository) Find(query repository.PaymentQuery) ([]entity.Payment, int64, error) {
+	var total int64
+	if err := r.matching(query).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
 	var models []model.Payment
-	if err := r.db.Preload("Tenant.Room").Find(&models).Error; err != nil {
-		return nil, err
+	if err := paginate(r.matching(query), query.Page).Preload("Tenant.Room").Preload("LineItems").Preload("Transactions").Find(&models).Error; err != nil {
+		return nil, 0, err
 	}
 
 	entities := make([]entity.Payment, len(models))
 	for i, m := range models {
 		entities[i] = *m.ToEntity()
 	}
-	return entities, nil
+	return entities, total, nil
+}
+
+func (r *paymentRepository) matching(query repository.PaymentQuery) *gorm.DB {
+	db := byProperty(r.db.Model(&model.Payment{}), "property_id", query.PropertyID)
+	if query.TenantID != 0 {
+		db = db.Where("tenant_id = ?", query.TenantID)
+	}
+	if query.RoomID != 0 {
+		db = db.Where("tenant_id IN (SELECT id FROM tenants WHERE room_id = ?)", query.RoomID)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if query.Method != "" {
+		db = db.Where("payment_method = ? OR id IN (SELECT payment_id FROM payment_transactions WHERE method = ?)", query.Method, query.Method)
+	}
+	if !query.DueFrom.IsZero() {
+		db = db.Where("due_date >= ?", query.DueFrom)
+	}
+	if !query.DueTo.IsZero() {
+		db = db.Where("due_date < ?", query.DueTo)
+	}
+	return db
 }
 
 func (r *paymentRepository) FindByID(id uint) (*entity.Payment, error) {
 	var m model.Payment
-	if err := r.db.Preload("Tenant.Room").First(&m, id).Error; err != nil {
+	if err := r.db.Preload("Tenant.Room").Preload("LineItems").Preload("Transactions").First(&m, id).Error; err != nil {
 		return nil, err
 	}
 	return m.ToEntity(), nil
@@ -51,7 +80,7 @@ func (r *paymentRepository) FindByID(id uint) (*entity.Payment, error) {
 
 func (r *paymentRepository) FindByTenantID(tenantID uint) ([]entity.Payment, error) {
 	var models []model.Payment
-	if err := r.db.Where("tenant_id = ?", tenantID).Find(&models).Error; err != nil {
+	if err := r.db.Preload("LineItems").Preload("Transactions").Where("tenant_id = ?", tenantID).Find(&models).Error; err != nil {
 		return nil, err
 	}
 
@@ -64,8 +93,8 @@ func (r *paymentRepository) FindByTenantID(tenantID uint) ([]entity.Payment, err
 
 func (r *paymentRepository) FindOverdue(now time.Time) ([]entity.Payment, error) {
 	var models []model.Payment
-	if err := r.db.Preload("Tenant.Room").
-		Where("status = ? AND due_date < ?", "unpaid", now).
+	if err := r.db.Preload("Tenant.Room").Preload("LineItems").Preload("Transactions").
+		Where("status IN ? AND due_date < ?", []string{"unpaid", "partial"}, now).
 		Find(&models).Error; err != nil {
 		return nil, err
 	}
@@ -77,27 +106,90 @@ func (r *paymentRepository) FindOverdue(now time.Time) ([]entity.Payment, error)
 	return entities, nil
 }
 
+func (r *paymentRepository) ExistsForPeriod(tenantID uint, period string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Payment{}).
+		Where("tenant_id = ? AND period = ?", tenantID, period).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindByTenantAndPeriod returns nil without an error when the tenant has not
+// been billed for the period yet.
+func (r *paymentRepository) FindByTenantAndPeriod(tenantID uint, period string) (*entity.Payment, error) {
+	var models []model.Payment
+	if err := r.db.Preload("LineItems").Preload("Transactions").
+		Where("tenant_id = ? AND period = ?", tenantID, period).
+		Limit(1).
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return models[0].ToEntity(), nil
+}
+
 func (r *paymentRepository) Update(payment *entity.Payment) error {
 	m := &model.Payment{}
 	m.FromEntity(payment)
-	return r.db.Save(m).Error
+	return matchedRow(r.db.Model(m).Where("id = ?", m.ID).
+		Select("*").Omit("CreatedAt", clause.Associations).Updates(m))
 }
 
-func (r *paymentRepository) CountOverdue(now time.Time) (int64, error) {
+func (r *paymentRepository) CountOverdue(now time.Time, propertyID uint) (int64, error) {
 	var count int64
-	err := r.db.Model(&model.Payment{}).
-		Where("status = ? AND due_date < ?", "unpaid", now).
+	err := byProperty(r.db.Model(&model.Payment{}), "property_id", propertyID).
+		Where("status IN ? AND due_date < ?", []string{"unpaid", "partial"}, now).
 		Count(&count).Error
 	return count, err
 }
 
-func (r *paymentRepository) SumPaidByPeriod(start, end time.Time) (float64, error) {
+// SumPaidByPeriod totals the money received in the period. Transactions that
+// only move an existing tenant credit onto a bill are not new income.
+func (r *paymentRepository) SumPaidByPeriod(start, end time.Time, propertyID uint) (entity.Money, error) {
 	var result struct {
-		Total float64
+		Total entity.Money
 	}
-	err := r.db.Model(&model.Payment{}).
-		Select("COALESCE(SUM(amount), 0) as total").
-		Where("status = ? AND paid_at >= ? AND paid_at < ?", "paid", start, end).
+	query := r.db.Model(&model.PaymentTransaction{}).
+		Joins("JOIN payments ON payments.id = payment_transactions.payment_id")
+	err := byProperty(query, "payments.property_id", propertyID).
+		Select("COALESCE(SUM(payment_transactions.amount), 0) as total").
+		Where("payment_transactions.method <> ? AND payment_transactions.paid_at >= ? AND payment_transactions.paid_at < ?", "credit", start, end).
 		Scan(&result).Error
 	return result.Total, err
 }
+
+func (r *paymentRepository) AddLineItem(item *entity.PaymentLineItem) error {
+	m := &model.PaymentLineItem{}
+	m.FromEntity(item)
+	if err := r.db.Create(m).Error; err != nil {
+		return err
+	}
+	*item = *m.ToEntity()
+	return nil
+}
+
+func (r *paymentRepository) FindLineItemByID(id uint) (*entity.PaymentLineItem, error) {
+	var m model.PaymentLineItem
+	if err := r.db.First(&m, id).Error; err != nil {
+		return nil, err
+	}
+	return m.ToEntity(), nil
+}
+
+func (r *paymentRepository) UpdateLineItem(item *entity.PaymentLineItem) error {
+	m := &model.PaymentLineItem{}
+	m.FromEntity(item)
+	return r.db.Save(m).Error
+}
+
+func (r *paymentRepository) AddTransaction(transaction *entity.PaymentTransaction) error {
+	m := &model.PaymentTransaction{}
+	m.FromEntity(transaction)
+	if err := r.db.Create(m).Error; err != nil {
+		return err
+	}
+	*transaction = *m.ToEntity()
+	return nil
+}
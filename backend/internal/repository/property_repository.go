@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Property Repository Implementation
+type propertyRepository struct {
+	db *gorm.DB
+}
+
+func NewPropertyRepository(db *gorm.DB) repository.PropertyRepository {
+	return &propertyRepository{db: db}
+}
+
+func (r *propertyRepository) Create(property *entity.Property) error {
+	m := &model.Property{}
+	m.FromEntity(property)
+	if err := r.db.Create(m).Error; err != nil {
+		return err
+	}
+	*property = *m.ToEntity()
+	return nil
+}
+
+func (r *propertyRepository) FindAll() ([]entity.Property, error) {
+	var models []model.Property
+	if err := r.db.Order("name ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]entity.Property, len(models))
+	for i, m := range models {
+		entities[i] = *m.ToEntity()
+	}
+	return entities, nil
+}
+
+func (r *propertyRepository) FindByID(id uint) (*entity.Property, error) {
+	var m model.Property
+	if err := r.db.First(&m, id).Error; err != nil {
+		return nil, err
+	}
+	return m.ToEntity(), nil
+}
+
+func (r *propertyRepository) Update(property *entity.Property) error {
+	m := &model.Property{}
+	m.FromEntity(property)
+	return matchedRow(r.db.Model(m).Where("id = ?", m.ID).
+		Select("*").Omit("CreatedAt", clause.Associations).Updates(m))
+}
+
+func (r *propertyRepository) Delete(id uint) error {
+	return matchedRow(r.db.Delete(&model.Property{}, id))
+}
+
+func (r *propertyRepository) CountRooms(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Room{}).Where("property_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// byProperty limits a query to one property; 0 leaves it unscoped.
+func byProperty(db *gorm.DB, column string, propertyID uint) *gorm.DB {
+	if propertyID == 0 {
+		return db
+	}
+	return db.Where(column+" = ?", propertyID)
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Receipt Repository Implementation
+type receiptRepository struct {
+	db *gorm.DB
+}
+
+func NewReceiptRepository(db *gorm.DB) repository.ReceiptRepository {
+	return &receiptRepository{db: db}
+}
+
+// FindByPaymentID returns nil without an error when no receipt was issued yet.
+func (r *receiptRepository) FindByPaymentID(paymentID uint) (*entity.Receipt, error) {
+	var models []model.Receipt
+	if err := r.db.Where("payment_id = ?", paymentID).Limit(1).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return models[0].ToEntity(), nil
+}
+
+// Issue takes the next number of the receipt's property and year and stores
+// the receipt in the same transaction, so a failed insert never leaves a gap
+// in the sequence.
+func (r *receiptRepository) Issue(receipt *entity.Receipt, prefix string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		seq := model.ReceiptCounter{PropertyID: receipt.PropertyID, Year: receipt.Year}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&seq).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&seq, "property_id = ? AND year = ?", receipt.PropertyID, receipt.Year).Error; err != nil {
+			return err
+		}
+
+		seq.LastValue++
+		if err := tx.Model(&seq).
+			Where("property_id = ? AND year = ?", seq.PropertyID, seq.Year).
+			Update("last_value", seq.LastValue).Error; err != nil {
+			return err
+		}
+
+		receipt.Sequence = seq.LastValue
+		receipt.Number = fmt.Sprintf("%s/%d/%06d", prefix, receipt.Year, seq.LastValue)
+
+		m := &model.Receipt{}
+		m.FromEntity(receipt)
+		if err := tx.Create(m).Error; err != nil {
+			return err
+		}
+		*receipt = *m.ToEntity()
+		return nil
+	})
+}
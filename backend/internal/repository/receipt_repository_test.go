@@ -0,0 +1,62 @@
+package repository_test
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/repository"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestReceiptRepositoryIssue(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, open func(t *testing.T) *gorm.DB) {
+		receipts := repository.NewReceiptRepository(createOrganization(t, open(t), "Kos Melati"))
+
+		// Numbers run per property and year; a receipt that fails to store
+		// does not use one up
+		tests := []struct {
+			name       string
+			propertyID uint
+			paymentID  uint
+			year       int
+			prefix     string
+			want       string
+		}{
+			{"first", 1, 1, 2026, "MLT", "MLT/2026/000001"},
+			{"second", 1, 2, 2026, "MLT", "MLT/2026/000002"},
+			{"other property", 2, 3, 2026, "RCP", "RCP/2026/000001"},
+			{"next year", 1, 4, 2027, "MLT", "MLT/2027/000001"},
+			{"payment with a receipt", 1, 2, 2026, "MLT", ""},
+			{"after the failure", 1, 5, 2026, "MLT", "MLT/2026/000003"},
+		}
+		for _, tt := range tests {
+			receipt := &entity.Receipt{PropertyID: tt.propertyID, PaymentID: tt.paymentID, Year: tt.year, IssuedAt: time.Now()}
+			err := receipts.Issue(receipt, tt.prefix)
+			if tt.want == "" {
+				if !errors.Is(err, entity.ErrConflict) {
+					t.Errorf("%s: got error %v, want a conflict", tt.name, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if receipt.ID == 0 || receipt.Number != tt.want {
+				t.Errorf("%s: receipt %d numbered %q, want %q", tt.name, receipt.ID, receipt.Number, tt.want)
+			}
+		}
+
+		found, err := receipts.FindByPaymentID(2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found == nil || found.Number != "MLT/2026/000002" || found.Sequence != 2 {
+			t.Errorf("receipt of payment 2 = %+v, want MLT/2026/000002", found)
+		}
+		if found, err := receipts.FindByPaymentID(99); err != nil || found != nil {
+			t.Errorf("receipt of payment 99 = %+v (%v), want none", found, err)
+		}
+	})
+}
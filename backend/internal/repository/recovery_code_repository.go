@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Recovery Code Repository Implementation
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) repository.RecoveryCodeRepository {
+	return &recoveryCodeRepository{db: db}
+}
+
+func (r *recoveryCodeRepository) Replace(userID uint, codes []entity.RecoveryCode) error {
+	if err := r.db.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+
+	models := make([]model.RecoveryCode, len(codes))
+	for i := range codes {
+		codes[i].UserID = userID
+		models[i].FromEntity(&codes[i])
+	}
+	return r.db.Create(&models).Error
+}
+
+func (r *recoveryCodeRepository) Use(userID uint, hash string, at time.Time) (bool, error) {
+	result := r.db.Model(&model.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", at)
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,143 @@
+package repository_test
+
+import (
+	"ezkost/internal/config"
+	"ezkost/internal/domain/entity"
+	domainrepository "ezkost/internal/domain/repository"
+	"ezkost/internal/repository"
+	"ezkost/internal/repository/repositorytest"
+	"ezkost/package/database"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// postgresDSNEnv names the variable holding the DSN of a Postgres database
+// the tests also run against, e.g. "host=localhost user=postgres
+// password=postgres dbname=ezkost_test". Each test works in a schema of its
+// own, dropped when the test ends. Without it only SQLite is tested.
+const postgresDSNEnv = "TEST_POSTGRES_DSN"
+
+type driver struct {
+	name string
+	open func(t *testing.T) *gorm.DB
+}
+
+// drivers returns the databases to run the tests on.
+func drivers() []driver {
+	found := []driver{{database.DriverSQLite, openSQLite}}
+	if dsn := os.Getenv(postgresDSNEnv); dsn != "" {
+		found = append(found, driver{database.DriverPostgres, func(t *testing.T) *gorm.DB { return openPostgres(t, dsn) }})
+	}
+	return found
+}
+
+// forEachDriver runs test once per database driver.
+func forEachDriver(t *testing.T, test func(t *testing.T, open func(t *testing.T) *gorm.DB)) {
+	for _, d := range drivers() {
+		t.Run(d.name, func(t *testing.T) { test(t, d.open) })
+	}
+}
+
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := database.ConnectDB(&config.Config{DBDriver: database.DriverMemory})
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeOnCleanup(t, db)
+	return prepare(t, db)
+}
+
+var schemaSeq atomic.Int64
+
+// openPostgres connects to a new, empty schema of the database at dsn.
+func openPostgres(t *testing.T, dsn string) *gorm.DB {
+	t.Helper()
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeOnCleanup(t, admin)
+
+	schema := fmt.Sprintf("ezkost_test_%d_%d", os.Getpid(), schemaSeq.Add(1))
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := admin.Exec("DROP SCHEMA " + schema + " CASCADE").Error; err != nil {
+			t.Errorf("failed to drop schema %s: %v", schema, err)
+		}
+	})
+
+	// search_path is a run-time parameter, so every pooled connection gets it
+	if strings.Contains(dsn, "://") {
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+		dsn += separator + "search_path=" + schema
+	} else {
+		dsn += " search_path=" + schema
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeOnCleanup(t, db)
+	return prepare(t, db)
+}
+
+func closeOnCleanup(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+}
+
+// prepare migrates db and installs the callbacks the server installs.
+func prepare(t *testing.T, db *gorm.DB) *gorm.DB {
+	t.Helper()
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+	if err := repository.RegisterOrganizationScope(db); err != nil {
+		t.Fatal(err)
+	}
+	if err := repository.RegisterAuditTrail(db); err != nil {
+		t.Fatal(err)
+	}
+	if err := repository.RegisterErrorTranslation(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// createOrganization returns db scoped to a new organization.
+func createOrganization(t *testing.T, db *gorm.DB, name string) *gorm.DB {
+	t.Helper()
+	organization := &entity.Organization{Name: name}
+	if err := repository.NewOrganizationRepository(db).Create(organization); err != nil {
+		t.Fatal(err)
+	}
+	return repository.ForOrganization(db, organization.ID)
+}
+
+func TestRepositories(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, open func(t *testing.T) *gorm.DB) {
+		repositorytest.Run(t, func(t *testing.T) *domainrepository.Repositories {
+			return repository.NewRepositories(createOrganization(t, open(t), "Kos Melati"))
+		})
+	})
+}
@@ -0,0 +1,111 @@
+package repositorytest
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"testing"
+	"time"
+)
+
+func runExpenses(t *testing.T, open Open) {
+	createExpense := func(t *testing.T, repos *repository.Repositories, propertyID uint, description string, day time.Time, amount string) *entity.Expense {
+		t.Helper()
+		expense := &entity.Expense{PropertyID: propertyID, Description: description, Amount: money(t, amount), ExpenseDate: day}
+		must(t, repos.Expenses.Create(expense))
+		return expense
+	}
+
+	t.Run("CreateUpdateDelete", func(t *testing.T) {
+		repos := open(t)
+		expense := createExpense(t, repos, 1, "Listrik", date(2026, 2, 3), "250000.25")
+		if expense.ID == 0 || expense.CreatedAt.IsZero() {
+			t.Fatalf("created expense = %+v, want an id and a creation time", expense)
+		}
+
+		expense.Description = "Listrik Februari"
+		expense.Amount = money(t, "260000.75")
+		must(t, repos.Expenses.Update(expense))
+		found, err := repos.Expenses.FindByID(expense.ID)
+		must(t, err)
+		if found.Description != "Listrik Februari" || !found.ExpenseDate.Equal(date(2026, 2, 3)) {
+			t.Errorf("updated expense = %+v", found)
+		}
+		wantMoney(t, "amount", found.Amount, "260000.75")
+		wantCreatedAt(t, found.CreatedAt, expense.CreatedAt)
+
+		must(t, repos.Expenses.Delete(expense.ID))
+		_, err = repos.Expenses.FindByID(expense.ID)
+		wantKind(t, err, entity.ErrNotFound, "not_found")
+	})
+
+	t.Run("UpdateAndDeleteMissing", func(t *testing.T) {
+		repos := open(t)
+		err := repos.Expenses.Update(&entity.Expense{ID: 99, PropertyID: 1, Description: "Air", Amount: money(t, "1000"), ExpenseDate: date(2026, 2, 3)})
+		wantKind(t, err, entity.ErrNotFound, "not_found")
+		wantKind(t, repos.Expenses.Delete(99), entity.ErrNotFound, "not_found")
+
+		expenses, _, err := repos.Expenses.Find(repository.ExpenseQuery{})
+		must(t, err)
+		if len(expenses) != 0 {
+			t.Errorf("updating a missing expense stored %+v", expenses)
+		}
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		repos := open(t)
+		a := createExpense(t, repos, 1, "Air", date(2026, 1, 31), "100")
+		b := createExpense(t, repos, 1, "Listrik", date(2026, 2, 3), "300")
+		c := createExpense(t, repos, 2, "Cat", date(2026, 2, 3), "200")
+		d := createExpense(t, repos, 1, "Sapu", date(2026, 3, 1), "50")
+
+		tests := []struct {
+			name  string
+			query repository.ExpenseQuery
+			want  []uint
+			total int64
+		}{
+			{"newest first", repository.ExpenseQuery{}, []uint{d.ID, b.ID, c.ID, a.ID}, 4},
+			{"property", repository.ExpenseQuery{PropertyID: 2}, []uint{c.ID}, 1},
+			{"date range", repository.ExpenseQuery{From: date(2026, 2, 1), To: date(2026, 3, 1)}, []uint{b.ID, c.ID}, 2},
+			{"amount", repository.ExpenseQuery{Page: repository.Page{Sort: []repository.Sort{{Field: "amount"}}}}, []uint{d.ID, a.ID, c.ID, b.ID}, 4},
+			{"description desc", repository.ExpenseQuery{Page: repository.Page{Sort: []repository.Sort{{Field: "description", Desc: true}}}}, []uint{d.ID, b.ID, c.ID, a.ID}, 4},
+			{"page", repository.ExpenseQuery{Page: repository.Page{Limit: 2, Offset: 1}}, []uint{b.ID, c.ID}, 4},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				expenses, total, err := repos.Expenses.Find(tt.query)
+				must(t, err)
+				wantIDs(t, expenses, func(e entity.Expense) uint { return e.ID }, tt.want...)
+				if total != tt.total {
+					t.Errorf("total = %d, want %d", total, tt.total)
+				}
+			})
+		}
+	})
+
+	t.Run("SumByPeriod", func(t *testing.T) {
+		repos := open(t)
+		createExpense(t, repos, 1, "Air", date(2026, 2, 1), "0.10")
+		createExpense(t, repos, 1, "Listrik", date(2026, 2, 28), "0.20")
+		createExpense(t, repos, 2, "Cat", date(2026, 2, 14), "1000000.05")
+		createExpense(t, repos, 1, "Sapu", date(2026, 3, 1), "50")
+
+		tests := []struct {
+			name       string
+			start, end time.Time
+			propertyID uint
+			want       string
+		}{
+			{"every property", date(2026, 2, 1), date(2026, 3, 1), 0, "1000000.35"},
+			{"one property", date(2026, 2, 1), date(2026, 3, 1), 1, "0.30"},
+			{"nothing spent", date(2026, 4, 1), date(2026, 5, 1), 0, "0.00"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				total, err := repos.Expenses.SumByPeriod(tt.start, tt.end, tt.propertyID)
+				must(t, err)
+				wantMoney(t, "total", total, tt.want)
+			})
+		}
+	})
+}
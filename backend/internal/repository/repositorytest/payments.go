@@ -0,0 +1,282 @@
+package repositorytest
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"testing"
+	"time"
+)
+
+func runPayments(t *testing.T, open Open) {
+	t.Run("CreateAndFindByID", func(t *testing.T) {
+		repos := open(t)
+		room := createRoom(t, repos, 1, "101", "1000")
+		tenant := createTenant(t, repos, 1, "Budi", &room.ID)
+		payment := createPayment(t, repos, tenant, "2026-02", date(2026, 2, 15), "1500000.10")
+		if payment.ID == 0 || payment.Status != "unpaid" {
+			t.Fatalf("created payment = %+v, want an id and status unpaid", payment)
+		}
+
+		found, err := repos.Payments.FindByID(payment.ID)
+		must(t, err)
+		if found.Period != "2026-02" || !found.DueDate.Equal(date(2026, 2, 15)) || found.PaidAt != nil {
+			t.Errorf("found payment = %+v", found)
+		}
+		if found.Tenant.Name != "Budi" || found.Tenant.Room == nil || found.Tenant.Room.RoomNumber != "101" {
+			t.Errorf("tenant = %+v, want Budi in 101", found.Tenant)
+		}
+		if len(found.LineItems) != 0 || len(found.Transactions) != 0 {
+			t.Errorf("line items %v and transactions %v, want none", found.LineItems, found.Transactions)
+		}
+		wantMoney(t, "amount", found.Amount, "1500000.10")
+	})
+
+	t.Run("FindByIDMissing", func(t *testing.T) {
+		_, err := open(t).Payments.FindByID(99)
+		wantKind(t, err, entity.ErrNotFound, "not_found")
+	})
+
+	t.Run("UpdateMissing", func(t *testing.T) {
+		repos := open(t)
+		tenant := createTenant(t, repos, 1, "Budi", nil)
+		err := repos.Payments.Update(&entity.Payment{ID: 99, TenantID: tenant.ID, Amount: money(t, "1000"), DueDate: date(2026, 2, 1)})
+		wantKind(t, err, entity.ErrNotFound, "not_found")
+
+		payments, err := repos.Payments.FindByTenantID(tenant.ID)
+		must(t, err)
+		if len(payments) != 0 {
+			t.Errorf("updating a missing payment stored %+v", payments)
+		}
+	})
+
+	t.Run("MissingTenant", func(t *testing.T) {
+		err := open(t).Payments.Create(&entity.Payment{TenantID: 99, Amount: money(t, "1000"), DueDate: date(2026, 2, 1)})
+		wantKind(t, err, entity.ErrConflict, "missing_reference")
+	})
+
+	t.Run("OneBillPerPeriod", func(t *testing.T) {
+		repos := open(t)
+		tenant := createTenant(t, repos, 1, "Budi", nil)
+		createPayment(t, repos, tenant, "2026-02", date(2026, 2, 15), "1000")
+		createPayment(t, repos, tenant, "", date(2026, 2, 20), "50")
+		createPayment(t, repos, tenant, "", date(2026, 2, 21), "50")
+
+		err := repos.Payments.Create(&entity.Payment{TenantID: tenant.ID, Amount: money(t, "1000"), DueDate: date(2026, 2, 15), Period: "2026-02"})
+		wantKind(t, err, entity.ErrConflict, "duplicate")
+	})
+
+	t.Run("Period", func(t *testing.T) {
+		repos := open(t)
+		tenant := createTenant(t, repos, 1, "Budi", nil)
+		payment := createPayment(t, repos, tenant, "2026-02", date(2026, 2, 15), "1000")
+
+		tests := []struct {
+			period string
+			want   bool
+		}{
+			{"2026-02", true},
+			{"2026-03", false},
+		}
+		for _, tt := range tests {
+			exists, err := repos.Payments.ExistsForPeriod(tenant.ID, tt.period)
+			must(t, err)
+			found, err := repos.Payments.FindByTenantAndPeriod(tenant.ID, tt.period)
+			must(t, err)
+			if exists != tt.want || (found != nil) != tt.want {
+				t.Errorf("period %s: exists %v, found %+v, want %v", tt.period, exists, found, tt.want)
+			}
+			if found != nil && found.ID != payment.ID {
+				t.Errorf("period %s: found payment %d, want %d", tt.period, found.ID, payment.ID)
+			}
+		}
+	})
+
+	t.Run("UpdateMarksPaid", func(t *testing.T) {
+		repos := open(t)
+		tenant := createTenant(t, repos, 1, "Budi", nil)
+		tests := []struct {
+			name string
+			due  time.Time
+			want string
+		}{
+			{"on time", time.Now().AddDate(0, 0, 7).UTC().Truncate(time.Second), "paid"},
+			{"late", date(2026, 2, 15), "late"},
+		}
+		for i, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				payment := createPayment(t, repos, tenant, "", tt.due, "1000")
+				paidAt := date(2026, 2, 10+i)
+				payment.PaidAt = &paidAt
+				payment.PaymentMethod = "cash"
+				must(t, repos.Payments.Update(payment))
+
+				found, err := repos.Payments.FindByID(payment.ID)
+				must(t, err)
+				if found.Status != tt.want || found.PaidAt == nil || !found.PaidAt.Equal(paidAt) || found.PaymentMethod != "cash" {
+					t.Errorf("updated payment = %+v, want status %s", found, tt.want)
+				}
+				wantCreatedAt(t, found.CreatedAt, payment.CreatedAt)
+			})
+		}
+	})
+
+	t.Run("Overdue", func(t *testing.T) {
+		repos := open(t)
+		room := createRoom(t, repos, 1, "101", "1000")
+		budi := createTenant(t, repos, 1, "Budi", &room.ID)
+		ani := createTenant(t, repos, 2, "Ani", nil)
+		now := date(2026, 3, 1)
+
+		overdue := createPayment(t, repos, budi, "2026-01", date(2026, 1, 15), "1000")
+		partial := createPayment(t, repos, ani, "2026-02", date(2026, 2, 15), "1000")
+		partial.Status = "partial"
+		must(t, repos.Payments.Update(partial))
+		createPayment(t, repos, budi, "2026-03", date(2026, 3, 1), "1000")
+		paid := createPayment(t, repos, budi, "2026-02", date(2026, 2, 15), "1000")
+		paidAt := date(2026, 2, 14)
+		paid.PaidAt = &paidAt
+		must(t, repos.Payments.Update(paid))
+
+		payments, err := repos.Payments.FindOverdue(now)
+		must(t, err)
+		wantIDs(t, payments, func(p entity.Payment) uint { return p.ID }, overdue.ID, partial.ID)
+		if payments[0].Tenant.Room == nil || payments[0].Tenant.Room.ID != room.ID {
+			t.Errorf("tenant room = %+v, want room %d", payments[0].Tenant.Room, room.ID)
+		}
+
+		for propertyID, want := range map[uint]int64{0: 2, 1: 1, 2: 1, 3: 0} {
+			count, err := repos.Payments.CountOverdue(now, propertyID)
+			must(t, err)
+			if count != want {
+				t.Errorf("CountOverdue(property %d) = %d, want %d", propertyID, count, want)
+			}
+		}
+	})
+
+	t.Run("SumPaidByPeriod", func(t *testing.T) {
+		repos := open(t)
+		budi := createTenant(t, repos, 1, "Budi", nil)
+		ani := createTenant(t, repos, 2, "Ani", nil)
+		first := createPayment(t, repos, budi, "2026-02", date(2026, 2, 1), "1000")
+		second := createPayment(t, repos, ani, "2026-02", date(2026, 2, 1), "1000")
+
+		transactions := []entity.PaymentTransaction{
+			{PaymentID: first.ID, Amount: money(t, "0.10"), Method: "cash", PaidAt: date(2026, 2, 1)},
+			{PaymentID: first.ID, Amount: money(t, "0.20"), Method: "transfer", PaidAt: date(2026, 2, 28)},
+			{PaymentID: first.ID, Amount: money(t, "500"), Method: "credit", PaidAt: date(2026, 2, 10)},
+			{PaymentID: first.ID, Amount: money(t, "700"), Method: "cash", PaidAt: date(2026, 3, 1)},
+			{PaymentID: second.ID, Amount: money(t, "1000000.45"), Method: "cash", PaidAt: date(2026, 2, 5)},
+		}
+		for i := range transactions {
+			must(t, repos.Payments.AddTransaction(&transactions[i]))
+		}
+
+		tests := []struct {
+			name       string
+			start, end time.Time
+			propertyID uint
+			want       string
+		}{
+			{"every property", date(2026, 2, 1), date(2026, 3, 1), 0, "1000000.75"},
+			{"one property", date(2026, 2, 1), date(2026, 3, 1), 1, "0.30"},
+			{"nothing paid", date(2026, 4, 1), date(2026, 5, 1), 0, "0.00"},
+			{"nothing in the property", date(2026, 2, 1), date(2026, 3, 1), 3, "0.00"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				total, err := repos.Payments.SumPaidByPeriod(tt.start, tt.end, tt.propertyID)
+				must(t, err)
+				wantMoney(t, "total", total, tt.want)
+			})
+		}
+
+		found, err := repos.Payments.FindByID(first.ID)
+		must(t, err)
+		wantIDs(t, found.Transactions, func(t entity.PaymentTransaction) uint { return t.ID },
+			transactions[0].ID, transactions[1].ID, transactions[2].ID, transactions[3].ID)
+		wantMoney(t, "amount paid", found.AmountPaid(), "1200.30")
+	})
+
+	t.Run("LineItems", func(t *testing.T) {
+		repos := open(t)
+		tenant := createTenant(t, repos, 1, "Budi", nil)
+		payment := createPayment(t, repos, tenant, "2026-02", date(2026, 2, 1), "1000")
+
+		item := &entity.PaymentLineItem{PaymentID: payment.ID, Type: "late_fee", Description: "Late fee", Amount: money(t, "50.50")}
+		must(t, repos.Payments.AddLineItem(item))
+		if item.ID == 0 {
+			t.Fatal("line item has no id")
+		}
+
+		waivedBy, waivedAt := uint(7), date(2026, 2, 20)
+		item.Waived, item.WaivedBy, item.WaivedAt, item.WaiverReason = true, &waivedBy, &waivedAt, "First time"
+		must(t, repos.Payments.UpdateLineItem(item))
+
+		found, err := repos.Payments.FindLineItemByID(item.ID)
+		must(t, err)
+		if !found.Waived || found.WaivedBy == nil || *found.WaivedBy != 7 || found.WaiverReason != "First time" {
+			t.Errorf("waived line item = %+v", found)
+		}
+		_, err = repos.Payments.FindLineItemByID(99)
+		wantKind(t, err, entity.ErrNotFound, "not_found")
+
+		bill, err := repos.Payments.FindByID(payment.ID)
+		must(t, err)
+		wantIDs(t, bill.LineItems, func(i entity.PaymentLineItem) uint { return i.ID }, item.ID)
+		wantMoney(t, "total due", bill.TotalDue(), "1000.00")
+
+		err = repos.Payments.AddLineItem(&entity.PaymentLineItem{PaymentID: 99, Type: "late_fee", Amount: money(t, "1")})
+		wantKind(t, err, entity.ErrConflict, "missing_reference")
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		repos := open(t)
+		room := createRoom(t, repos, 1, "101", "1000")
+		budi := createTenant(t, repos, 1, "Budi", &room.ID)
+		ani := createTenant(t, repos, 2, "Ani", nil)
+		a := createPayment(t, repos, budi, "2026-01", date(2026, 1, 10), "3000")
+		b := createPayment(t, repos, budi, "2026-02", date(2026, 2, 10), "1000")
+		c := createPayment(t, repos, ani, "2026-02", date(2026, 2, 20), "2000")
+		paidAt := date(2026, 1, 5)
+		a.PaidAt, a.PaymentMethod = &paidAt, "transfer"
+		must(t, repos.Payments.Update(a))
+		must(t, repos.Payments.AddTransaction(&entity.PaymentTransaction{PaymentID: c.ID, Amount: money(t, "500"), Method: "qris", PaidAt: date(2026, 2, 2)}))
+
+		tests := []struct {
+			name  string
+			query repository.PaymentQuery
+			want  []uint
+			total int64
+		}{
+			{"all", repository.PaymentQuery{}, []uint{a.ID, b.ID, c.ID}, 3},
+			{"property", repository.PaymentQuery{PropertyID: 2}, []uint{c.ID}, 1},
+			{"tenant", repository.PaymentQuery{TenantID: budi.ID}, []uint{a.ID, b.ID}, 2},
+			{"room", repository.PaymentQuery{RoomID: room.ID}, []uint{a.ID, b.ID}, 2},
+			{"status", repository.PaymentQuery{Status: "unpaid"}, []uint{b.ID, c.ID}, 2},
+			{"method on the bill", repository.PaymentQuery{Method: "transfer"}, []uint{a.ID}, 1},
+			{"method of a transaction", repository.PaymentQuery{Method: "qris"}, []uint{c.ID}, 1},
+			{"due range", repository.PaymentQuery{DueFrom: date(2026, 2, 10), DueTo: date(2026, 2, 20)}, []uint{b.ID}, 1},
+			{"amount desc", repository.PaymentQuery{Page: repository.Page{Sort: []repository.Sort{{Field: "amount", Desc: true}}}}, []uint{a.ID, c.ID, b.ID}, 3},
+			{"period then due date desc", repository.PaymentQuery{Page: repository.Page{Sort: []repository.Sort{{Field: "period"}, {Field: "due_date", Desc: true}}}}, []uint{a.ID, c.ID, b.ID}, 3},
+			{"paid at puts unpaid last", repository.PaymentQuery{Page: repository.Page{Sort: []repository.Sort{{Field: "paid_at"}}}}, []uint{a.ID, b.ID, c.ID}, 3},
+			{"page", repository.PaymentQuery{Page: repository.Page{Limit: 1, Offset: 2}}, []uint{c.ID}, 3},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				payments, total, err := repos.Payments.Find(tt.query)
+				must(t, err)
+				wantIDs(t, payments, func(p entity.Payment) uint { return p.ID }, tt.want...)
+				if total != tt.total {
+					t.Errorf("total = %d, want %d", total, tt.total)
+				}
+			})
+		}
+
+		byTenant, err := repos.Payments.FindByTenantID(ani.ID)
+		must(t, err)
+		wantIDs(t, byTenant, func(p entity.Payment) uint { return p.ID }, c.ID)
+		if len(byTenant[0].Transactions) != 1 {
+			t.Errorf("transactions = %v, want one", byTenant[0].Transactions)
+		}
+	})
+}
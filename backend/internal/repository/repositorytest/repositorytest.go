@@ -0,0 +1,114 @@
+// Package repositorytest is the contract every implementation of the room,
+// tenant, payment, expense and user repositories must meet. The GORM
+// repositories run it on each database driver and the in-memory ones on
+// their store, so both behave the same for the usecases built on them.
+package repositorytest
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"testing"
+	"time"
+)
+
+// Open returns empty repositories for one test. Only Rooms, Tenants,
+// Payments, Expenses and Users are used.
+type Open func(t *testing.T) *repository.Repositories
+
+// Run runs the whole contract against the repositories open returns.
+func Run(t *testing.T, open Open) {
+	t.Run("Rooms", func(t *testing.T) { runRooms(t, open) })
+	t.Run("Tenants", func(t *testing.T) { runTenants(t, open) })
+	t.Run("Payments", func(t *testing.T) { runPayments(t, open) })
+	t.Run("Expenses", func(t *testing.T) { runExpenses(t, open) })
+	t.Run("Users", func(t *testing.T) { runUsers(t, open) })
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func money(t *testing.T, amount string) entity.Money {
+	t.Helper()
+	m, err := entity.ParseMoney(amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// wantKind fails unless err is a domain error of kind with code.
+func wantKind(t *testing.T, err error, kind error, code string) {
+	t.Helper()
+	var domainErr *entity.Error
+	if !errors.As(err, &domainErr) || domainErr.Kind != kind || domainErr.Code != code {
+		t.Fatalf("got error %v, want %v %q", err, kind, code)
+	}
+}
+
+// wantCreatedAt fails unless got is want as a database keeps it, to the
+// microsecond.
+func wantCreatedAt(t *testing.T, got, want time.Time) {
+	t.Helper()
+	if got.IsZero() || got.Sub(want).Abs() >= time.Microsecond {
+		t.Errorf("created at = %v, want %v kept", got, want)
+	}
+}
+
+func wantMoney(t *testing.T, name string, got entity.Money, want string) {
+	t.Helper()
+	if got.String() != want || got.Currency != entity.DefaultCurrency {
+		t.Errorf("%s = %s %s, want %s %s", name, got, got.Currency, want, entity.DefaultCurrency)
+	}
+}
+
+func wantIDs[T any](t *testing.T, rows []T, id func(T) uint, want ...uint) {
+	t.Helper()
+	got := make([]uint, len(rows))
+	for i, row := range rows {
+		got[i] = id(row)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got ids %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("got ids %v, want %v", got, want)
+		}
+	}
+}
+
+func createRoom(t *testing.T, repos *repository.Repositories, propertyID uint, number, price string) *entity.Room {
+	t.Helper()
+	room := &entity.Room{PropertyID: propertyID, RoomNumber: number, Price: money(t, price)}
+	must(t, repos.Rooms.Create(room))
+	return room
+}
+
+func createTenant(t *testing.T, repos *repository.Repositories, propertyID uint, name string, roomID *uint) *entity.Tenant {
+	t.Helper()
+	tenant := &entity.Tenant{PropertyID: propertyID, Name: name, Phone: "0812", RoomID: roomID, StartDate: date(2026, 1, 15)}
+	must(t, repos.Tenants.Create(tenant))
+	return tenant
+}
+
+func createPayment(t *testing.T, repos *repository.Repositories, tenant *entity.Tenant, period string, due time.Time, amount string) *entity.Payment {
+	t.Helper()
+	payment := &entity.Payment{
+		PropertyID: tenant.PropertyID,
+		TenantID:   tenant.ID,
+		Amount:     money(t, amount),
+		DueDate:    due,
+		Period:     period,
+	}
+	must(t, repos.Payments.Create(payment))
+	return payment
+}
@@ -0,0 +1,178 @@
+package repositorytest
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"testing"
+)
+
+func runRooms(t *testing.T, open Open) {
+	t.Run("CreateAndFindByID", func(t *testing.T) {
+		repos := open(t)
+		room := createRoom(t, repos, 1, "101", "1500000.50")
+		if room.ID == 0 || room.Status != "empty" || room.CreatedAt.IsZero() {
+			t.Fatalf("created room = %+v, want an id, status empty and a creation time", room)
+		}
+
+		found, err := repos.Rooms.FindByID(room.ID)
+		must(t, err)
+		if found.RoomNumber != "101" || found.PropertyID != 1 || found.Tenant != nil {
+			t.Errorf("found room = %+v", found)
+		}
+		wantMoney(t, "price", found.Price, "1500000.50")
+	})
+
+	t.Run("FindByIDMissing", func(t *testing.T) {
+		_, err := open(t).Rooms.FindByID(99)
+		wantKind(t, err, entity.ErrNotFound, "not_found")
+	})
+
+	t.Run("DuplicateRoomNumber", func(t *testing.T) {
+		repos := open(t)
+		createRoom(t, repos, 1, "101", "1000")
+		createRoom(t, repos, 2, "101", "1000")
+
+		err := repos.Rooms.Create(&entity.Room{PropertyID: 1, RoomNumber: "101", Price: money(t, "1000")})
+		wantKind(t, err, entity.ErrConflict, "duplicate")
+	})
+
+	t.Run("FindByIDLoadsTenant", func(t *testing.T) {
+		repos := open(t)
+		room := createRoom(t, repos, 1, "101", "1000")
+		createTenant(t, repos, 1, "Budi", &room.ID)
+
+		found, err := repos.Rooms.FindByID(room.ID)
+		must(t, err)
+		if found.Tenant == nil || found.Tenant.Name != "Budi" {
+			t.Errorf("tenant = %+v, want Budi", found.Tenant)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repos := open(t)
+		room := createRoom(t, repos, 1, "101", "1000")
+		room.Price = money(t, "2500.25")
+		room.Notes = "Repainted"
+		must(t, repos.Rooms.Update(room))
+
+		found, err := repos.Rooms.FindByID(room.ID)
+		must(t, err)
+		if found.Notes != "Repainted" {
+			t.Errorf("notes = %q", found.Notes)
+		}
+		wantMoney(t, "price", found.Price, "2500.25")
+		wantCreatedAt(t, found.CreatedAt, room.CreatedAt)
+	})
+
+	t.Run("UpdateStatus", func(t *testing.T) {
+		repos := open(t)
+		room := createRoom(t, repos, 1, "101", "1000")
+		must(t, repos.Rooms.UpdateStatus(room.ID, "occupied"))
+
+		found, err := repos.Rooms.FindByID(room.ID)
+		must(t, err)
+		if found.Status != "occupied" {
+			t.Errorf("status = %q, want occupied", found.Status)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repos := open(t)
+		room := createRoom(t, repos, 1, "101", "1000")
+		must(t, repos.Rooms.Delete(room.ID))
+
+		_, err := repos.Rooms.FindByID(room.ID)
+		wantKind(t, err, entity.ErrNotFound, "not_found")
+	})
+
+	t.Run("UpdateAndDeleteMissing", func(t *testing.T) {
+		repos := open(t)
+		err := repos.Rooms.Update(&entity.Room{ID: 99, PropertyID: 1, RoomNumber: "999", Price: money(t, "1000")})
+		wantKind(t, err, entity.ErrNotFound, "not_found")
+		wantKind(t, repos.Rooms.Delete(99), entity.ErrNotFound, "not_found")
+
+		rooms, _, err := repos.Rooms.Find(repository.RoomQuery{})
+		must(t, err)
+		if len(rooms) != 0 {
+			t.Errorf("updating a missing room stored %+v", rooms)
+		}
+	})
+
+	t.Run("DeleteInUse", func(t *testing.T) {
+		repos := open(t)
+		room := createRoom(t, repos, 1, "101", "1000")
+		createTenant(t, repos, 1, "Budi", &room.ID)
+
+		wantKind(t, repos.Rooms.Delete(room.ID), entity.ErrConflict, "in_use")
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		repos := open(t)
+		a := createRoom(t, repos, 1, "101", "1000")
+		b := createRoom(t, repos, 1, "102", "3000")
+		c := createRoom(t, repos, 2, "201", "2000")
+		d := createRoom(t, repos, 1, "103", "2000")
+		must(t, repos.Rooms.UpdateStatus(b.ID, "occupied"))
+
+		tests := []struct {
+			name  string
+			query repository.RoomQuery
+			want  []uint
+			total int64
+		}{
+			{"all", repository.RoomQuery{}, []uint{a.ID, b.ID, c.ID, d.ID}, 4},
+			{"property", repository.RoomQuery{PropertyID: 1}, []uint{a.ID, b.ID, d.ID}, 3},
+			{"status", repository.RoomQuery{Status: "empty"}, []uint{a.ID, c.ID, d.ID}, 3},
+			{"price desc then id", repository.RoomQuery{Page: repository.Page{Sort: []repository.Sort{{Field: "price", Desc: true}}}}, []uint{b.ID, c.ID, d.ID, a.ID}, 4},
+			{"page", repository.RoomQuery{Page: repository.Page{Limit: 2, Offset: 1, Sort: []repository.Sort{{Field: "room_number"}}}}, []uint{b.ID, d.ID}, 4},
+			{"past the end", repository.RoomQuery{Page: repository.Page{Limit: 2, Offset: 10}}, []uint{}, 4},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				rooms, total, err := repos.Rooms.Find(tt.query)
+				must(t, err)
+				wantIDs(t, rooms, func(r entity.Room) uint { return r.ID }, tt.want...)
+				if total != tt.total {
+					t.Errorf("total = %d, want %d", total, tt.total)
+				}
+			})
+		}
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		repos := open(t)
+		a := createRoom(t, repos, 1, "101", "1000")
+		createRoom(t, repos, 1, "102", "1000")
+		b := createRoom(t, repos, 2, "201", "1000")
+		must(t, repos.Rooms.UpdateStatus(a.ID, "occupied"))
+		must(t, repos.Rooms.UpdateStatus(b.ID, "occupied"))
+
+		tests := []struct {
+			name       string
+			status     string
+			propertyID uint
+			want       int64
+		}{
+			{"every room", "", 0, 3},
+			{"rooms of a property", "", 1, 2},
+			{"occupied", "occupied", 0, 2},
+			{"occupied in a property", "occupied", 2, 1},
+			{"empty in a property", "empty", 2, 0},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var got int64
+				var err error
+				if tt.status == "" {
+					got, err = repos.Rooms.Count(tt.propertyID)
+				} else {
+					got, err = repos.Rooms.CountByStatus(tt.status, tt.propertyID)
+				}
+				must(t, err)
+				if got != tt.want {
+					t.Errorf("count = %d, want %d", got, tt.want)
+				}
+			})
+		}
+	})
+}
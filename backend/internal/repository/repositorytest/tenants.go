@@ -0,0 +1,173 @@
+package repositorytest
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"testing"
+)
+
+func runTenants(t *testing.T, open Open) {
+	t.Run("CreateAndFindByID", func(t *testing.T) {
+		repos := open(t)
+		room := createRoom(t, repos, 1, "101", "1000")
+		tenant := createTenant(t, repos, 1, "Budi", &room.ID)
+		if tenant.ID == 0 || tenant.Status != "active" {
+			t.Fatalf("created tenant = %+v, want an id and status active", tenant)
+		}
+		payment := createPayment(t, repos, tenant, "2026-02", date(2026, 2, 15), "1000")
+
+		found, err := repos.Tenants.FindByID(tenant.ID)
+		must(t, err)
+		if !found.StartDate.Equal(date(2026, 1, 15)) || found.EndDate != nil {
+			t.Errorf("dates = %v to %v", found.StartDate, found.EndDate)
+		}
+		if found.Room == nil || found.Room.RoomNumber != "101" {
+			t.Errorf("room = %+v, want 101", found.Room)
+		}
+		wantIDs(t, found.Payments, func(p entity.Payment) uint { return p.ID }, payment.ID)
+		wantMoney(t, "credit", found.CreditBalance, "0.00")
+	})
+
+	t.Run("FindByIDMissing", func(t *testing.T) {
+		_, err := open(t).Tenants.FindByID(99)
+		wantKind(t, err, entity.ErrNotFound, "not_found")
+	})
+
+	t.Run("MissingRoom", func(t *testing.T) {
+		repos := open(t)
+		missing := uint(99)
+		err := repos.Tenants.Create(&entity.Tenant{PropertyID: 1, Name: "Budi", Phone: "0812", RoomID: &missing, StartDate: date(2026, 1, 1)})
+		wantKind(t, err, entity.ErrConflict, "missing_reference")
+	})
+
+	t.Run("UpdateKeepsCredit", func(t *testing.T) {
+		repos := open(t)
+		tenant := createTenant(t, repos, 1, "Budi", nil)
+		must(t, repos.Tenants.AdjustCredit(tenant.ID, money(t, "150.50")))
+		must(t, repos.Tenants.AdjustCredit(tenant.ID, money(t, "-50.25")))
+
+		end := date(2026, 6, 30)
+		tenant.Name = "Budi Santoso"
+		tenant.EndDate = &end
+		tenant.Status = "inactive"
+		tenant.CreditBalance = money(t, "999")
+		must(t, repos.Tenants.Update(tenant))
+
+		found, err := repos.Tenants.FindByID(tenant.ID)
+		must(t, err)
+		if found.Name != "Budi Santoso" || found.Status != "inactive" || found.EndDate == nil || !found.EndDate.Equal(end) {
+			t.Errorf("updated tenant = %+v", found)
+		}
+		wantMoney(t, "credit", found.CreditBalance, "100.25")
+		wantCreatedAt(t, found.CreatedAt, tenant.CreatedAt)
+	})
+
+	t.Run("UpdateAndDeleteMissing", func(t *testing.T) {
+		repos := open(t)
+		err := repos.Tenants.Update(&entity.Tenant{ID: 99, PropertyID: 1, Name: "Budi", Phone: "0812", StartDate: date(2026, 1, 15)})
+		wantKind(t, err, entity.ErrNotFound, "not_found")
+		wantKind(t, repos.Tenants.Delete(99), entity.ErrNotFound, "not_found")
+
+		tenants, err := repos.Tenants.FindByStatus("active")
+		must(t, err)
+		if len(tenants) != 0 {
+			t.Errorf("updating a missing tenant stored %+v", tenants)
+		}
+	})
+
+	t.Run("DeleteInUse", func(t *testing.T) {
+		repos := open(t)
+		tenant := createTenant(t, repos, 1, "Budi", nil)
+		createPayment(t, repos, tenant, "2026-02", date(2026, 2, 15), "1000")
+
+		wantKind(t, repos.Tenants.Delete(tenant.ID), entity.ErrConflict, "in_use")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repos := open(t)
+		tenant := createTenant(t, repos, 1, "Budi", nil)
+		must(t, repos.Tenants.Delete(tenant.ID))
+
+		_, err := repos.Tenants.FindByID(tenant.ID)
+		wantKind(t, err, entity.ErrNotFound, "not_found")
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		repos := open(t)
+		a := createTenant(t, repos, 1, "Budi", nil)
+		b := createTenant(t, repos, 1, "Ani 50% Off", nil)
+		c := createTenant(t, repos, 2, "Budiman", nil)
+		d := createTenant(t, repos, 1, "Candra", nil)
+		d.Status = "inactive"
+		must(t, repos.Tenants.Update(d))
+
+		tests := []struct {
+			name  string
+			query repository.TenantQuery
+			want  []uint
+			total int64
+		}{
+			{"all", repository.TenantQuery{}, []uint{a.ID, b.ID, c.ID, d.ID}, 4},
+			{"property", repository.TenantQuery{PropertyID: 2}, []uint{c.ID}, 1},
+			{"status", repository.TenantQuery{Status: "active"}, []uint{a.ID, b.ID, c.ID}, 3},
+			{"search ignores case", repository.TenantQuery{Search: "bUDI"}, []uint{a.ID, c.ID}, 2},
+			{"search is literal", repository.TenantQuery{Search: "50%"}, []uint{b.ID}, 1},
+			{"search underscore is literal", repository.TenantQuery{Search: "_"}, []uint{}, 0},
+			{"name desc", repository.TenantQuery{Page: repository.Page{Sort: []repository.Sort{{Field: "name", Desc: true}}}}, []uint{d.ID, c.ID, a.ID, b.ID}, 4},
+			{"page", repository.TenantQuery{Status: "active", Page: repository.Page{Limit: 1, Offset: 1}}, []uint{b.ID}, 3},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tenants, total, err := repos.Tenants.Find(tt.query)
+				must(t, err)
+				wantIDs(t, tenants, func(t entity.Tenant) uint { return t.ID }, tt.want...)
+				if total != tt.total {
+					t.Errorf("total = %d, want %d", total, tt.total)
+				}
+			})
+		}
+	})
+
+	t.Run("FindByStatus", func(t *testing.T) {
+		repos := open(t)
+		room := createRoom(t, repos, 1, "101", "1000")
+		a := createTenant(t, repos, 1, "Budi", &room.ID)
+		b := createTenant(t, repos, 1, "Ani", nil)
+		b.Status = "inactive"
+		must(t, repos.Tenants.Update(b))
+
+		active, err := repos.Tenants.FindByStatus("active")
+		must(t, err)
+		wantIDs(t, active, func(t entity.Tenant) uint { return t.ID }, a.ID)
+		if active[0].Room == nil || active[0].Room.ID != room.ID {
+			t.Errorf("room = %+v, want room %d", active[0].Room, room.ID)
+		}
+	})
+
+	t.Run("CountByStatus", func(t *testing.T) {
+		repos := open(t)
+		createTenant(t, repos, 1, "Budi", nil)
+		createTenant(t, repos, 2, "Ani", nil)
+		gone := createTenant(t, repos, 1, "Candra", nil)
+		gone.Status = "inactive"
+		must(t, repos.Tenants.Update(gone))
+
+		tests := []struct {
+			status     string
+			propertyID uint
+			want       int64
+		}{
+			{"active", 0, 2},
+			{"active", 1, 1},
+			{"inactive", 1, 1},
+			{"inactive", 2, 0},
+		}
+		for _, tt := range tests {
+			got, err := repos.Tenants.CountByStatus(tt.status, tt.propertyID)
+			must(t, err)
+			if got != tt.want {
+				t.Errorf("CountByStatus(%q, %d) = %d, want %d", tt.status, tt.propertyID, got, tt.want)
+			}
+		}
+	})
+}
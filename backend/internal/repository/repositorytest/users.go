@@ -0,0 +1,86 @@
+package repositorytest
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"testing"
+	"time"
+)
+
+func runUsers(t *testing.T, open Open) {
+	createUser := func(t *testing.T, repos *repository.Repositories, name, email string) *entity.User {
+		t.Helper()
+		user := &entity.User{Name: name, Email: email, PasswordHash: "hash"}
+		must(t, repos.Users.Create(user))
+		return user
+	}
+
+	t.Run("CreateAndFind", func(t *testing.T) {
+		repos := open(t)
+		user := createUser(t, repos, "Sari", "sari@example.com")
+		if user.ID == 0 || user.Role != "staff" || user.Status != "active" {
+			t.Fatalf("created user = %+v, want an id, role staff and status active", user)
+		}
+
+		byEmail, err := repos.Users.FindByEmail("sari@example.com")
+		must(t, err)
+		byID, err := repos.Users.FindByID(user.ID)
+		must(t, err)
+		for _, found := range []*entity.User{byEmail, byID} {
+			if found.ID != user.ID || found.Name != "Sari" || found.PasswordHash != "hash" {
+				t.Errorf("found user = %+v", found)
+			}
+		}
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		repos := open(t)
+		_, err := repos.Users.FindByEmail("nobody@example.com")
+		wantKind(t, err, entity.ErrNotFound, "not_found")
+		_, err = repos.Users.FindByID(99)
+		wantKind(t, err, entity.ErrNotFound, "not_found")
+	})
+
+	t.Run("DuplicateEmail", func(t *testing.T) {
+		repos := open(t)
+		createUser(t, repos, "Sari", "sari@example.com")
+		other := createUser(t, repos, "Joko", "joko@example.com")
+
+		err := repos.Users.Create(&entity.User{Name: "Sari", Email: "sari@example.com", PasswordHash: "hash"})
+		wantKind(t, err, entity.ErrConflict, "duplicate")
+		other.Email = "sari@example.com"
+		wantKind(t, repos.Users.Update(other), entity.ErrConflict, "duplicate")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repos := open(t)
+		user := createUser(t, repos, "Sari", "sari@example.com")
+		locked := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		user.Role = "accountant"
+		user.FailedLogins = 5
+		user.LockedUntil = &locked
+		must(t, repos.Users.Update(user))
+
+		found, err := repos.Users.FindByID(user.ID)
+		must(t, err)
+		if found.Role != "accountant" || found.FailedLogins != 5 || found.LockedUntil == nil || !found.LockedUntil.Equal(locked) {
+			t.Errorf("updated user = %+v", found)
+		}
+	})
+
+	t.Run("FindAllByName", func(t *testing.T) {
+		repos := open(t)
+		c := createUser(t, repos, "Wati", "wati@example.com")
+		a := createUser(t, repos, "Andi", "andi@example.com")
+		b := createUser(t, repos, "Sari", "sari@example.com")
+
+		users, err := repos.Users.FindAll()
+		must(t, err)
+		wantIDs(t, users, func(u entity.User) uint { return u.ID }, a.ID, b.ID, c.ID)
+
+		must(t, repos.Users.Delete(b.ID))
+		users, err = repos.Users.FindAll()
+		must(t, err)
+		wantIDs(t, users, func(u entity.User) uint { return u.ID }, a.ID, c.ID)
+	})
+}
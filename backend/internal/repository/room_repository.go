@@ -6,6 +6,7 @@ import (
 	"ezkost/internal/repository/model"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Room Repository Implementation
@@ -27,17 +28,30 @@ func (r *roomRepository) Create(room *entity.Room) error {
 	return nil
 }
 
-func (r *roomRepository) FindAll() ([]entity.Room, error) {
+func (r *roomRepository) Find(query repository.RoomQuery) ([]entity.Room, int64, error) {
+	var total int64
+	if err := r.matching(query).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
 	var models []model.Room
-	if err := r.db.Preload("Tenant").Find(&models).Error; err != nil {
-		return nil, err
+	if err := paginate(r.matching(query), query.Page).Preload("Tenant").Find(&models).Error; err != nil {
+		return nil, 0, err
 	}
 
 	entities := make([]entity.Room, len(models))
 	for i, m := range models {
 		entities[i] = *m.ToEntity()
 	}
-	return entities, nil
+	return entities, total, nil
+}
+
+func (r *roomRepository) matching(query repository.RoomQuery) *gorm.DB {
+	db := byProperty(r.db.Model(&model.Room{}), "property_id", query.PropertyID)
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	return db
 }
 
 func (r *roomRepository) FindByID(id uint) (*entity.Room, error) {
@@ -51,7 +65,8 @@ func (r *roomRepository) FindByID(id uint) (*entity.Room, error) {
 func (r *roomRepository) Update(room *entity.Room) error {
 	m := &model.Room{}
 	m.FromEntity(room)
-	return r.db.Save(m).Error
+	return matchedRow(r.db.Model(m).Where("id = ?", m.ID).
+		Select("*").Omit("CreatedAt", clause.Associations).Updates(m))
 }
 
 func (r *roomRepository) UpdateStatus(id uint, status string) error {
@@ -59,17 +74,17 @@ func (r *roomRepository) UpdateStatus(id uint, status string) error {
 }
 
 func (r *roomRepository) Delete(id uint) error {
-	return r.db.Delete(&model.Room{}, id).Error
+	return matchedRow(r.db.Delete(&model.Room{}, id))
 }
 
-func (r *roomRepository) Count() (int64, error) {
+func (r *roomRepository) Count(propertyID uint) (int64, error) {
 	var count int64
-	err := r.db.Model(&model.Room{}).Count(&count).Error
+	err := byProperty(r.db.Model(&model.Room{}), "property_id", propertyID).Count(&count).Error
 	return count, err
 }
 
-func (r *roomRepository) CountByStatus(status string) (int64, error) {
+func (r *roomRepository) CountByStatus(status string, propertyID uint) (int64, error) {
 	var count int64
-	err := r.db.Model(&model.Room{}).Where("status = ?", status).Count(&count).Error
+	err := byProperty(r.db.Model(&model.Room{}), "property_id", propertyID).Where("status = ?", status).Count(&count).Error
 	return count, err
 }
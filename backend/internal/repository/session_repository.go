@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Session Repository Implementation
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) repository.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) Create(session *entity.Session) error {
+	m := &model.Session{}
+	m.FromEntity(session)
+	if err := r.db.Create(m).Error; err != nil {
+		return err
+	}
+	*session = *m.ToEntity()
+	return nil
+}
+
+func (r *sessionRepository) FindByID(id uint) (*entity.Session, error) {
+	var m model.Session
+	if err := r.db.First(&m, id).Error; err != nil {
+		return nil, err
+	}
+	return m.ToEntity(), nil
+}
+
+func (r *sessionRepository) Revoke(id uint, at time.Time, reason string) error {
+	return r.db.Model(&model.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": at, "revoked_reason": reason}).Error
+}
+
+func (r *sessionRepository) RevokeByUserID(userID uint, at time.Time, reason string) error {
+	return r.db.Model(&model.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{"revoked_at": at, "revoked_reason": reason}).Error
+}
+
+func (r *sessionRepository) RevokeOthers(userID, keepID uint, at time.Time, reason string) error {
+	return r.db.Model(&model.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, keepID).
+		Updates(map[string]interface{}{"revoked_at": at, "revoked_reason": reason}).Error
+}
+
+func (r *sessionRepository) AddRefreshToken(token *entity.RefreshToken) error {
+	m := &model.RefreshToken{}
+	m.FromEntity(token)
+	if err := r.db.Create(m).Error; err != nil {
+		return err
+	}
+	*token = *m.ToEntity()
+	return nil
+}
+
+func (r *sessionRepository) FindRefreshToken(hash string) (*entity.RefreshToken, error) {
+	var models []model.RefreshToken
+	if err := r.db.Where("token_hash = ?", hash).Limit(1).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	return models[0].ToEntity(), nil
+}
+
+func (r *sessionRepository) UseRefreshToken(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&model.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	return result.RowsAffected == 1, result.Error
+}
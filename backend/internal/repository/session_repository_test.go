@@ -0,0 +1,53 @@
+package repository_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/repository"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestSessionRepositoryRevokeOthers(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, open func(t *testing.T) *gorm.DB) {
+		db := createOrganization(t, open(t), "Kos Melati")
+		users := repository.NewUserRepository(db)
+		sessions := repository.NewSessionRepository(db)
+
+		sari := &entity.User{Name: "Sari", Email: "sari@example.com", PasswordHash: "hash"}
+		budi := &entity.User{Name: "Budi", Email: "budi@example.com", PasswordHash: "hash"}
+		for _, user := range []*entity.User{sari, budi} {
+			mustDo(t, users.Create(user))
+		}
+		earlier := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		current := &entity.Session{UserID: sari.ID}
+		phone := &entity.Session{UserID: sari.ID}
+		loggedOut := &entity.Session{UserID: sari.ID}
+		other := &entity.Session{UserID: budi.ID}
+		for _, session := range []*entity.Session{current, phone, loggedOut, other} {
+			mustDo(t, sessions.Create(session))
+		}
+		mustDo(t, sessions.Revoke(loggedOut.ID, earlier, "logout"))
+
+		mustDo(t, sessions.RevokeOthers(sari.ID, current.ID, time.Now(), "password_change"))
+
+		tests := []struct {
+			name       string
+			session    *entity.Session
+			wantReason string
+		}{
+			{"current", current, ""},
+			{"phone", phone, "password_change"},
+			{"logged out", loggedOut, "logout"},
+			{"other user", other, ""},
+		}
+		for _, tt := range tests {
+			got, err := sessions.FindByID(tt.session.ID)
+			mustDo(t, err)
+			if got.RevokedReason != tt.wantReason || (got.RevokedAt != nil) != (tt.wantReason != "") {
+				t.Errorf("%s session revoked at %v for %q, want %q", tt.name, got.RevokedAt, got.RevokedReason, tt.wantReason)
+			}
+		}
+	})
+}
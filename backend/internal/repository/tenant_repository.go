@@ -4,8 +4,10 @@ import (
 	"ezkost/internal/domain/entity"
 	"ezkost/internal/domain/repository"
 	"ezkost/internal/repository/model"
+	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Tenant Repository Implementation
@@ -27,17 +29,38 @@ func (r *tenantRepository) Create(tenant *entity.Tenant) error {
 	return nil
 }
 
-func (r *tenantRepository) FindAll() ([]entity.Tenant, error) {
+func (r *tenantRepository) Find(query repository.TenantQuery) ([]entity.Tenant, int64, error) {
+	var total int64
+	if err := r.matching(query).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
 	var models []model.Tenant
-	if err := r.db.Preload("Room").Preload("Payments").Find(&models).Error; err != nil {
-		return nil, err
+	if err := paginate(r.matching(query), query.Page).Preload("Room").Preload("Payments").Find(&models).Error; err != nil {
+		return nil, 0, err
 	}
 
 	entities := make([]entity.Tenant, len(models))
 	for i, m := range models {
 		entities[i] = *m.ToEntity()
 	}
-	return entities, nil
+	return entities, total, nil
+}
+
+func (r *tenantRepository) matching(query repository.TenantQuery) *gorm.DB {
+	db := byProperty(r.db.Model(&model.Tenant{}), "property_id", query.PropertyID)
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if query.Search != "" {
+		db = db.Where(`LOWER(name) LIKE ? ESCAPE '\'`, "%"+escapeLike(strings.ToLower(query.Search))+"%")
+	}
+	return db
+}
+
+// escapeLike makes s match itself literally in a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
 
 func (r *tenantRepository) FindByID(id uint) (*entity.Tenant, error) {
@@ -48,18 +71,39 @@ func (r *tenantRepository) FindByID(id uint) (*entity.Tenant, error) {
 	return m.ToEntity(), nil
 }
 
+func (r *tenantRepository) FindByStatus(status string) ([]entity.Tenant, error) {
+	var models []model.Tenant
+	if err := r.db.Preload("Room").Where("status = ?", status).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]entity.Tenant, len(models))
+	for i, m := range models {
+		entities[i] = *m.ToEntity()
+	}
+	return entities, nil
+}
+
+// Update never touches the credit balance; use AdjustCredit for that.
 func (r *tenantRepository) Update(tenant *entity.Tenant) error {
 	m := &model.Tenant{}
 	m.FromEntity(tenant)
-	return r.db.Save(m).Error
+	return matchedRow(r.db.Model(m).Where("id = ?", m.ID).
+		Select("*").Omit("CreatedAt", "CreditBalance", clause.Associations).Updates(m))
 }
 
 func (r *tenantRepository) Delete(id uint) error {
-	return r.db.Delete(&model.Tenant{}, id).Error
+	return matchedRow(r.db.Delete(&model.Tenant{}, id))
 }
 
-func (r *tenantRepository) CountByStatus(status string) (int64, error) {
+func (r *tenantRepository) CountByStatus(status string, propertyID uint) (int64, error) {
 	var count int64
-	err := r.db.Model(&model.Tenant{}).Where("status = ?", status).Count(&count).Error
+	err := byProperty(r.db.Model(&model.Tenant{}), "property_id", propertyID).Where("status = ?", status).Count(&count).Error
 	return count, err
 }
+
+func (r *tenantRepository) AdjustCredit(id uint, delta entity.Money) error {
+	return r.db.Model(&model.Tenant{}).
+		Where("id = ?", id).
+		Update("credit_balance", gorm.Expr("credit_balance + ?", delta)).Error
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"ezkost/internal/domain/repository"
+
+	"gorm.io/gorm"
+)
+
+// Unit Of Work Implementation
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) repository.UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+func (u *unitOfWork) Do(fn func(repos *repository.Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepositories(tx))
+	})
+}
+
+// NewRepositories builds every GORM repository on db, which may be a
+// transaction.
+func NewRepositories(db *gorm.DB) *repository.Repositories {
+	return &repository.Repositories{
+		Organizations:   NewOrganizationRepository(db),
+		Users:           NewUserRepository(db),
+		Properties:      NewPropertyRepository(db),
+		Rooms:           NewRoomRepository(db),
+		Tenants:         NewTenantRepository(db),
+		Payments:        NewPaymentRepository(db),
+		Expenses:        NewExpenseRepository(db),
+		Deposits:        NewDepositRepository(db),
+		LateFeePolicies: NewLateFeePolicyRepository(db),
+		MeterReadings:   NewMeterReadingRepository(db),
+		UtilityTariffs:  NewUtilityTariffRepository(db),
+		Letterheads:     NewLetterheadRepository(db),
+		Receipts:        NewReceiptRepository(db),
+		Leases:          NewLeaseRepository(db),
+		Occupancies:     NewOccupancyRepository(db),
+		Invitations:     NewInvitationRepository(db),
+		Audit:           NewAuditRepository(db),
+		Sessions:        NewSessionRepository(db),
+		PasswordResets:  NewPasswordResetRepository(db),
+		RecoveryCodes:   NewRecoveryCodeRepository(db),
+		LoginAttempts:   NewLoginAttemptRepository(db),
+	}
+}
@@ -27,6 +27,19 @@ func (r *userRepository) Create(user *entity.User) error {
 	return nil
 }
 
+func (r *userRepository) FindAll() ([]entity.User, error) {
+	var models []model.User
+	if err := r.db.Order("name ASC").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]entity.User, len(models))
+	for i, m := range models {
+		entities[i] = *m.ToEntity()
+	}
+	return entities, nil
+}
+
 func (r *userRepository) FindByEmail(email string) (*entity.User, error) {
 	var m model.User
 	if err := r.db.Where("email = ?", email).First(&m).Error; err != nil {
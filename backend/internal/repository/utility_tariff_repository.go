@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/model"
+
+	"gorm.io/gorm"
+)
+
+// Utility Tariff Repository Implementation
+type utilityTariffRepository struct {
+	db *gorm.DB
+}
+
+func NewUtilityTariffRepository(db *gorm.DB) repository.UtilityTariffRepository {
+	return &utilityTariffRepository{db: db}
+}
+
+func (r *utilityTariffRepository) FindAll() ([]entity.UtilityTariff, error) {
+	var models []model.UtilityTariff
+	if err := r.db.Preload("Tiers", orderTiers).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]entity.UtilityTariff, len(models))
+	for i, m := range models {
+		entities[i] = *m.ToEntity()
+	}
+	return entities, nil
+}
+
+func (r *utilityTariffRepository) FindByUtility(utility string) (*entity.UtilityTariff, error) {
+	var m model.UtilityTariff
+	if err := r.db.Preload("Tiers", orderTiers).Where("utility = ?", utility).First(&m).Error; err != nil {
+		return nil, err
+	}
+	return m.ToEntity(), nil
+}
+
+// Save replaces the tariff and all of its tiers.
+func (r *utilityTariffRepository) Save(tariff *entity.UtilityTariff) error {
+	m := &model.UtilityTariff{}
+	m.FromEntity(tariff)
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Tiers").Save(m).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tariff_id = ?", m.ID).Delete(&model.TariffTier{}).Error; err != nil {
+			return err
+		}
+		for i := range m.Tiers {
+			m.Tiers[i].ID = 0
+			m.Tiers[i].TariffID = m.ID
+		}
+		if len(m.Tiers) > 0 {
+			if err := tx.Create(&m.Tiers).Error; err != nil {
+				return err
+			}
+		}
+		*tariff = *m.ToEntity()
+		return nil
+	})
+}
+
+// orderTiers keeps bounded tiers in ascending order with the open-ended tier last.
+func orderTiers(db *gorm.DB) *gorm.DB {
+	return db.Order("CASE WHEN up_to = 0 THEN 1 ELSE 0 END, up_to ASC")
+}
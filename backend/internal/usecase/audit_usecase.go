@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"time"
+)
+
+const AuditAccessDenied = "access_denied"
+
+// Limits on how many audit entries one search returns
+const (
+	DefaultAuditLimit = 100
+	MaxAuditLimit     = 500
+)
+
+// Audit Usecase
+type AuditUsecase interface {
+	Record(entry *entity.AuditEntry) error
+	Search(filter repository.AuditFilter) ([]entity.AuditEntry, error)
+}
+
+type auditUsecase struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditUsecase(auditRepo repository.AuditRepository) AuditUsecase {
+	return &auditUsecase{auditRepo: auditRepo}
+}
+
+func (u *auditUsecase) Record(entry *entity.AuditEntry) error {
+	entry.ID = 0
+	entry.CreatedAt = time.Now()
+	return u.auditRepo.Create(entry)
+}
+
+func (u *auditUsecase) Search(filter repository.AuditFilter) ([]entity.AuditEntry, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultAuditLimit
+	}
+	filter.Limit = min(filter.Limit, MaxAuditLimit)
+	return u.auditRepo.Find(filter)
+}
@@ -0,0 +1,88 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"testing"
+	"time"
+)
+
+// auditLog keeps entries in the order they were recorded and remembers the
+// last filter searched with.
+type auditLog struct {
+	rows   []entity.AuditEntry
+	filter repository.AuditFilter
+}
+
+var _ repository.AuditRepository = (*auditLog)(nil)
+
+func (a *auditLog) Create(entry *entity.AuditEntry) error {
+	entry.ID = uint(len(a.rows) + 1)
+	a.rows = append(a.rows, *entry)
+	return nil
+}
+
+func (a *auditLog) Find(filter repository.AuditFilter) ([]entity.AuditEntry, error) {
+	a.filter = filter
+	var found []entity.AuditEntry
+	for i := len(a.rows) - 1; i >= 0 && len(found) < filter.Limit; i-- {
+		if filter.Action == "" || a.rows[i].Action == filter.Action {
+			found = append(found, a.rows[i])
+		}
+	}
+	return found, nil
+}
+
+func TestAuditRecord(t *testing.T) {
+	f := newFixture(t)
+	entry := &entity.AuditEntry{ID: 42, UserID: 1, Action: "access_denied", CreatedAt: date(2020, 1, 1)}
+	before := time.Now()
+	must(t, f.usecases.Audit.Record(entry))
+
+	if entry.ID != 1 {
+		t.Errorf("id = %d, want the one the log hands out", entry.ID)
+	}
+	if entry.CreatedAt.Before(before) {
+		t.Errorf("created at %v, want the time it was recorded", entry.CreatedAt)
+	}
+}
+
+func TestAuditSearch(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     repository.AuditFilter
+		wantLimit  int
+		wantCount  int
+		wantNewest uint
+	}{
+		{"default limit", repository.AuditFilter{}, 100, 100, 119},
+		{"negative limit", repository.AuditFilter{Limit: -1}, 100, 100, 119},
+		{"own limit", repository.AuditFilter{Limit: 10}, 10, 10, 119},
+		{"capped limit", repository.AuditFilter{Limit: 1000}, 500, 120, 119},
+		{"filtered", repository.AuditFilter{Action: "delete", Limit: 1000}, 500, 20, 114},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			for i := 0; i < 120; i++ {
+				action := "update"
+				if i%6 == 0 {
+					action = "delete"
+				}
+				must(t, f.usecases.Audit.Record(&entity.AuditEntry{UserID: 1, Action: action, EntityType: "rooms", EntityID: uint(i)}))
+			}
+
+			entries, err := f.usecases.Audit.Search(tt.filter)
+			must(t, err)
+			if got := f.repos.Audit.(*auditLog).filter.Limit; got != tt.wantLimit {
+				t.Errorf("searched with limit %d, want %d", got, tt.wantLimit)
+			}
+			if len(entries) != tt.wantCount {
+				t.Errorf("%d entries, want %d", len(entries), tt.wantCount)
+			}
+			if len(entries) > 0 && entries[0].EntityID != tt.wantNewest {
+				t.Errorf("first entry = %+v, want the newest, for room %d", entries[0], tt.wantNewest)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	totpIssuer        = "EZKost"
+	recoveryCodeCount = 10
+	challengePurpose  = "two_factor"
+)
+
+// LoginResult is the outcome of a login step. Either Tokens are issued or,
+// when a second factor is needed, a Challenge.
+type LoginResult struct {
+	User      *entity.User
+	Tokens    *AuthTokens
+	Challenge *TwoFactorChallenge
+	// RecoveryCodes are returned once, by the login that completed
+	// enrollment
+	RecoveryCodes []string
+}
+
+// TwoFactorChallenge is the second step of a login. EnrollmentRequired tells
+// the client the user has no authenticator yet and has to set one up with
+// EnrollWithChallenge first.
+type TwoFactorChallenge struct {
+	Token              string    `json:"challenge_token"`
+	ExpiresAt          time.Time `json:"expires_at"`
+	EnrollmentRequired bool      `json:"enrollment_required"`
+}
+
+// TwoFactorSetup is shown to the user while enrolling; ProvisioningURI is
+// meant to be rendered as a QR code for the authenticator app.
+type TwoFactorSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+func (u *authUsecase) VerifyTwoFactor(challengeToken, code, ip string) (*LoginResult, error) {
+	user, err := u.parseChallenge(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.limitAccount(user.Email, ip); err != nil {
+		return nil, err
+	}
+	if err := u.checkLocked(user, ip); err != nil {
+		return nil, err
+	}
+
+	result := &LoginResult{User: user}
+	if user.TwoFactorEnabled {
+		var ok bool
+		ok, err = u.checkSecondFactor(user, code)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			err = ErrInvalidCode
+		}
+	} else {
+		if user.TOTPSecret == "" {
+			return nil, entity.Conflict("two_factor_not_set_up", "set up an authenticator before verifying")
+		}
+		result.RecoveryCodes, err = u.enable(user, code)
+	}
+	if errors.Is(err, ErrInvalidCode) {
+		if err := u.loginFailed(user, ip, LoginInvalidCode); err != nil {
+			return nil, err
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Tokens, err = u.startSession(user); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (u *authUsecase) EnrollWithChallenge(challengeToken string) (*TwoFactorSetup, error) {
+	user, err := u.parseChallenge(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	return u.setup(user)
+}
+
+func (u *authUsecase) SetupTwoFactor(userID uint) (*TwoFactorSetup, error) {
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.setup(user)
+}
+
+func (u *authUsecase) EnableTwoFactor(userID uint, code string) ([]string, error) {
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, entity.Conflict("two_factor_enabled", "two-factor authentication is already enabled")
+	}
+	if user.TOTPSecret == "" {
+		return nil, entity.Conflict("two_factor_not_set_up", "set up an authenticator before enabling two-factor authentication")
+	}
+	return u.enable(user, code)
+}
+
+func (u *authUsecase) DisableTwoFactor(userID uint, password string) error {
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return ErrWrongPassword
+	}
+	required, err := u.twoFactorRequired(user)
+	if err != nil {
+		return err
+	}
+	if required {
+		return entity.Forbidden("two_factor_required", "your organization requires two-factor authentication")
+	}
+
+	user.TwoFactorEnabled = false
+	user.TOTPSecret = ""
+	user.TOTPLastStep = 0
+	user.UpdatedAt = time.Now()
+	return u.uow.Do(func(repos *repository.Repositories) error {
+		if err := repos.Users.Update(user); err != nil {
+			return err
+		}
+		return repos.RecoveryCodes.Replace(user.ID, nil)
+	})
+}
+
+func (u *authUsecase) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TwoFactorEnabled {
+		return nil, entity.Conflict("two_factor_disabled", "two-factor authentication is not enabled")
+	}
+	step, ok := verifyTOTP(user.TOTPSecret, strings.TrimSpace(code), time.Now(), user.TOTPLastStep)
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	user.TOTPLastStep = step
+	user.UpdatedAt = time.Now()
+	return u.saveRecoveryCodes(user)
+}
+
+// setup gives the user a new secret. It only takes effect once confirmed,
+// so a user who is already enrolled has to disable two-factor first.
+func (u *authUsecase) setup(user *entity.User) (*TwoFactorSetup, error) {
+	if user.TwoFactorEnabled {
+		return nil, entity.Conflict("two_factor_enabled", "two-factor authentication is already enabled")
+	}
+
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	user.TOTPSecret = secret
+	user.TOTPLastStep = 0
+	user.UpdatedAt = time.Now()
+	if err := u.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: totpURI(totpIssuer, user.Email, secret),
+	}, nil
+}
+
+// enable confirms the pending secret with a code from the authenticator.
+func (u *authUsecase) enable(user *entity.User, code string) ([]string, error) {
+	step, ok := verifyTOTP(user.TOTPSecret, strings.TrimSpace(code), time.Now(), user.TOTPLastStep)
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	user.TwoFactorEnabled = true
+	user.TOTPLastStep = step
+	user.UpdatedAt = time.Now()
+	return u.saveRecoveryCodes(user)
+}
+
+// saveRecoveryCodes saves the user and replaces their recovery codes with new
+// ones, which are returned.
+func (u *authUsecase) saveRecoveryCodes(user *entity.User) ([]string, error) {
+	codes, err := newRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes := make([]entity.RecoveryCode, len(codes))
+	for i, code := range codes {
+		recoveryCodes[i] = entity.RecoveryCode{CodeHash: hashRecoveryCode(code), CreatedAt: time.Now()}
+	}
+
+	err = u.uow.Do(func(repos *repository.Repositories) error {
+		if err := repos.Users.Update(user); err != nil {
+			return err
+		}
+		return repos.RecoveryCodes.Replace(user.ID, recoveryCodes)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// checkSecondFactor accepts a current authenticator code or an unused
+// recovery code.
+func (u *authUsecase) checkSecondFactor(user *entity.User, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	now := time.Now()
+	if step, ok := verifyTOTP(user.TOTPSecret, code, now, user.TOTPLastStep); ok {
+		user.TOTPLastStep = step
+		user.UpdatedAt = now
+		return true, u.userRepo.Update(user)
+	}
+
+	var used bool
+	err := u.uow.Do(func(repos *repository.Repositories) error {
+		var err error
+		used, err = repos.RecoveryCodes.Use(user.ID, hashRecoveryCode(code), now)
+		return err
+	})
+	return used, err
+}
+
+func (u *authUsecase) twoFactorRequired(user *entity.User) (bool, error) {
+	organization, err := u.organizationRepo.FindByID(user.OrganizationID)
+	if err != nil {
+		return false, err
+	}
+	return organization.RequireTwoFactor, nil
+}
+
+// newChallenge signs a token that only VerifyTwoFactor and
+// EnrollWithChallenge accept; ValidateToken refuses it.
+func (u *authUsecase) newChallenge(user *entity.User) (*TwoFactorChallenge, error) {
+	expiresAt := time.Now().Add(u.opts.ChallengeTTL)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": user.ID,
+		"purpose": challengePurpose,
+		"exp":     expiresAt.Unix(),
+	})
+	tokenString, err := token.SignedString([]byte(u.opts.JWTSecret))
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorChallenge{
+		Token:              tokenString,
+		ExpiresAt:          expiresAt,
+		EnrollmentRequired: !user.TwoFactorEnabled,
+	}, nil
+}
+
+func (u *authUsecase) parseChallenge(tokenString string) (*entity.User, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(u.opts.JWTSecret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidChallenge
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != challengePurpose {
+		return nil, ErrInvalidChallenge
+	}
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return nil, ErrInvalidChallenge
+	}
+
+	user, err := u.userRepo.FindByID(uint(userID))
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil || user.Status != "active" {
+		return nil, ErrInvalidChallenge
+	}
+	return user, nil
+}
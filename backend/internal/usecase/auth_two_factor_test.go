@@ -0,0 +1,208 @@
+package usecase_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/usecase"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type recoveryCodes struct {
+	rows []entity.RecoveryCode
+}
+
+var _ repository.RecoveryCodeRepository = (*recoveryCodes)(nil)
+
+func (r *recoveryCodes) Replace(userID uint, codes []entity.RecoveryCode) error {
+	var kept []entity.RecoveryCode
+	for _, code := range r.rows {
+		if code.UserID != userID {
+			kept = append(kept, code)
+		}
+	}
+	for _, code := range codes {
+		code.UserID = userID
+		kept = append(kept, code)
+	}
+	r.rows = kept
+	return nil
+}
+
+func (r *recoveryCodes) Use(userID uint, hash string, at time.Time) (bool, error) {
+	for i := range r.rows {
+		if r.rows[i].UserID == userID && r.rows[i].CodeHash == hash && r.rows[i].UsedAt == nil {
+			r.rows[i].UsedAt = &at
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type loginAttempts struct {
+	rows []entity.LoginAttempt
+}
+
+var _ repository.LoginAttemptRepository = (*loginAttempts)(nil)
+
+func (l *loginAttempts) Create(attempt *entity.LoginAttempt) error {
+	l.rows = append(l.rows, *attempt)
+	return nil
+}
+
+// totp is the code an authenticator app shows for secret, steps of 30
+// seconds after now.
+func totp(t *testing.T, secret string, steps int64) string {
+	t.Helper()
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	must(t, err)
+	return hotp(key, time.Now().Unix()/30+steps)
+}
+
+// hotp is the code of one time step (RFC 4226 section 5.3).
+func hotp(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	return fmt.Sprintf("%06d", binary.BigEndian.Uint32(sum[offset:offset+4])&0x7fffffff%1000000)
+}
+
+func TestHOTP(t *testing.T) {
+	// The SHA1 test vector of RFC 6238 at 59 seconds, cut to six digits
+	if got := hotp([]byte("12345678901234567890"), 59/30); got != "287082" {
+		t.Errorf("hotp = %s, want 287082", got)
+	}
+}
+
+func TestAuthEnableTwoFactor(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   int64
+		wantErr error
+	}{
+		{"current code", 0, nil},
+		{"code of the next step", 1, nil},
+		{"code two minutes old", -4, usecase.ErrInvalidCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			auth, _, _ := signIn(t, f, usecase.AuthOptions{RefreshTokenTTL: time.Hour})
+			setup, err := auth.SetupTwoFactor(1)
+			must(t, err)
+			if !strings.HasPrefix(setup.ProvisioningURI, "otpauth://totp/EZKost:sari@example.com?") {
+				t.Errorf("provisioning URI = %s", setup.ProvisioningURI)
+			}
+
+			codes, err := auth.EnableTwoFactor(1, totp(t, setup.Secret, tt.steps))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			user, err := f.repos.Users.FindByID(1)
+			must(t, err)
+			wantCodes := 0
+			if tt.wantErr == nil {
+				wantCodes = 10
+			}
+			if user.TwoFactorEnabled != (tt.wantErr == nil) || len(codes) != wantCodes {
+				t.Errorf("two-factor enabled = %v with %d recovery codes", user.TwoFactorEnabled, len(codes))
+			}
+		})
+	}
+}
+
+func TestAuthVerifyTwoFactor(t *testing.T) {
+	tests := []struct {
+		name string
+		// code is given the secret and the recovery codes
+		code      func(t *testing.T, secret string, recovery []string) string
+		challenge string
+		wantErr   error
+	}{
+		{"code of the next step", func(t *testing.T, secret string, _ []string) string {
+			return totp(t, secret, 1)
+		}, "", nil},
+		{"code typed with spaces", func(t *testing.T, secret string, _ []string) string {
+			return " " + totp(t, secret, 1) + " "
+		}, "", nil},
+		{"code used to enable", func(t *testing.T, secret string, _ []string) string {
+			return totp(t, secret, 0)
+		}, "", usecase.ErrInvalidCode},
+		{"code two minutes ahead", func(t *testing.T, secret string, _ []string) string {
+			return totp(t, secret, 4)
+		}, "", usecase.ErrInvalidCode},
+		{"not a code", func(t *testing.T, _ string, _ []string) string {
+			return "abcdef"
+		}, "", usecase.ErrInvalidCode},
+		{"recovery code", func(t *testing.T, _ string, recovery []string) string {
+			return recovery[3]
+		}, "", nil},
+		{"recovery code typed loosely", func(t *testing.T, _ string, recovery []string) string {
+			return strings.ToUpper(strings.ReplaceAll(recovery[3], "-", " "))
+		}, "", nil},
+		{"forged challenge", func(t *testing.T, secret string, _ []string) string {
+			return totp(t, secret, 1)
+		}, "forged", usecase.ErrInvalidChallenge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			auth, logins, _ := signIn(t, f, usecase.AuthOptions{RefreshTokenTTL: time.Hour, ChallengeTTL: 5 * time.Minute})
+			setup, err := auth.SetupTwoFactor(1)
+			must(t, err)
+			recovery, err := auth.EnableTwoFactor(1, totp(t, setup.Secret, 0))
+			must(t, err)
+
+			result, err := auth.Login("sari@example.com", "rahasia123", "10.0.0.1")
+			must(t, err)
+			if result.Tokens != nil || result.Challenge == nil || result.Challenge.EnrollmentRequired {
+				t.Fatalf("login = %+v, want a challenge for the second factor", result)
+			}
+			challenge := result.Challenge.Token
+			if tt.challenge != "" {
+				challenge = tt.challenge
+			}
+
+			code := tt.code(t, setup.Secret, recovery)
+			result, err = auth.VerifyTwoFactor(challenge, code, "10.0.0.1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			user, err := f.repos.Users.FindByID(1)
+			must(t, err)
+			if tt.wantErr != nil {
+				if len(logins.rows) != 1 {
+					t.Errorf("%d sessions, want only the one of signing in", len(logins.rows))
+				}
+				wantFailed := 0
+				if errors.Is(tt.wantErr, usecase.ErrInvalidCode) {
+					wantFailed = 1
+				}
+				if user.FailedLogins != wantFailed {
+					t.Errorf("%d failed logins, want %d", user.FailedLogins, wantFailed)
+				}
+				return
+			}
+			if result.Tokens == nil || len(logins.rows) != 2 || user.FailedLogins != 0 {
+				t.Errorf("result = %+v with %d sessions and %d failed logins, want a new session", result, len(logins.rows), user.FailedLogins)
+			}
+
+			// Neither a code nor a recovery code works twice
+			login, err := auth.Login("sari@example.com", "rahasia123", "10.0.0.1")
+			must(t, err)
+			if _, err := auth.VerifyTwoFactor(login.Challenge.Token, code, "10.0.0.1"); !errors.Is(err, usecase.ErrInvalidCode) {
+				t.Errorf("replayed code: got error %v, want %v", err, usecase.ErrInvalidCode)
+			}
+		})
+	}
+}
@@ -4,92 +4,659 @@ import (
 	"errors"
 	"ezkost/internal/domain/entity"
 	"ezkost/internal/domain/repository"
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrRegistrationClosed is returned by Register unless self-registration was
+// opened in the configuration.
+var ErrRegistrationClosed = entity.Forbidden("registration_closed", "registration is closed")
+
+var (
+	ErrInvalidRefreshToken = entity.Unauthorized("invalid_refresh_token", "refresh token is invalid or has expired")
+	// ErrRefreshTokenReused means a refresh token was presented after it had
+	// already been exchanged, so it may have been stolen. The whole session
+	// is revoked.
+	ErrRefreshTokenReused = entity.Unauthorized("refresh_token_reused", "refresh token was already used; the session has been revoked")
+	ErrWrongPassword      = entity.Invalid("wrong_password", "current password is incorrect")
+	ErrInvalidChallenge   = entity.Unauthorized("invalid_challenge", "login challenge is invalid or has expired")
+	ErrInvalidCode        = entity.Invalid("invalid_code", "invalid two-factor code")
+	ErrTwoFactorRequired  = entity.Unauthorized("two_factor_required", "your organization requires two-factor authentication; log in again to enroll")
+)
+
+// Reasons a login attempt failed
+const (
+	LoginUnknownEmail  = "unknown_email"
+	LoginWrongPassword = "wrong_password"
+	LoginInvalidCode   = "invalid_code"
+	LoginLocked        = "locked"
+	LoginRateLimited   = "rate_limited"
+)
+
+// Reasons a session was revoked
+const (
+	RevokedLogout    = "logout"
+	RevokedLogoutAll = "logout_all"
+	RevokedReuse     = "refresh_token_reuse"
+	RevokedReset     = "password_reset"
+	RevokedChange    = "password_change"
+)
+
 type AuthUsecase interface {
-	Register(user *entity.User, password string) error
-	Login(email, password string) (string, *entity.User, error)
-	ValidateToken(tokenString string) (uint, string, error)
+	// Register signs up a new organization with user as its owner, when
+	// self-registration is open.
+	Register(organization *entity.Organization, user *entity.User, password string) error
+	// Bootstrap creates an organization and its first owner regardless of
+	// whether registration is open. It is meant for the command line.
+	Bootstrap(organization *entity.Organization, user *entity.User, password string) error
+	// AcceptInvitation creates the invited user from a single-use token.
+	AcceptInvitation(token, name, password string) (*entity.User, error)
+	// Login checks the password. It starts a new session unless the user
+	// has to pass two-factor authentication, in which case it returns a
+	// challenge for VerifyTwoFactor. Failures are recorded with the client
+	// IP and repeated ones lock the account for a while, reported as a
+	// *RetryError.
+	Login(email, password, ip string) (*LoginResult, error)
+	// VerifyTwoFactor completes a login with a code from the authenticator
+	// app or a recovery code. Users who must enroll first confirm their new
+	// authenticator with it, and get their recovery codes.
+	VerifyTwoFactor(challengeToken, code, ip string) (*LoginResult, error)
+	// EnrollWithChallenge starts enrollment for a user whose organization
+	// requires two-factor authentication and who is still logging in.
+	EnrollWithChallenge(challengeToken string) (*TwoFactorSetup, error)
+	SetupTwoFactor(userID uint) (*TwoFactorSetup, error)
+	// EnableTwoFactor confirms the authenticator set up by SetupTwoFactor
+	// and returns the recovery codes, which are shown only once.
+	EnableTwoFactor(userID uint, code string) ([]string, error)
+	DisableTwoFactor(userID uint, password string) error
+	RegenerateRecoveryCodes(userID uint, code string) ([]string, error)
+	// Refresh exchanges a refresh token for new tokens of the same session.
+	Refresh(refreshToken string) (*AuthTokens, error)
+	// Logout revokes one session; LogoutAll every session of the user.
+	Logout(sessionID uint) error
+	LogoutAll(userID uint) error
+	// ChangePassword sets a new password and logs the user out of every
+	// session but sessionID, the one making the change.
+	ChangePassword(userID, sessionID uint, oldPassword, newPassword string) error
+	// ForgotPassword mails a reset token to the user with the email. It
+	// does not reveal whether such a user exists.
+	ForgotPassword(email string) error
+	// ResetPassword sets a new password from a reset token and logs the
+	// user out of every session.
+	ResetPassword(token, newPassword string) error
+	ValidateToken(tokenString string) (*TokenClaims, error)
+}
+
+// AuthTokens are issued by Login and Refresh. The access token authenticates
+// requests until ExpiresAt; the refresh token can be exchanged once.
+type AuthTokens struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// TokenClaims identify who made a request, for which organization and from
+// which session.
+type TokenClaims struct {
+	UserID         uint
+	OrganizationID uint
+	SessionID      uint
+	Role           string
+}
+
+// AuthOptions configure authentication.
+type AuthOptions struct {
+	JWTSecret        string
+	RegistrationOpen bool
+	AccessTokenTTL   time.Duration
+	RefreshTokenTTL  time.Duration
+
+	Mailer           Mailer
+	PasswordResetTTL time.Duration
+	// PasswordResetURL is the page where users choose a new password; the
+	// reset token is appended as the "token" query parameter.
+	PasswordResetURL string
+
+	// ChallengeTTL is how long the second step of a login may take
+	ChallengeTTL time.Duration
+
+	// AccountLimiter limits login attempts per account
+	AccountLimiter RateLimiter
+	// After LockoutThreshold failed logins in a row the account is locked
+	// for LockoutDuration, doubling with every further failure up to
+	// LockoutMaxDuration. A threshold of 0 disables lockout.
+	LockoutThreshold   int
+	LockoutDuration    time.Duration
+	LockoutMaxDuration time.Duration
 }
 
 type authUsecase struct {
-	userRepo  repository.UserRepository
-	jwtSecret string
+	uow              repository.UnitOfWork
+	userRepo         repository.UserRepository
+	organizationRepo repository.OrganizationRepository
+	sessionRepo      repository.SessionRepository
+	opts             AuthOptions
 }
 
-func NewAuthUsecase(userRepo repository.UserRepository, jwtSecret string) AuthUsecase {
+func NewAuthUsecase(
+	uow repository.UnitOfWork,
+	userRepo repository.UserRepository,
+	organizationRepo repository.OrganizationRepository,
+	sessionRepo repository.SessionRepository,
+	opts AuthOptions,
+) AuthUsecase {
 	return &authUsecase{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
+		uow:              uow,
+		userRepo:         userRepo,
+		organizationRepo: organizationRepo,
+		sessionRepo:      sessionRepo,
+		opts:             opts,
 	}
 }
 
-func (u *authUsecase) Register(user *entity.User, password string) error {
+func (u *authUsecase) Register(organization *entity.Organization, user *entity.User, password string) error {
+	if !u.opts.RegistrationOpen {
+		return ErrRegistrationClosed
+	}
+	return u.createOwner(organization, user, password)
+}
+
+func (u *authUsecase) Bootstrap(organization *entity.Organization, user *entity.User, password string) error {
+	return u.createOwner(organization, user, password)
+}
+
+func (u *authUsecase) createOwner(organization *entity.Organization, user *entity.User, password string) error {
+	if organization.Name == "" {
+		organization.Name = user.Name
+	}
+
+	hashedPassword, err := u.prepareUser(user, password)
+	if err != nil {
+		return err
+	}
+
+	user.PasswordHash = hashedPassword
+	user.Role = "owner"
+	organization.CreatedAt = time.Now()
+	organization.UpdatedAt = time.Now()
+
+	return u.uow.Do(func(repos *repository.Repositories) error {
+		if err := repos.Organizations.Create(organization); err != nil {
+			return err
+		}
+		user.OrganizationID = organization.ID
+		return repos.Users.Create(user)
+	})
+}
+
+// prepareUser checks that the email is free and hashes the password.
+func (u *authUsecase) prepareUser(user *entity.User, password string) (string, error) {
+	user.Email = strings.ToLower(strings.TrimSpace(user.Email))
+	if user.Name == "" || user.Email == "" {
+		return "", entity.Invalid("name_required", "name and email are required")
+	}
+
 	// Check if email already exists
-	existing, _ := u.userRepo.FindByEmail(user.Email)
+	existing, err := u.userRepo.FindByEmail(user.Email)
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return "", err
+	}
 	if existing != nil {
-		return errors.New("email already registered")
+		return "", entity.Conflict("email_taken", "email already registered")
 	}
 
-	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := hashPassword(password)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	user.PasswordHash = string(hashedPassword)
+	user.Status = "active"
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
+	return string(hashedPassword), nil
+}
 
-	return u.userRepo.Create(user)
+func (u *authUsecase) AcceptInvitation(token, name, password string) (*entity.User, error) {
+	var user *entity.User
+	err := u.uow.Do(func(repos *repository.Repositories) error {
+		invitation, err := repos.Invitations.FindByTokenHash(hashToken(token))
+		if err != nil {
+			return err
+		}
+		if invitation == nil || invitation.AcceptedAt != nil || !time.Now().Before(invitation.ExpiresAt) {
+			return entity.Invalid("invalid_invitation", "invitation is invalid or has expired")
+		}
+
+		user = &entity.User{
+			OrganizationID: invitation.OrganizationID,
+			Name:           name,
+			Email:          invitation.Email,
+			Role:           invitation.Role,
+		}
+		hashedPassword, err := u.prepareUser(user, password)
+		if err != nil {
+			return err
+		}
+		user.PasswordHash = hashedPassword
+		if err := repos.Users.Create(user); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		invitation.AcceptedAt = &now
+		invitation.UpdatedAt = now
+		return repos.Invitations.Update(invitation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
-func (u *authUsecase) Login(email, password string) (string, *entity.User, error) {
+func (u *authUsecase) Login(email, password, ip string) (*LoginResult, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if err := u.limitAccount(email, ip); err != nil {
+		return nil, err
+	}
+
 	user, err := u.userRepo.FindByEmail(email)
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return nil, err
+	}
 	if err != nil {
-		return "", nil, errors.New("invalid credentials")
+		if err := u.recordAttempt(&entity.LoginAttempt{Email: email, IP: ip, Reason: LoginUnknownEmail}); err != nil {
+			return nil, err
+		}
+		return nil, entity.Unauthorized("invalid_credentials", "invalid credentials")
+	}
+	if err := u.checkLocked(user, ip); err != nil {
+		return nil, err
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return "", nil, errors.New("invalid credentials")
+		if err := u.loginFailed(user, ip, LoginWrongPassword); err != nil {
+			return nil, err
+		}
+		return nil, entity.Unauthorized("invalid_credentials", "invalid credentials")
+	}
+	if user.Status != "active" {
+		return nil, entity.Unauthorized("account_deactivated", "account is deactivated")
+	}
+
+	required, err := u.twoFactorRequired(user)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled || required {
+		challenge, err := u.newChallenge(user)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResult{User: user, Challenge: challenge}, nil
+	}
+
+	tokens, err := u.startSession(user)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{User: user, Tokens: tokens}, nil
+}
+
+// startSession completes a login. The failure count starts over only here,
+// so passing the password step alone does not reset two-factor attempts.
+func (u *authUsecase) startSession(user *entity.User) (*AuthTokens, error) {
+	if user.FailedLogins > 0 || user.LockedUntil != nil {
+		user.FailedLogins = 0
+		user.LockedUntil = nil
+		user.UpdatedAt = time.Now()
+		if err := u.userRepo.Update(user); err != nil {
+			return nil, err
+		}
+	}
+
+	session := &entity.Session{
+		UserID:    user.ID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := u.sessionRepo.Create(session); err != nil {
+		return nil, err
+	}
+	return u.issueTokens(user, session)
+}
+
+func (u *authUsecase) Refresh(refreshToken string) (*AuthTokens, error) {
+	token, err := u.sessionRepo.FindRefreshToken(hashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	session, err := u.sessionRepo.FindByID(token.SessionID)
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil || session.RevokedAt != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// A second exchange of the same token means two parties hold it
+	now := time.Now()
+	fresh, err := u.sessionRepo.UseRefreshToken(token.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		if err := u.sessionRepo.Revoke(session.ID, now, RevokedReuse); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+	if !now.Before(token.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := u.userRepo.FindByID(session.UserID)
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil || user.Status != "active" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// Sessions started before the organization required two-factor
+	// authentication end at the next refresh
+	required, err := u.twoFactorRequired(user)
+	if err != nil {
+		return nil, err
+	}
+	if required && !user.TwoFactorEnabled {
+		return nil, ErrTwoFactorRequired
+	}
+	return u.issueTokens(user, session)
+}
+
+func (u *authUsecase) Logout(sessionID uint) error {
+	return u.sessionRepo.Revoke(sessionID, time.Now(), RevokedLogout)
+}
+
+func (u *authUsecase) LogoutAll(userID uint) error {
+	return u.sessionRepo.RevokeByUserID(userID, time.Now(), RevokedLogoutAll)
+}
+
+func (u *authUsecase) ChangePassword(userID, sessionID uint, oldPassword, newPassword string) error {
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(oldPassword)); err != nil {
+		return ErrWrongPassword
+	}
+
+	hashedPassword, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	return u.uow.Do(func(repos *repository.Repositories) error {
+		now := time.Now()
+		user.PasswordHash = hashedPassword
+		user.UpdatedAt = now
+		if err := repos.Users.Update(user); err != nil {
+			return err
+		}
+		return repos.Sessions.RevokeOthers(user.ID, sessionID, now, RevokedChange)
+	})
+}
+
+func (u *authUsecase) ForgotPassword(email string) error {
+	user, err := u.userRepo.FindByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return err
+	}
+	if err != nil || user.Status != "active" {
+		return nil
+	}
+
+	token, hash, err := newToken()
+	if err != nil {
+		return err
+	}
+	err = u.uow.Do(func(repos *repository.Repositories) error {
+		return repos.PasswordResets.Create(&entity.PasswordReset{
+			UserID:    user.ID,
+			TokenHash: hash,
+			ExpiresAt: time.Now().Add(u.opts.PasswordResetTTL),
+			CreatedAt: time.Now(),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	link := token
+	if u.opts.PasswordResetURL != "" {
+		link = u.opts.PasswordResetURL + "?token=" + url.QueryEscape(token)
+	}
+	return u.opts.Mailer.Send(Mail{
+		To:      user.Email,
+		Subject: "Reset your EZKost password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to choose a new password. It can be used once and expires in %s.\n\n%s\n\nIf you did not ask for this, you can ignore this email.\n",
+			user.Name, u.opts.PasswordResetTTL, link,
+		),
+	})
+}
+
+func (u *authUsecase) ResetPassword(token, newPassword string) error {
+	hashedPassword, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	return u.uow.Do(func(repos *repository.Repositories) error {
+		reset, err := repos.PasswordResets.FindByTokenHash(hashToken(token))
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if reset == nil || reset.UsedAt != nil || !now.Before(reset.ExpiresAt) {
+			return entity.Invalid("invalid_reset_token", "reset token is invalid or has expired")
+		}
+		fresh, err := repos.PasswordResets.Use(reset.ID, now)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return entity.Invalid("invalid_reset_token", "reset token is invalid or has expired")
+		}
+
+		user, err := repos.Users.FindByID(reset.UserID)
+		if err != nil {
+			return err
+		}
+		user.PasswordHash = hashedPassword
+		user.UpdatedAt = now
+		if err := repos.Users.Update(user); err != nil {
+			return err
+		}
+		return repos.Sessions.RevokeByUserID(user.ID, now, RevokedReset)
+	})
+}
+
+// limitAccount takes a token from the account's bucket.
+func (u *authUsecase) limitAccount(email, ip string) error {
+	ok, retryAfter, err := u.opts.AccountLimiter.Allow("account:" + email)
+	if err != nil || ok {
+		return err
+	}
+	if err := u.recordAttempt(&entity.LoginAttempt{Email: email, IP: ip, Reason: LoginRateLimited}); err != nil {
+		return err
+	}
+	return &RetryError{Reason: "too many login attempts", RetryAfter: retryAfter}
+}
+
+func (u *authUsecase) checkLocked(user *entity.User, ip string) error {
+	now := time.Now()
+	if user.LockedUntil == nil || !now.Before(*user.LockedUntil) {
+		return nil
+	}
+	err := u.recordAttempt(&entity.LoginAttempt{UserID: user.ID, Email: user.Email, IP: ip, Reason: LoginLocked})
+	if err != nil {
+		return err
+	}
+	return &RetryError{Reason: "account is locked after too many failed logins", RetryAfter: user.LockedUntil.Sub(now)}
+}
+
+// loginFailed records a failure and locks the account once there were too
+// many in a row. The lock is returned as a *RetryError.
+func (u *authUsecase) loginFailed(user *entity.User, ip, reason string) error {
+	now := time.Now()
+	user.FailedLogins++
+	user.UpdatedAt = now
+
+	var lockout time.Duration
+	if threshold := u.opts.LockoutThreshold; threshold > 0 && user.FailedLogins >= threshold {
+		lockout = u.opts.LockoutDuration
+		for i := threshold; i < user.FailedLogins && lockout < u.opts.LockoutMaxDuration; i++ {
+			lockout *= 2
+		}
+		lockout = min(lockout, u.opts.LockoutMaxDuration)
+		lockedUntil := now.Add(lockout)
+		user.LockedUntil = &lockedUntil
+	}
+
+	err := u.uow.Do(func(repos *repository.Repositories) error {
+		if err := repos.Users.Update(user); err != nil {
+			return err
+		}
+		return repos.LoginAttempts.Create(&entity.LoginAttempt{
+			UserID:    user.ID,
+			Email:     user.Email,
+			IP:        ip,
+			Reason:    reason,
+			CreatedAt: now,
+		})
+	})
+	if err != nil {
+		return err
+	}
+	if lockout > 0 {
+		return &RetryError{Reason: "account is locked after too many failed logins", RetryAfter: lockout}
+	}
+	return nil
+}
+
+func (u *authUsecase) recordAttempt(attempt *entity.LoginAttempt) error {
+	attempt.CreatedAt = time.Now()
+	return u.uow.Do(func(repos *repository.Repositories) error {
+		return repos.LoginAttempts.Create(attempt)
+	})
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < 6 {
+		return "", entity.Invalid("password_too_short", "password must be at least 6 characters")
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// issueTokens signs an access token for the session and adds the next
+// refresh token to it.
+func (u *authUsecase) issueTokens(user *entity.User, session *entity.Session) (*AuthTokens, error) {
+	refreshToken, hash, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	err = u.sessionRepo.AddRefreshToken(&entity.RefreshToken{
+		SessionID: session.ID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(u.opts.RefreshTokenTTL),
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Generate JWT token
+	expiresAt := time.Now().Add(u.opts.AccessTokenTTL)
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.ID,
-		"role":    user.Role,
-		"exp":     time.Now().Add(time.Hour * 24 * 7).Unix(),
+		"user_id":         user.ID,
+		"organization_id": user.OrganizationID,
+		"session_id":      session.ID,
+		"role":            user.Role,
+		"exp":             expiresAt.Unix(),
 	})
 
-	tokenString, err := token.SignedString([]byte(u.jwtSecret))
+	accessToken, err := token.SignedString([]byte(u.opts.JWTSecret))
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 
-	return tokenString, user, nil
+	return &AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
 }
 
-func (u *authUsecase) ValidateToken(tokenString string) (uint, string, error) {
+// ValidateToken parses an access token issued by Login or Refresh. Tokens
+// without an organization or a session predate those and are rejected so
+// their holders log in again. The user and session are looked up so that
+// deactivation, role changes and logouts take effect immediately rather than
+// when the token expires.
+func (u *authUsecase) ValidateToken(tokenString string) (*TokenClaims, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte(u.jwtSecret), nil
-	})
+		return []byte(u.opts.JWTSecret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil || !token.Valid {
-		return 0, "", errors.New("invalid token")
+		return nil, entity.Unauthorized("invalid_token", "invalid token")
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
-		return 0, "", errors.New("invalid token claims")
+		return nil, entity.Unauthorized("invalid_token", "invalid token claims")
+	}
+
+	userID, okUser := claims["user_id"].(float64)
+	organizationID, okOrganization := claims["organization_id"].(float64)
+	sessionID, okSession := claims["session_id"].(float64)
+	_, isChallenge := claims["purpose"]
+	if !okUser || !okOrganization || !okSession || organizationID == 0 || isChallenge {
+		return nil, entity.Unauthorized("invalid_token", "invalid token claims")
 	}
 
-	userID := uint(claims["user_id"].(float64))
-	role := claims["role"].(string)
+	session, err := u.sessionRepo.FindByID(uint(sessionID))
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil || session.UserID != uint(userID) || session.RevokedAt != nil {
+		return nil, entity.Unauthorized("invalid_token", "invalid token")
+	}
+
+	user, err := u.userRepo.FindByID(uint(userID))
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return nil, err
+	}
+	if err != nil || user.OrganizationID != uint(organizationID) || user.Status != "active" {
+		return nil, entity.Unauthorized("invalid_token", "invalid token")
+	}
 
-	return userID, role, nil
+	return &TokenClaims{
+		UserID:         user.ID,
+		OrganizationID: user.OrganizationID,
+		SessionID:      session.ID,
+		Role:           user.Role,
+	}, nil
 }
@@ -0,0 +1,261 @@
+package usecase_test
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/memory"
+	"ezkost/internal/usecase"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type sessions struct {
+	rows   []entity.Session
+	tokens []entity.RefreshToken
+}
+
+var _ repository.SessionRepository = (*sessions)(nil)
+
+func (s *sessions) Create(session *entity.Session) error {
+	session.ID = uint(len(s.rows) + 1)
+	s.rows = append(s.rows, *session)
+	return nil
+}
+
+func (s *sessions) FindByID(id uint) (*entity.Session, error) {
+	for _, session := range s.rows {
+		if session.ID == id {
+			return &session, nil
+		}
+	}
+	return nil, entity.NotFound("not_found", "session not found")
+}
+
+func (s *sessions) Revoke(id uint, at time.Time, reason string) error {
+	return s.revoke(func(session entity.Session) bool { return session.ID == id }, at, reason)
+}
+
+func (s *sessions) RevokeByUserID(userID uint, at time.Time, reason string) error {
+	return s.revoke(func(session entity.Session) bool { return session.UserID == userID }, at, reason)
+}
+
+func (s *sessions) RevokeOthers(userID, keepID uint, at time.Time, reason string) error {
+	return s.revoke(func(session entity.Session) bool {
+		return session.UserID == userID && session.ID != keepID
+	}, at, reason)
+}
+
+func (s *sessions) revoke(match func(entity.Session) bool, at time.Time, reason string) error {
+	for i := range s.rows {
+		if match(s.rows[i]) && s.rows[i].RevokedAt == nil {
+			s.rows[i].RevokedAt = &at
+			s.rows[i].RevokedReason = reason
+		}
+	}
+	return nil
+}
+
+func (s *sessions) AddRefreshToken(token *entity.RefreshToken) error {
+	token.ID = uint(len(s.tokens) + 1)
+	s.tokens = append(s.tokens, *token)
+	return nil
+}
+
+func (s *sessions) FindRefreshToken(hash string) (*entity.RefreshToken, error) {
+	for _, token := range s.tokens {
+		if token.TokenHash == hash {
+			return &token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *sessions) UseRefreshToken(id uint, at time.Time) (bool, error) {
+	for i := range s.tokens {
+		if s.tokens[i].ID == id {
+			if s.tokens[i].UsedAt != nil {
+				return false, nil
+			}
+			s.tokens[i].UsedAt = &at
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type allowAll struct{}
+
+func (allowAll) Allow(key string) (bool, time.Duration, error) { return true, 0, nil }
+
+// signIn sets up an auth usecase for Sari, an active owner with the password
+// "rahasia123", and logs her in.
+func signIn(t *testing.T, f *fixture, opts usecase.AuthOptions) (usecase.AuthUsecase, *sessions, *usecase.AuthTokens) {
+	t.Helper()
+	logins := &sessions{}
+	f.repos.Sessions = logins
+	f.repos.RecoveryCodes = &recoveryCodes{}
+	f.repos.LoginAttempts = &loginAttempts{}
+	orgs := &organizations{}
+	must(t, orgs.Create(&entity.Organization{Name: "Kos Melati"}))
+	opts.JWTSecret = "secret"
+	opts.AccessTokenTTL = 15 * time.Minute
+	opts.AccountLimiter = allowAll{}
+	auth := usecase.NewAuthUsecase(memory.NewUnitOfWork(f.repos), f.repos.Users, orgs, logins, opts)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("rahasia123"), bcrypt.MinCost)
+	must(t, err)
+	must(t, f.repos.Users.Create(&entity.User{OrganizationID: 1, Name: "Sari", Email: "sari@example.com",
+		PasswordHash: string(hash), Role: "owner", Status: "active"}))
+	result, err := auth.Login("sari@example.com", "rahasia123", "10.0.0.1")
+	must(t, err)
+	return auth, logins, result.Tokens
+}
+
+func TestAuthChangePassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldPassword string
+		wantErr     error
+		wantRevoked []bool
+	}{
+		{"right password", "rahasia123", nil, []bool{false, true, false}},
+		{"wrong password", "tebakan", usecase.ErrWrongPassword, []bool{false, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			logins := &sessions{}
+			f.repos.Sessions = logins
+			auth := usecase.NewAuthUsecase(memory.NewUnitOfWork(f.repos), f.repos.Users, nil, logins, usecase.AuthOptions{})
+
+			hash, err := bcrypt.GenerateFromPassword([]byte("rahasia123"), bcrypt.MinCost)
+			must(t, err)
+			sari := &entity.User{Name: "Sari", Email: "sari@example.com", PasswordHash: string(hash), Status: "active"}
+			budi := &entity.User{Name: "Budi", Email: "budi@example.com", PasswordHash: string(hash), Status: "active"}
+			must(t, f.repos.Users.Create(sari))
+			must(t, f.repos.Users.Create(budi))
+			current := &entity.Session{UserID: sari.ID}
+			for _, session := range []*entity.Session{current, {UserID: sari.ID}, {UserID: budi.ID}} {
+				must(t, logins.Create(session))
+			}
+
+			err = auth.ChangePassword(sari.ID, current.ID, tt.oldPassword, "baru12345")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			for i, session := range logins.rows {
+				if revoked := session.RevokedAt != nil; revoked != tt.wantRevoked[i] {
+					t.Errorf("session %d revoked = %v, want %v", session.ID, revoked, tt.wantRevoked[i])
+				}
+				if session.RevokedAt != nil && session.RevokedReason != usecase.RevokedChange {
+					t.Errorf("session %d revoked for %q", session.ID, session.RevokedReason)
+				}
+			}
+			user, err := f.repos.Users.FindByID(sari.ID)
+			must(t, err)
+			changed := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("baru12345")) == nil
+			if changed != (tt.wantErr == nil) {
+				t.Errorf("password changed = %v", changed)
+			}
+		})
+	}
+}
+
+func TestAuthBootstrapChecksEmail(t *testing.T) {
+	dbDown := errors.New("connection refused")
+	tests := []struct {
+		name    string
+		lookup  error
+		email   string
+		wantErr func(t *testing.T, err error)
+	}{
+		{"registered email", nil, " SARI@example.com", func(t *testing.T, err error) {
+			wantKind(t, err, entity.ErrConflict, "email_taken")
+		}},
+		{"lookup fails", dbDown, "budi@example.com", func(t *testing.T, err error) {
+			if !errors.Is(err, dbDown) {
+				t.Fatalf("got error %v, want %v", err, dbDown)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			must(t, f.repos.Users.Create(&entity.User{Name: "Sari", Email: "sari@example.com", Role: "owner"}))
+			var users repository.UserRepository = f.repos.Users
+			if tt.lookup != nil {
+				users = failingUsers{users, tt.lookup}
+			}
+			auth := usecase.NewAuthUsecase(memory.NewUnitOfWork(f.repos), users, nil, &sessions{}, usecase.AuthOptions{})
+
+			err := auth.Bootstrap(&entity.Organization{Name: "Kos Group"}, &entity.User{Name: "Budi", Email: tt.email}, "rahasia123")
+			tt.wantErr(t, err)
+			if all, _ := f.repos.Users.FindAll(); len(all) != 1 {
+				t.Errorf("users = %+v, want only Sari", all)
+			}
+		})
+	}
+}
+
+func TestAuthRefresh(t *testing.T) {
+	tests := []struct {
+		name string
+		ttl  time.Duration
+		// reuse exchanges the same refresh token twice
+		reuse       bool
+		token       string
+		wantErr     error
+		wantRevoked string
+	}{
+		{"fresh token", time.Hour, false, "", nil, ""},
+		{"reused token", time.Hour, true, "", usecase.ErrRefreshTokenReused, usecase.RevokedReuse},
+		{"expired token", -time.Minute, false, "", usecase.ErrInvalidRefreshToken, ""},
+		{"unknown token", time.Hour, false, "tebakan", usecase.ErrInvalidRefreshToken, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			auth, logins, tokens := signIn(t, f, usecase.AuthOptions{RefreshTokenTTL: tt.ttl})
+			refreshToken := tokens.RefreshToken
+			if tt.token != "" {
+				refreshToken = tt.token
+			}
+
+			var err error
+			var refreshed *usecase.AuthTokens
+			if tt.reuse {
+				refreshed, err = auth.Refresh(refreshToken)
+				must(t, err)
+			}
+			got, err := auth.Refresh(refreshToken)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			session := logins.rows[0]
+			if session.RevokedReason != tt.wantRevoked || (session.RevokedAt != nil) != (tt.wantRevoked != "") {
+				t.Errorf("session revoked at %v for %q, want %q", session.RevokedAt, session.RevokedReason, tt.wantRevoked)
+			}
+			if tt.wantErr == nil {
+				claims, err := auth.ValidateToken(got.AccessToken)
+				must(t, err)
+				if claims.SessionID != session.ID {
+					t.Errorf("refreshed into session %d, want %d", claims.SessionID, session.ID)
+				}
+				return
+			}
+			if tt.reuse {
+				// Whoever holds the token handed out by the first exchange
+				// is signed out as well
+				if _, err := auth.Refresh(refreshed.RefreshToken); !errors.Is(err, usecase.ErrInvalidRefreshToken) {
+					t.Errorf("refreshing the newer token: got error %v, want %v", err, usecase.ErrInvalidRefreshToken)
+				}
+				if _, err := auth.ValidateToken(refreshed.AccessToken); err == nil {
+					t.Error("the newer access token is still accepted")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"time"
+)
+
+// Billing Usecase
+type BillingResult struct {
+	Period  string           `json:"period"`
+	Created []entity.Payment `json:"created"`
+	Skipped int              `json:"skipped"`
+}
+
+type BillingUsecase interface {
+	RunMonthly(period time.Time) (*BillingResult, error)
+	PreviewProration(roomID uint, start time.Time, end *time.Time) (*ProrationPreview, error)
+}
+
+type billingUsecase struct {
+	uow         repository.UnitOfWork
+	paymentRepo repository.PaymentRepository
+	tenantRepo  repository.TenantRepository
+	roomRepo    repository.RoomRepository
+	leaseRepo   repository.LeaseRepository
+	proration   ProrationPolicy
+	ledger      *paymentLedger
+	biller      *utilityBiller
+}
+
+func NewBillingUsecase(
+	uow repository.UnitOfWork,
+	paymentRepo repository.PaymentRepository,
+	tenantRepo repository.TenantRepository,
+	roomRepo repository.RoomRepository,
+	readingRepo repository.MeterReadingRepository,
+	leaseRepo repository.LeaseRepository,
+	proration ProrationPolicy,
+) BillingUsecase {
+	return &billingUsecase{
+		uow:         uow,
+		paymentRepo: paymentRepo,
+		tenantRepo:  tenantRepo,
+		roomRepo:    roomRepo,
+		leaseRepo:   leaseRepo,
+		proration:   proration,
+		ledger:      newPaymentLedger(paymentRepo, tenantRepo),
+		biller:      newUtilityBiller(readingRepo, paymentRepo, tenantRepo),
+	}
+}
+
+// RunMonthly creates one unpaid rent payment per active tenant for the month
+// containing period, with that month's utility charges as line items. Tenants
+// already billed for that month are skipped, so the run can be repeated safely.
+// Rent comes from the tenant's lease; quarterly and yearly leases are billed
+// for the whole cycle in its first month and skipped in the others.
+func (u *billingUsecase) RunMonthly(period time.Time) (*BillingResult, error) {
+	key := period.Format("2006-01")
+	result := &BillingResult{Period: key, Created: []entity.Payment{}}
+
+	tenants, err := u.tenantRepo.FindByStatus("active")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tenant := range tenants {
+		if tenant.RoomID == nil || tenant.Room == nil {
+			result.Skipped++
+			continue
+		}
+
+		dueDate := anniversaryDate(tenant.StartDate, period)
+		if dueDate.Before(truncateDay(tenant.StartDate)) {
+			result.Skipped++
+			continue
+		}
+		if tenant.EndDate != nil && tenant.EndDate.Before(firstOfMonth(period)) {
+			result.Skipped++
+			continue
+		}
+
+		exists, err := u.paymentRepo.ExistsForPeriod(tenant.ID, key)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			result.Skipped++
+			continue
+		}
+
+		rent, lease, err := monthlyRent(u.leaseRepo, &tenant, dueDate)
+		if err != nil {
+			return nil, err
+		}
+		months := billedMonths(lease, period)
+		if months == 0 {
+			result.Skipped++
+			continue
+		}
+
+		payment := &entity.Payment{
+			PropertyID: tenant.PropertyID,
+			TenantID:   tenant.ID,
+			Amount:     rent.Mul(int64(months)),
+			DueDate:    dueDate,
+			Status:     "unpaid",
+			Period:     key,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		}
+		err = u.uow.Do(func(repos *repository.Repositories) error {
+			return u.withRepos(repos).issue(payment, *tenant.RoomID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.Created = append(result.Created, *payment)
+	}
+
+	return result, nil
+}
+
+// withRepos returns a copy of the usecase working on repos of a unit of work.
+func (u *billingUsecase) withRepos(repos *repository.Repositories) *billingUsecase {
+	return &billingUsecase{
+		uow:         u.uow,
+		paymentRepo: repos.Payments,
+		tenantRepo:  repos.Tenants,
+		roomRepo:    repos.Rooms,
+		leaseRepo:   repos.Leases,
+		proration:   u.proration,
+		ledger:      newPaymentLedger(repos.Payments, repos.Tenants),
+		biller:      newUtilityBiller(repos.MeterReadings, repos.Payments, repos.Tenants),
+	}
+}
+
+// issue stores a new bill with the room's utility charges and pays what it
+// can from the tenant's credit.
+func (u *billingUsecase) issue(payment *entity.Payment, roomID uint) error {
+	if err := u.paymentRepo.Create(payment); err != nil {
+		return err
+	}
+	if err := u.biller.Attach(payment, roomID); err != nil {
+		return err
+	}
+	return u.ledger.ApplyCredit(payment)
+}
+
+// PreviewProration shows what a tenant moving into roomID on start (and out on
+// end, when given) would be charged, without storing anything.
+func (u *billingUsecase) PreviewProration(roomID uint, start time.Time, end *time.Time) (*ProrationPreview, error) {
+	if end != nil && end.Before(start) {
+		return nil, entity.Invalid("invalid_date_range", "end_date must not be before start_date")
+	}
+
+	room, err := u.roomRepo.FindByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	moveIn := u.proration.MoveIn(room.Price, start)
+	preview := &ProrationPreview{
+		RoomID: room.ID,
+		Price:  room.Price,
+		Basis:  u.proration.Basis,
+		MoveIn: &moveIn,
+	}
+
+	if end != nil {
+		var moveOut ProratedCharge
+		if firstOfMonth(start).Equal(firstOfMonth(*end)) {
+			moveOut = u.proration.Between(room.Price, start, *end)
+			preview.MoveIn = nil
+		} else {
+			moveOut = u.proration.MoveOut(room.Price, *end)
+		}
+		preview.MoveOut = &moveOut
+	}
+
+	return preview, nil
+}
+
+// billedMonths is how many months of rent the bill for period covers. The
+// lease's first month is billed on its own; cycles run from the month after.
+func billedMonths(lease *entity.Lease, period time.Time) int {
+	cycle := 1
+	if lease != nil {
+		cycle = lease.CycleMonths()
+	}
+	if cycle == 1 {
+		return 1
+	}
+
+	first := firstOfMonth(lease.StartDate)
+	month := firstOfMonth(period)
+	if month.Equal(first) {
+		return 1
+	}
+	offset := (month.Year()-first.Year())*12 + int(month.Month()-first.Month()) - 1
+	if offset%cycle != 0 {
+		return 0
+	}
+	return cycle
+}
+
+// ParseBillingPeriod parses a "YYYY-MM" period, defaulting to the current month.
+func ParseBillingPeriod(value string) (time.Time, error) {
+	if value == "" {
+		return firstOfMonth(time.Now()), nil
+	}
+	period, err := time.ParseInLocation("2006-01", value, time.Local)
+	if err != nil {
+		return time.Time{}, entity.Invalid("invalid_period", "period must be in YYYY-MM format")
+	}
+	return period, nil
+}
+
+// anniversaryDate returns the day of month of start within the month of
+// period, clamped to the last day when the month is shorter.
+func anniversaryDate(start, period time.Time) time.Time {
+	first := firstOfMonth(period)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := start.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, first.Location())
+}
+
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,195 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"testing"
+	"time"
+)
+
+func TestBillingRunMonthly(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(t *testing.T, f *fixture, tenant *entity.Tenant, room *entity.Room)
+		period time.Time
+		// wantAmount is the rent billed, empty when the tenant is skipped
+		wantAmount string
+		wantDue    time.Time
+		wantTotal  string
+		wantStatus string
+	}{
+		{
+			name:       "room price",
+			period:     date(2026, 2, 1),
+			wantAmount: "3100000.00",
+			wantDue:    date(2026, 2, 15),
+			wantTotal:  "3100000.00",
+			wantStatus: "unpaid",
+		},
+		{
+			name: "lease rent",
+			setup: func(t *testing.T, f *fixture, tenant *entity.Tenant, room *entity.Room) {
+				must(t, f.leases.Create(&entity.Lease{TenantID: tenant.ID, RoomID: room.ID, StartDate: date(2026, 1, 15), EndDate: date(2027, 1, 14), Rent: money(t, "2500000"), Status: "active"}))
+			},
+			period:     date(2026, 2, 1),
+			wantAmount: "2500000.00",
+			wantDue:    date(2026, 2, 15),
+			wantTotal:  "2500000.00",
+			wantStatus: "unpaid",
+		},
+		{
+			name: "quarterly lease bills the cycle up front",
+			setup: func(t *testing.T, f *fixture, tenant *entity.Tenant, room *entity.Room) {
+				must(t, f.leases.Create(&entity.Lease{TenantID: tenant.ID, RoomID: room.ID, StartDate: date(2026, 1, 15), EndDate: date(2027, 1, 14), Rent: money(t, "2500000"), BillingCycle: "quarterly", Status: "active"}))
+			},
+			period:     date(2026, 2, 1),
+			wantAmount: "7500000.00",
+			wantDue:    date(2026, 2, 15),
+			wantTotal:  "7500000.00",
+			wantStatus: "unpaid",
+		},
+		{
+			name: "quarterly lease skips the rest of the cycle",
+			setup: func(t *testing.T, f *fixture, tenant *entity.Tenant, room *entity.Room) {
+				must(t, f.leases.Create(&entity.Lease{TenantID: tenant.ID, RoomID: room.ID, StartDate: date(2026, 1, 15), EndDate: date(2027, 1, 14), Rent: money(t, "2500000"), BillingCycle: "quarterly", Status: "active"}))
+			},
+			period: date(2026, 3, 1),
+		},
+		{
+			name: "utility charges",
+			setup: func(t *testing.T, f *fixture, tenant *entity.Tenant, room *entity.Room) {
+				must(t, f.readings.Create(&entity.MeterReading{RoomID: room.ID, Utility: "electricity", Period: "2026-02", Consumption: 42, Charge: money(t, "61950.50")}))
+				must(t, f.readings.Create(&entity.MeterReading{RoomID: room.ID, Utility: "water", Period: "2026-01", Consumption: 3, Charge: money(t, "15000")}))
+			},
+			period:     date(2026, 2, 1),
+			wantAmount: "3100000.00",
+			wantDue:    date(2026, 2, 15),
+			wantTotal:  "3161950.50",
+			wantStatus: "unpaid",
+		},
+		{
+			name: "credit pays part of the bill",
+			setup: func(t *testing.T, f *fixture, tenant *entity.Tenant, room *entity.Room) {
+				must(t, f.repos.Tenants.AdjustCredit(tenant.ID, money(t, "1000000")))
+			},
+			period:     date(2026, 2, 1),
+			wantAmount: "3100000.00",
+			wantDue:    date(2026, 2, 15),
+			wantTotal:  "3100000.00",
+			wantStatus: "partial",
+		},
+		{
+			name: "moved out before the month",
+			setup: func(t *testing.T, f *fixture, tenant *entity.Tenant, room *entity.Room) {
+				end := date(2026, 1, 31)
+				tenant.EndDate = &end
+				must(t, f.repos.Tenants.Update(tenant))
+			},
+			period: date(2026, 2, 1),
+		},
+		{
+			name:   "before the move-in",
+			period: date(2025, 12, 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			room := f.room(t, "101", "3100000")
+			tenant := f.tenant(t, "Budi", room, date(2026, 1, 15))
+			if tt.setup != nil {
+				tt.setup(t, f, tenant, room)
+			}
+
+			result, err := f.usecases.Billing.RunMonthly(tt.period)
+			must(t, err)
+			if result.Period != tt.period.Format("2006-01") {
+				t.Errorf("period = %q", result.Period)
+			}
+			if tt.wantAmount == "" {
+				if len(result.Created) != 0 || result.Skipped != 1 {
+					t.Fatalf("created %d and skipped %d, want the tenant skipped", len(result.Created), result.Skipped)
+				}
+				return
+			}
+			if len(result.Created) != 1 || result.Skipped != 0 {
+				t.Fatalf("created %d and skipped %d, want one bill", len(result.Created), result.Skipped)
+			}
+
+			payment := f.payment(t, tenant.ID, result.Period)
+			wantMoney(t, "amount", payment.Amount, tt.wantAmount)
+			wantMoney(t, "total due", payment.TotalDue(), tt.wantTotal)
+			if !payment.DueDate.Equal(tt.wantDue) || payment.Status != tt.wantStatus || payment.PropertyID != 1 {
+				t.Errorf("bill = due %v, status %q, property %d, want due %v, status %q, property 1",
+					payment.DueDate, payment.Status, payment.PropertyID, tt.wantDue, tt.wantStatus)
+			}
+			for _, reading := range f.readings.rows {
+				billed := reading.PaymentID != nil && *reading.PaymentID == payment.ID
+				if billed != (reading.Period == result.Period) {
+					t.Errorf("%s reading of %s billed = %v", reading.Utility, reading.Period, billed)
+				}
+			}
+
+			// Running the month again changes nothing
+			again, err := f.usecases.Billing.RunMonthly(tt.period)
+			must(t, err)
+			if len(again.Created) != 0 || again.Skipped != 1 {
+				t.Errorf("second run created %d and skipped %d, want the tenant skipped", len(again.Created), again.Skipped)
+			}
+			rebilled := f.payment(t, tenant.ID, result.Period)
+			if rebilled.ID != payment.ID || len(rebilled.LineItems) != len(payment.LineItems) {
+				t.Errorf("second run changed the bill: %+v", rebilled)
+			}
+		})
+	}
+}
+
+func TestBillingSkipsTenantsWithoutRoom(t *testing.T) {
+	f := newFixture(t)
+	must(t, f.repos.Tenants.Create(&entity.Tenant{PropertyID: 1, Name: "Ani", Phone: "0812", StartDate: date(2026, 1, 1)}))
+
+	result, err := f.usecases.Billing.RunMonthly(date(2026, 2, 1))
+	must(t, err)
+	if len(result.Created) != 0 || result.Skipped != 1 {
+		t.Errorf("created %d and skipped %d, want the tenant skipped", len(result.Created), result.Skipped)
+	}
+}
+
+func TestBillingPreviewProration(t *testing.T) {
+	f := newFixture(t)
+	room := f.room(t, "101", "3100000")
+	moveOut := date(2026, 3, 10)
+	sameMonth := date(2026, 1, 20)
+	before := date(2026, 1, 1)
+
+	tests := []struct {
+		name        string
+		start       time.Time
+		end         *time.Time
+		wantMoveIn  string
+		wantMoveOut string
+	}{
+		{"move in", date(2026, 1, 15), nil, "1700000.00", ""},
+		{"move in and out", date(2026, 1, 15), &moveOut, "1700000.00", "1000000.00"},
+		{"within one month", date(2026, 1, 11), &sameMonth, "", "1000000.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preview, err := f.usecases.Billing.PreviewProration(room.ID, tt.start, tt.end)
+			must(t, err)
+			if (preview.MoveIn != nil) != (tt.wantMoveIn != "") || (preview.MoveOut != nil) != (tt.wantMoveOut != "") {
+				t.Fatalf("preview = %+v", preview)
+			}
+			if preview.MoveIn != nil {
+				wantMoney(t, "move in", preview.MoveIn.Amount, tt.wantMoveIn)
+			}
+			if preview.MoveOut != nil {
+				wantMoney(t, "move out", preview.MoveOut.Amount, tt.wantMoveOut)
+			}
+		})
+	}
+
+	_, err := f.usecases.Billing.PreviewProration(room.ID, date(2026, 1, 15), &before)
+	wantKind(t, err, entity.ErrValidation, "invalid_date_range")
+	_, err = f.usecases.Billing.PreviewProration(99, date(2026, 1, 15), nil)
+	wantKind(t, err, entity.ErrNotFound, "not_found")
+}
@@ -1,59 +1,110 @@
 package usecase
 
 import (
+	"ezkost/internal/domain/entity"
 	"ezkost/internal/domain/repository"
 	"time"
 )
 
 // Dashboard Usecase
 type DashboardSummary struct {
-	TotalRooms     int64   `json:"total_rooms"`
-	OccupiedRooms  int64   `json:"occupied_rooms"`
-	EmptyRooms     int64   `json:"empty_rooms"`
-	MonthlyIncome  float64 `json:"monthly_income"`
-	MonthlyExpense float64 `json:"monthly_expense"`
-	Profit         float64 `json:"profit"`
-	OverdueTenants int64   `json:"overdue_tenants"`
-	ActiveTenants  int64   `json:"active_tenants"`
+	PropertyID     uint               `json:"property_id,omitempty"`
+	PropertyName   string             `json:"property_name,omitempty"`
+	TotalRooms     int64              `json:"total_rooms"`
+	OccupiedRooms  int64              `json:"occupied_rooms"`
+	EmptyRooms     int64              `json:"empty_rooms"`
+	MonthlyIncome  entity.Money       `json:"monthly_income"`
+	MonthlyExpense entity.Money       `json:"monthly_expense"`
+	Profit         entity.Money       `json:"profit"`
+	OverdueTenants int64              `json:"overdue_tenants"`
+	ActiveTenants  int64              `json:"active_tenants"`
+	MonthlyRent    entity.Money       `json:"monthly_rent"`
+	Properties     []DashboardSummary `json:"properties,omitempty"`
 }
 
 type DashboardUsecase interface {
-	GetSummary() (*DashboardSummary, error)
+	// GetSummary summarises one property, or the whole portfolio with a
+	// per-property breakdown when propertyID is 0.
+	GetSummary(propertyID uint) (*DashboardSummary, error)
 }
 
 type dashboardUsecase struct {
-	roomRepo    repository.RoomRepository
-	tenantRepo  repository.TenantRepository
-	paymentRepo repository.PaymentRepository
-	expenseRepo repository.ExpenseRepository
+	propertyRepo repository.PropertyRepository
+	roomRepo     repository.RoomRepository
+	tenantRepo   repository.TenantRepository
+	paymentRepo  repository.PaymentRepository
+	expenseRepo  repository.ExpenseRepository
+	leaseRepo    repository.LeaseRepository
 }
 
 func NewDashboardUsecase(
+	propertyRepo repository.PropertyRepository,
 	roomRepo repository.RoomRepository,
 	tenantRepo repository.TenantRepository,
 	paymentRepo repository.PaymentRepository,
 	expenseRepo repository.ExpenseRepository,
+	leaseRepo repository.LeaseRepository,
 ) DashboardUsecase {
 	return &dashboardUsecase{
-		roomRepo:    roomRepo,
-		tenantRepo:  tenantRepo,
-		paymentRepo: paymentRepo,
-		expenseRepo: expenseRepo,
+		propertyRepo: propertyRepo,
+		roomRepo:     roomRepo,
+		tenantRepo:   tenantRepo,
+		paymentRepo:  paymentRepo,
+		expenseRepo:  expenseRepo,
+		leaseRepo:    leaseRepo,
 	}
 }
 
-func (u *dashboardUsecase) GetSummary() (*DashboardSummary, error) {
+func (u *dashboardUsecase) GetSummary(propertyID uint) (*DashboardSummary, error) {
+	if propertyID != 0 {
+		property, err := u.propertyRepo.FindByID(propertyID)
+		if err != nil {
+			return nil, err
+		}
+		return u.summarise(property)
+	}
+
+	summary, err := u.summarise(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := u.propertyRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	summary.Properties = make([]DashboardSummary, 0, len(properties))
+	for i := range properties {
+		breakdown, err := u.summarise(&properties[i])
+		if err != nil {
+			return nil, err
+		}
+		summary.Properties = append(summary.Properties, *breakdown)
+	}
+	return summary, nil
+}
+
+// summarise computes the figures for one property, or every property when
+// property is nil.
+func (u *dashboardUsecase) summarise(property *entity.Property) (*DashboardSummary, error) {
 	summary := &DashboardSummary{}
 
+	var propertyID uint
+	if property != nil {
+		propertyID = property.ID
+		summary.PropertyID = property.ID
+		summary.PropertyName = property.Name
+	}
+
 	// Total rooms
-	total, err := u.roomRepo.Count()
+	total, err := u.roomRepo.Count(propertyID)
 	if err != nil {
 		return nil, err
 	}
 	summary.TotalRooms = total
 
 	// Occupied rooms
-	occupied, err := u.roomRepo.CountByStatus("occupied")
+	occupied, err := u.roomRepo.CountByStatus("occupied", propertyID)
 	if err != nil {
 		return nil, err
 	}
@@ -65,33 +116,49 @@ func (u *dashboardUsecase) GetSummary() (*DashboardSummary, error) {
 	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
 	endOfMonth := startOfMonth.AddDate(0, 1, 0)
 
-	income, err := u.paymentRepo.SumPaidByPeriod(startOfMonth, endOfMonth)
+	income, err := u.paymentRepo.SumPaidByPeriod(startOfMonth, endOfMonth, propertyID)
 	if err != nil {
 		return nil, err
 	}
 	summary.MonthlyIncome = income
 
 	// Monthly expense
-	expense, err := u.expenseRepo.SumByPeriod(startOfMonth, endOfMonth)
+	expense, err := u.expenseRepo.SumByPeriod(startOfMonth, endOfMonth, propertyID)
 	if err != nil {
 		return nil, err
 	}
 	summary.MonthlyExpense = expense
-	summary.Profit = income - expense
+	summary.Profit = income.Sub(expense)
 
 	// Overdue tenants
-	overdue, err := u.paymentRepo.CountOverdue(now)
+	overdue, err := u.paymentRepo.CountOverdue(now, propertyID)
 	if err != nil {
 		return nil, err
 	}
 	summary.OverdueTenants = overdue
 
 	// Active tenants
-	active, err := u.tenantRepo.CountByStatus("active")
+	active, err := u.tenantRepo.CountByStatus("active", propertyID)
 	if err != nil {
 		return nil, err
 	}
 	summary.ActiveTenants = active
 
+	// Monthly rent roll at the agreed lease rents
+	tenants, err := u.tenantRepo.FindByStatus("active")
+	if err != nil {
+		return nil, err
+	}
+	for _, tenant := range tenants {
+		if propertyID != 0 && tenant.PropertyID != propertyID {
+			continue
+		}
+		rent, _, err := monthlyRent(u.leaseRepo, &tenant, now)
+		if err != nil {
+			return nil, err
+		}
+		summary.MonthlyRent = summary.MonthlyRent.Add(rent)
+	}
+
 	return summary, nil
 }
@@ -0,0 +1,142 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/usecase"
+	"testing"
+	"time"
+)
+
+// dashboardFixture fills Kos Melati for the current month: Budi rents room
+// 101 since the first at an agreed 2,800,000 and has paid, Ani rents room 102
+// at 2,000,000 since two months ago and never paid, and room 103 is empty.
+// 500,000 was spent this month and 900,000 last month.
+func dashboardFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := newFixture(t)
+	now := time.Now()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	budi := f.tenant(t, "Budi", f.room(t, "101", "3100000"), thisMonth)
+	must(t, f.usecases.Lease.Create(&entity.Lease{TenantID: budi.ID, TermMonths: 12, Rent: money(t, "2800000")}))
+	_, err := f.usecases.Payment.RecordTransaction(f.payment(t, budi.ID, thisMonth.Format("2006-01")).ID, &entity.PaymentTransaction{
+		Amount: money(t, "2800000"),
+		Method: "transfer",
+	})
+	must(t, err)
+	f.tenant(t, "Ani", f.room(t, "102", "2000000"), thisMonth.AddDate(0, -2, 0))
+	f.room(t, "103", "1500000")
+
+	for _, expense := range []entity.Expense{
+		{PropertyID: 1, Description: "Electricity", Amount: money(t, "500000"), ExpenseDate: now},
+		{PropertyID: 1, Description: "Repairs", Amount: money(t, "900000"), ExpenseDate: thisMonth.AddDate(0, 0, -1)},
+	} {
+		must(t, f.usecases.Expense.Create(&expense))
+	}
+	return f
+}
+
+func TestDashboardSummary(t *testing.T) {
+	f := dashboardFixture(t)
+	summary, err := f.usecases.Dashboard.GetSummary(0)
+	must(t, err)
+
+	counts := []struct {
+		name      string
+		got, want int64
+	}{
+		{"total rooms", summary.TotalRooms, 3},
+		{"occupied rooms", summary.OccupiedRooms, 2},
+		{"empty rooms", summary.EmptyRooms, 1},
+		{"overdue bills", summary.OverdueTenants, 1},
+		{"active tenants", summary.ActiveTenants, 2},
+	}
+	for _, c := range counts {
+		if c.got != c.want {
+			t.Errorf("%s = %d, want %d", c.name, c.got, c.want)
+		}
+	}
+	amounts := []struct {
+		name string
+		got  entity.Money
+		want string
+	}{
+		{"income", summary.MonthlyIncome, "2800000.00"},
+		{"expense", summary.MonthlyExpense, "500000.00"},
+		{"profit", summary.Profit, "2300000.00"},
+		{"rent roll", summary.MonthlyRent, "4800000.00"},
+	}
+	for _, a := range amounts {
+		wantMoney(t, a.name, a.got, a.want)
+	}
+}
+
+func TestDashboardSummaryMissingProperty(t *testing.T) {
+	f := newFixture(t)
+	_, err := f.usecases.Dashboard.GetSummary(99)
+	wantKind(t, err, entity.ErrNotFound, "not_found")
+}
+
+func TestDashboardSummaryByProperty(t *testing.T) {
+	// Kos Mawar adds Dewi, who rents room 201 at 1,200,000 since the first
+	// and has paid, and 300,000 spent this month
+	f := dashboardFixture(t)
+	now := time.Now()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	room := &entity.Room{PropertyID: 2, RoomNumber: "201", Price: money(t, "1200000"), Status: "empty"}
+	must(t, f.repos.Rooms.Create(room))
+	dewi := f.tenant(t, "Dewi", room, thisMonth)
+	_, err := f.usecases.Payment.RecordTransaction(f.payment(t, dewi.ID, thisMonth.Format("2006-01")).ID, &entity.PaymentTransaction{
+		Amount: money(t, "1200000"),
+		Method: "cash",
+	})
+	must(t, err)
+	must(t, f.usecases.Expense.Create(&entity.Expense{PropertyID: 2, Description: "Water", Amount: money(t, "300000"), ExpenseDate: now}))
+
+	type figures struct {
+		rooms, occupied, empty, overdue, active int64
+		income, expense, profit, rent           string
+	}
+	tests := []struct {
+		name       string
+		propertyID uint
+		wantName   string
+		want       figures
+	}{
+		{"Kos Melati", 1, "Kos Melati", figures{3, 2, 1, 1, 2, "2800000.00", "500000.00", "2300000.00", "4800000.00"}},
+		{"Kos Mawar", 2, "Kos Mawar", figures{1, 1, 0, 0, 1, "1200000.00", "300000.00", "900000.00", "1200000.00"}},
+		{"portfolio", 0, "", figures{4, 3, 1, 1, 3, "4000000.00", "800000.00", "3200000.00", "6000000.00"}},
+	}
+	check := func(t *testing.T, summary *usecase.DashboardSummary, propertyID uint, name string, want figures) {
+		t.Helper()
+		got := figures{
+			summary.TotalRooms, summary.OccupiedRooms, summary.EmptyRooms, summary.OverdueTenants, summary.ActiveTenants,
+			summary.MonthlyIncome.String(), summary.MonthlyExpense.String(), summary.Profit.String(), summary.MonthlyRent.String(),
+		}
+		if summary.PropertyID != propertyID || summary.PropertyName != name || got != want {
+			t.Errorf("summary of %q = %+v, want %+v", summary.PropertyName, got, want)
+		}
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, err := f.usecases.Dashboard.GetSummary(tt.propertyID)
+			must(t, err)
+			check(t, summary, tt.propertyID, tt.wantName, tt.want)
+
+			// Only the portfolio breaks down by property, in the order of the
+			// property list
+			if tt.propertyID != 0 {
+				if len(summary.Properties) != 0 {
+					t.Errorf("breakdown = %+v, want none for one property", summary.Properties)
+				}
+				return
+			}
+			if len(summary.Properties) != 2 {
+				t.Fatalf("breakdown = %+v, want Kos Melati and Kos Mawar", summary.Properties)
+			}
+			for i, property := range tests[:2] {
+				check(t, &summary.Properties[i], property.propertyID, property.wantName, property.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"time"
+)
+
+// Deposit Usecase
+type DepositStatement struct {
+	TenantID        uint                  `json:"tenant_id"`
+	TenantName      string                `json:"tenant_name"`
+	Status          string                `json:"status"`
+	Received        entity.Money          `json:"received"`
+	Deducted        entity.Money          `json:"deducted"`
+	Refunded        entity.Money          `json:"refunded"`
+	Balance         entity.Money          `json:"balance"`
+	Entries         []entity.DepositEntry `json:"entries"`
+	OverduePayments []entity.Payment      `json:"overdue_payments"`
+	ClosedAt        *time.Time            `json:"closed_at"`
+}
+
+type DepositUsecase interface {
+	Receive(tenantID uint, amount entity.Money, reason string) (*DepositStatement, error)
+	Deduct(tenantID uint, amount entity.Money, reason string, paymentID *uint) (*DepositStatement, error)
+	GetStatement(tenantID uint) (*DepositStatement, error)
+	Settle(tenantID uint) (*DepositStatement, error)
+}
+
+type depositUsecase struct {
+	uow         repository.UnitOfWork
+	depositRepo repository.DepositRepository
+	tenantRepo  repository.TenantRepository
+	paymentRepo repository.PaymentRepository
+	ledger      *paymentLedger
+}
+
+func NewDepositUsecase(
+	uow repository.UnitOfWork,
+	depositRepo repository.DepositRepository,
+	tenantRepo repository.TenantRepository,
+	paymentRepo repository.PaymentRepository,
+) DepositUsecase {
+	return &depositUsecase{
+		uow:         uow,
+		depositRepo: depositRepo,
+		tenantRepo:  tenantRepo,
+		paymentRepo: paymentRepo,
+		ledger:      newPaymentLedger(paymentRepo, tenantRepo),
+	}
+}
+
+// withRepos returns a copy of the usecase working on repos of a unit of work.
+func (u *depositUsecase) withRepos(repos *repository.Repositories) *depositUsecase {
+	return &depositUsecase{
+		uow:         u.uow,
+		depositRepo: repos.Deposits,
+		tenantRepo:  repos.Tenants,
+		paymentRepo: repos.Payments,
+		ledger:      newPaymentLedger(repos.Payments, repos.Tenants),
+	}
+}
+
+func (u *depositUsecase) Receive(tenantID uint, amount entity.Money, reason string) (*DepositStatement, error) {
+	err := u.uow.Do(func(repos *repository.Repositories) error {
+		return u.withRepos(repos).receive(tenantID, amount, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.GetStatement(tenantID)
+}
+
+func (u *depositUsecase) receive(tenantID uint, amount entity.Money, reason string) error {
+	if !amount.IsPositive() {
+		return entity.Invalid("invalid_amount", "amount must be greater than zero")
+	}
+	if _, err := u.tenantRepo.FindByID(tenantID); err != nil {
+		return err
+	}
+
+	deposit, err := u.depositRepo.FindByTenantID(tenantID)
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return err
+	}
+	if deposit == nil {
+		deposit = &entity.Deposit{
+			TenantID:  tenantID,
+			Status:    "held",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		if err := u.depositRepo.Create(deposit); err != nil {
+			return err
+		}
+	}
+	if deposit.Status == "closed" {
+		return entity.Conflict("deposit_settled", "deposit is already settled")
+	}
+
+	return u.addEntry(deposit.ID, "received", amount, reason, nil)
+}
+
+// Deduct withholds part of the deposit. When paymentID is given the deduction
+// is recorded as a transaction on that bill and defaults to its balance.
+func (u *depositUsecase) Deduct(tenantID uint, amount entity.Money, reason string, paymentID *uint) (*DepositStatement, error) {
+	err := u.uow.Do(func(repos *repository.Repositories) error {
+		return u.withRepos(repos).deduct(tenantID, amount, reason, paymentID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.GetStatement(tenantID)
+}
+
+func (u *depositUsecase) deduct(tenantID uint, amount entity.Money, reason string, paymentID *uint) error {
+	statement, err := u.GetStatement(tenantID)
+	if err != nil {
+		return err
+	}
+	if statement.Status == "closed" {
+		return entity.Conflict("deposit_settled", "deposit is already settled")
+	}
+
+	var payment *entity.Payment
+	if paymentID != nil {
+		payment, err = u.paymentRepo.FindByID(*paymentID)
+		if err != nil {
+			return err
+		}
+		if payment.TenantID != tenantID {
+			return entity.Invalid("payment_not_of_tenant", "payment does not belong to this tenant")
+		}
+		if !payment.Balance().IsPositive() {
+			return entity.Conflict("payment_paid", "payment is already paid")
+		}
+		if amount.IsZero() {
+			amount = payment.Balance()
+		}
+		if amount.GreaterThan(payment.Balance()) {
+			return entity.Invalid("deduction_too_large", "deduction exceeds the payment balance")
+		}
+		if reason == "" {
+			reason = "Unpaid rent for " + payment.DueDate.Format("January 2006")
+		}
+	}
+
+	if !amount.IsPositive() {
+		return entity.Invalid("invalid_amount", "amount must be greater than zero")
+	}
+	if amount.GreaterThan(statement.Balance) {
+		return entity.Invalid("deduction_too_large", "deduction exceeds deposit balance")
+	}
+	if reason == "" {
+		return entity.Invalid("reason_required", "reason is required for deductions")
+	}
+
+	deposit, err := u.depositRepo.FindByTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+	if err := u.addEntry(deposit.ID, "deduction", amount, reason, paymentID); err != nil {
+		return err
+	}
+
+	if payment != nil {
+		if _, err := u.ledger.Pay(payment, amount, "deposit", time.Now(), reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *depositUsecase) GetStatement(tenantID uint) (*DepositStatement, error) {
+	tenant, err := u.tenantRepo.FindByID(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	deposit, err := u.depositRepo.FindByTenantID(tenantID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, entity.NotFound("deposit_not_found", "no deposit recorded for this tenant")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	overdue, err := u.overduePayments(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &DepositStatement{
+		TenantID:        tenant.ID,
+		TenantName:      tenant.Name,
+		Status:          deposit.Status,
+		Entries:         deposit.Entries,
+		OverduePayments: overdue,
+		ClosedAt:        deposit.ClosedAt,
+	}
+	for _, entry := range deposit.Entries {
+		switch entry.Type {
+		case "received":
+			statement.Received = statement.Received.Add(entry.Amount)
+		case "deduction":
+			statement.Deducted = statement.Deducted.Add(entry.Amount)
+		case "refund":
+			statement.Refunded = statement.Refunded.Add(entry.Amount)
+		}
+	}
+	statement.Balance = statement.Received.Sub(statement.Deducted).Sub(statement.Refunded)
+
+	return statement, nil
+}
+
+// Settle refunds the remaining balance and closes the deposit. It is only
+// allowed after move-out and once no payment of the tenant is overdue.
+func (u *depositUsecase) Settle(tenantID uint) (*DepositStatement, error) {
+	err := u.uow.Do(func(repos *repository.Repositories) error {
+		return u.withRepos(repos).settle(tenantID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.GetStatement(tenantID)
+}
+
+func (u *depositUsecase) settle(tenantID uint) error {
+	tenant, err := u.tenantRepo.FindByID(tenantID)
+	if err != nil {
+		return err
+	}
+	if tenant.Status == "active" {
+		return entity.Conflict("tenant_not_moved_out", "tenant has not moved out yet")
+	}
+
+	statement, err := u.GetStatement(tenantID)
+	if err != nil {
+		return err
+	}
+	if statement.Status == "closed" {
+		return nil
+	}
+	if len(statement.OverduePayments) > 0 {
+		return entity.Conflict("overdue_payments", "tenant still has overdue payments")
+	}
+
+	deposit, err := u.depositRepo.FindByTenantID(tenantID)
+	if err != nil {
+		return err
+	}
+
+	if statement.Balance.IsPositive() {
+		if err := u.addEntry(deposit.ID, "refund", statement.Balance, "Move-out refund", nil); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	deposit.Status = "closed"
+	deposit.ClosedAt = &now
+	deposit.UpdatedAt = now
+	return u.depositRepo.Update(deposit)
+}
+
+func (u *depositUsecase) addEntry(depositID uint, entryType string, amount entity.Money, reason string, paymentID *uint) error {
+	return u.depositRepo.AddEntry(&entity.DepositEntry{
+		DepositID: depositID,
+		Type:      entryType,
+		Amount:    amount,
+		Reason:    reason,
+		PaymentID: paymentID,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (u *depositUsecase) overduePayments(tenantID uint) ([]entity.Payment, error) {
+	all, err := u.paymentRepo.FindOverdue(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	overdue := []entity.Payment{}
+	for _, p := range all {
+		if p.TenantID == tenantID {
+			overdue = append(overdue, p)
+		}
+	}
+	return overdue, nil
+}
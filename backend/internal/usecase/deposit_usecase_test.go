@@ -0,0 +1,270 @@
+package usecase_test
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/memory"
+	"ezkost/internal/usecase"
+	"slices"
+	"testing"
+)
+
+type deposits struct {
+	rows    []entity.Deposit
+	entries int
+}
+
+var _ repository.DepositRepository = (*deposits)(nil)
+
+func (d *deposits) Create(deposit *entity.Deposit) error {
+	deposit.ID = uint(len(d.rows) + 1)
+	d.rows = append(d.rows, *deposit)
+	return nil
+}
+
+func (d *deposits) FindByTenantID(tenantID uint) (*entity.Deposit, error) {
+	for _, deposit := range d.rows {
+		if deposit.TenantID == tenantID {
+			deposit.Entries = slices.Clone(deposit.Entries)
+			return &deposit, nil
+		}
+	}
+	return nil, entity.NotFound("not_found", "deposit not found")
+}
+
+func (d *deposits) Update(deposit *entity.Deposit) error {
+	for i := range d.rows {
+		if d.rows[i].ID == deposit.ID {
+			entries := d.rows[i].Entries
+			d.rows[i] = *deposit
+			d.rows[i].Entries = entries
+			return nil
+		}
+	}
+	return entity.NotFound("not_found", "deposit not found")
+}
+
+func (d *deposits) AddEntry(entry *entity.DepositEntry) error {
+	for i := range d.rows {
+		if d.rows[i].ID == entry.DepositID {
+			d.entries++
+			entry.ID = uint(d.entries)
+			d.rows[i].Entries = append(d.rows[i].Entries, *entry)
+			return nil
+		}
+	}
+	return entity.NotFound("not_found", "deposit not found")
+}
+
+// failingDeposits fails every lookup by tenant with err.
+type failingDeposits struct {
+	*deposits
+	err error
+}
+
+func (d failingDeposits) FindByTenantID(tenantID uint) (*entity.Deposit, error) { return nil, d.err }
+
+func TestDepositReceiveLookupFails(t *testing.T) {
+	f := newFixture(t)
+	budi := f.tenant(t, "Budi", f.room(t, "101", "1500000"), date(2026, 1, 1))
+	stored := &deposits{}
+	lookupErr := errors.New("connection reset")
+	f.repos.Deposits = failingDeposits{stored, lookupErr}
+	depositUsecase := usecase.NewDepositUsecase(memory.NewUnitOfWork(f.repos), f.repos.Deposits, f.repos.Tenants, f.repos.Payments)
+
+	if _, err := depositUsecase.Receive(budi.ID, money(t, "1500000"), "Deposit"); !errors.Is(err, lookupErr) {
+		t.Errorf("got error %v, want %v", err, lookupErr)
+	}
+	if len(stored.rows) != 0 {
+		t.Errorf("deposits = %+v, want none created", stored.rows)
+	}
+}
+
+func TestDepositReceive(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID uint
+		amounts  []string
+		kind     error
+		code     string
+		want     string
+	}{
+		{"first deposit", 1, []string{"1500000"}, nil, "", "1500000.00"},
+		{"top-up", 1, []string{"1000000", "500000"}, nil, "", "1500000.00"},
+		{"nothing", 1, []string{"0"}, entity.ErrValidation, "invalid_amount", ""},
+		{"missing tenant", 99, []string{"1500000"}, entity.ErrNotFound, "not_found", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.tenant(t, "Budi", f.room(t, "101", "1500000"), date(2026, 1, 1))
+
+			var statement *usecase.DepositStatement
+			var err error
+			for _, amount := range tt.amounts {
+				statement, err = f.usecases.Deposit.Receive(tt.tenantID, money(t, amount), "Deposit")
+			}
+			if tt.kind != nil {
+				wantKind(t, err, tt.kind, tt.code)
+				_, err := f.usecases.Deposit.GetStatement(1)
+				wantKind(t, err, entity.ErrNotFound, "deposit_not_found")
+				return
+			}
+			must(t, err)
+			if statement.Status != "held" || len(statement.Entries) != len(tt.amounts) {
+				t.Errorf("statement = %+v, want a held deposit with %d entries", statement, len(tt.amounts))
+			}
+			wantMoney(t, "received", statement.Received, tt.want)
+			wantMoney(t, "balance", statement.Balance, tt.want)
+			if n := len(f.repos.Deposits.(*deposits).rows); n != 1 {
+				t.Errorf("%d deposits, want one per tenant", n)
+			}
+		})
+	}
+}
+
+func TestDepositDeduct(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount string
+		reason string
+		// bill deducts from Budi's January bill of 1,500,000, of which
+		// 1,000,000 is paid; paidBill deducts from a bill paid in full
+		bill         bool
+		paidBill     bool
+		kind         error
+		code         string
+		wantBalance  string
+		wantBillPaid bool
+	}{
+		{"damage", "250000", "Broken window", false, false, nil, "", "1750000.00", false},
+		{"without a reason", "250000", "", false, false, entity.ErrValidation, "reason_required", "", false},
+		{"more than the deposit", "2000001", "Broken window", false, false, entity.ErrValidation, "deduction_too_large", "", false},
+		{"nothing", "0", "Broken window", false, false, entity.ErrValidation, "invalid_amount", "", false},
+		{"a bill's balance", "0", "", true, false, nil, "", "1500000.00", true},
+		{"part of a bill", "200000", "", true, false, nil, "", "1800000.00", false},
+		{"more than a bill's balance", "600000", "", true, false, entity.ErrValidation, "deduction_too_large", "", false},
+		{"a paid bill", "0", "", false, true, entity.ErrConflict, "payment_paid", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			budi := f.tenant(t, "Budi", f.room(t, "101", "1550000"), date(2026, 1, 2))
+			_, err := f.usecases.Deposit.Receive(budi.ID, money(t, "2000000"), "Deposit")
+			must(t, err)
+			bill := f.payment(t, budi.ID, "2026-01")
+			var paymentID *uint
+			if tt.bill || tt.paidBill {
+				paid := "1000000"
+				if tt.paidBill {
+					paid = "1500000"
+				}
+				_, err := f.usecases.Payment.RecordTransaction(bill.ID, &entity.PaymentTransaction{Amount: money(t, paid), Method: "cash"})
+				must(t, err)
+				paymentID = &bill.ID
+			}
+
+			statement, err := f.usecases.Deposit.Deduct(budi.ID, money(t, tt.amount), tt.reason, paymentID)
+			if tt.kind != nil {
+				wantKind(t, err, tt.kind, tt.code)
+				statement, err := f.usecases.Deposit.GetStatement(budi.ID)
+				must(t, err)
+				wantMoney(t, "balance", statement.Balance, "2000000.00")
+				return
+			}
+			must(t, err)
+			wantMoney(t, "balance", statement.Balance, tt.wantBalance)
+			last := statement.Entries[len(statement.Entries)-1]
+			if last.Type != "deduction" || last.Reason == "" {
+				t.Errorf("last entry = %+v, want a deduction with a reason", last)
+			}
+			if paid := f.payment(t, budi.ID, "2026-01").PaidAt != nil; paid != tt.wantBillPaid {
+				t.Errorf("January bill paid = %v, want %v", paid, tt.wantBillPaid)
+			}
+		})
+	}
+
+	f := newFixture(t)
+	budi := f.tenant(t, "Budi", f.room(t, "101", "1550000"), date(2026, 1, 2))
+	ani := f.tenant(t, "Ani", f.room(t, "102", "1550000"), date(2026, 1, 2))
+	_, err := f.usecases.Deposit.Receive(budi.ID, money(t, "2000000"), "Deposit")
+	must(t, err)
+	aniBill := f.payment(t, ani.ID, "2026-01").ID
+	_, err = f.usecases.Deposit.Deduct(budi.ID, money(t, "0"), "", &aniBill)
+	wantKind(t, err, entity.ErrValidation, "payment_not_of_tenant")
+}
+
+func TestDepositSettle(t *testing.T) {
+	tests := []struct {
+		name string
+		// movedOut moves Budi out on January 31; paid is what he paid of
+		// his 1,550,000 January bill, which is overdue until paid in full
+		movedOut bool
+		paid     string
+		kind     error
+		code     string
+	}{
+		{"tenant still living there", false, "1550000", entity.ErrConflict, "tenant_not_moved_out"},
+		{"overdue bill", true, "", entity.ErrConflict, "overdue_payments"},
+		{"partly paid overdue bill", true, "1000000", entity.ErrConflict, "overdue_payments"},
+		{"refunds the balance", true, "1550000", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			budi := f.tenant(t, "Budi", f.room(t, "101", "1550000"), date(2026, 1, 1))
+			_, err := f.usecases.Deposit.Receive(budi.ID, money(t, "2000000"), "Deposit")
+			must(t, err)
+			_, err = f.usecases.Deposit.Deduct(budi.ID, money(t, "300000"), "Broken window", nil)
+			must(t, err)
+			if tt.paid != "" {
+				_, err := f.usecases.Payment.RecordTransaction(f.payment(t, budi.ID, "2026-01").ID, &entity.PaymentTransaction{
+					Amount: money(t, tt.paid),
+					Method: "cash",
+					PaidAt: date(2026, 1, 1),
+				})
+				must(t, err)
+			}
+			if tt.movedOut {
+				end := date(2026, 1, 31)
+				budi.EndDate = &end
+				must(t, f.repos.Tenants.Update(budi))
+				must(t, f.usecases.Tenant.Delete(budi.ID))
+			}
+
+			statement, err := f.usecases.Deposit.Settle(budi.ID)
+			if tt.kind != nil {
+				wantKind(t, err, tt.kind, tt.code)
+				statement, err := f.usecases.Deposit.GetStatement(budi.ID)
+				must(t, err)
+				if statement.Status != "held" {
+					t.Errorf("deposit %s, want held", statement.Status)
+				}
+				if wantOverdue := tt.code == "overdue_payments"; (len(statement.OverduePayments) == 1) != wantOverdue {
+					t.Errorf("overdue bills = %+v, want the January bill listed: %v", statement.OverduePayments, wantOverdue)
+				}
+				return
+			}
+			must(t, err)
+			if len(statement.OverduePayments) != 0 {
+				t.Errorf("overdue bills = %+v, want none", statement.OverduePayments)
+			}
+			if statement.Status != "closed" || statement.ClosedAt == nil {
+				t.Errorf("statement = %+v, want a closed deposit", statement)
+			}
+			wantMoney(t, "refunded", statement.Refunded, "1700000.00")
+			wantMoney(t, "balance", statement.Balance, "0.00")
+
+			// Settling again changes nothing, and a closed deposit takes no
+			// more money
+			statement, err = f.usecases.Deposit.Settle(budi.ID)
+			must(t, err)
+			wantMoney(t, "refunded", statement.Refunded, "1700000.00")
+			_, err = f.usecases.Deposit.Receive(budi.ID, money(t, "100000"), "Deposit")
+			wantKind(t, err, entity.ErrConflict, "deposit_settled")
+			_, err = f.usecases.Deposit.Deduct(budi.ID, money(t, "100000"), "Cleaning", nil)
+			wantKind(t, err, entity.ErrConflict, "deposit_settled")
+		})
+	}
+}
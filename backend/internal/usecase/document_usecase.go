@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"fmt"
+	"time"
+)
+
+const (
+	DocumentReceipt = "receipt"
+	DocumentInvoice = "invoice"
+)
+
+// PaymentDocument is everything printed on a receipt or invoice.
+type PaymentDocument struct {
+	Kind       string
+	Number     string
+	IssuedAt   time.Time
+	Letterhead entity.Letterhead
+	Payment    entity.Payment
+}
+
+// DocumentRenderer turns a payment document into a printable file.
+type DocumentRenderer interface {
+	RenderPaymentDocument(doc *PaymentDocument) ([]byte, error)
+}
+
+// Document Usecase
+type DocumentUsecase interface {
+	GetLetterhead() (*entity.Letterhead, error)
+	UpdateLetterhead(letterhead *entity.Letterhead) error
+	Receipt(paymentID uint) ([]byte, *PaymentDocument, error)
+	Invoice(paymentID uint) ([]byte, *PaymentDocument, error)
+}
+
+type documentUsecase struct {
+	paymentRepo    repository.PaymentRepository
+	receiptRepo    repository.ReceiptRepository
+	letterheadRepo repository.LetterheadRepository
+	propertyRepo   repository.PropertyRepository
+	renderer       DocumentRenderer
+}
+
+func NewDocumentUsecase(
+	paymentRepo repository.PaymentRepository,
+	receiptRepo repository.ReceiptRepository,
+	letterheadRepo repository.LetterheadRepository,
+	propertyRepo repository.PropertyRepository,
+	renderer DocumentRenderer,
+) DocumentUsecase {
+	return &documentUsecase{
+		paymentRepo:    paymentRepo,
+		receiptRepo:    receiptRepo,
+		letterheadRepo: letterheadRepo,
+		propertyRepo:   propertyRepo,
+		renderer:       renderer,
+	}
+}
+
+func (u *documentUsecase) GetLetterhead() (*entity.Letterhead, error) {
+	return u.letterheadRepo.Get()
+}
+
+func (u *documentUsecase) UpdateLetterhead(letterhead *entity.Letterhead) error {
+	if letterhead.Name == "" {
+		return entity.Invalid("name_required", "name is required")
+	}
+
+	current, err := u.letterheadRepo.Get()
+	if err != nil {
+		return err
+	}
+	letterhead.ID = current.ID
+	letterhead.CreatedAt = current.CreatedAt
+	if letterhead.ID == 0 {
+		letterhead.CreatedAt = time.Now()
+	}
+	letterhead.UpdatedAt = time.Now()
+	return u.letterheadRepo.Save(letterhead)
+}
+
+// Receipt renders the receipt of a fully paid payment. The receipt number is
+// allocated from the property's yearly sequence on first print and reused on
+// every reprint.
+func (u *documentUsecase) Receipt(paymentID uint) ([]byte, *PaymentDocument, error) {
+	payment, err := u.paymentRepo.FindByID(paymentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if payment.PaidAt == nil {
+		return nil, nil, entity.Conflict("payment_not_paid", "payment has not been paid in full")
+	}
+
+	receipt, err := u.receiptRepo.FindByPaymentID(paymentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if receipt == nil {
+		prefix := "RCP"
+		if property, err := u.propertyRepo.FindByID(payment.PropertyID); err == nil && property.ReceiptPrefix != "" {
+			prefix = property.ReceiptPrefix
+		}
+		receipt = &entity.Receipt{
+			PropertyID: payment.PropertyID,
+			PaymentID:  paymentID,
+			Year:       payment.PaidAt.Year(),
+			IssuedAt:   time.Now(),
+			CreatedAt:  time.Now(),
+		}
+		if err := u.receiptRepo.Issue(receipt, prefix); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return u.render(DocumentReceipt, receipt.Number, receipt.IssuedAt, payment)
+}
+
+func (u *documentUsecase) Invoice(paymentID uint) ([]byte, *PaymentDocument, error) {
+	payment, err := u.paymentRepo.FindByID(paymentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	number := fmt.Sprintf("INV/%d/%06d", payment.DueDate.Year(), payment.ID)
+	return u.render(DocumentInvoice, number, time.Now(), payment)
+}
+
+// render prints the document under the global letterhead, with the name and
+// contact details of the payment's property when it has one.
+func (u *documentUsecase) render(kind, number string, issuedAt time.Time, payment *entity.Payment) ([]byte, *PaymentDocument, error) {
+	letterhead, err := u.letterheadRepo.Get()
+	if err != nil {
+		return nil, nil, err
+	}
+	if property, err := u.propertyRepo.FindByID(payment.PropertyID); err == nil {
+		letterhead.Name = property.Name
+		letterhead.Address = property.Address
+		if property.Phone != "" {
+			letterhead.Phone = property.Phone
+		}
+		if property.Email != "" {
+			letterhead.Email = property.Email
+		}
+	}
+
+	doc := &PaymentDocument{
+		Kind:       kind,
+		Number:     number,
+		IssuedAt:   issuedAt,
+		Letterhead: *letterhead,
+		Payment:    *payment,
+	}
+	data, err := u.renderer.RenderPaymentDocument(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, doc, nil
+}
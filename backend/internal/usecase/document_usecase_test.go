@@ -0,0 +1,178 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/usecase"
+	"fmt"
+	"testing"
+)
+
+// receipts numbers receipts per property and year the way the database
+// sequence does.
+type receipts struct {
+	rows []entity.Receipt
+}
+
+var _ repository.ReceiptRepository = (*receipts)(nil)
+
+func (r *receipts) FindByPaymentID(paymentID uint) (*entity.Receipt, error) {
+	for _, receipt := range r.rows {
+		if receipt.PaymentID == paymentID {
+			return &receipt, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *receipts) Issue(receipt *entity.Receipt, prefix string) error {
+	receipt.Sequence = 1
+	for _, issued := range r.rows {
+		if issued.PropertyID == receipt.PropertyID && issued.Year == receipt.Year {
+			receipt.Sequence++
+		}
+	}
+	receipt.ID = uint(len(r.rows) + 1)
+	receipt.Number = fmt.Sprintf("%s/%d/%06d", prefix, receipt.Year, receipt.Sequence)
+	r.rows = append(r.rows, *receipt)
+	return nil
+}
+
+type letterheads struct {
+	saved *entity.Letterhead
+}
+
+var _ repository.LetterheadRepository = (*letterheads)(nil)
+
+func (l *letterheads) Get() (*entity.Letterhead, error) {
+	if l.saved == nil {
+		return &entity.Letterhead{}, nil
+	}
+	letterhead := *l.saved
+	return &letterhead, nil
+}
+
+func (l *letterheads) Save(letterhead *entity.Letterhead) error {
+	letterhead.ID = 1
+	saved := *letterhead
+	l.saved = &saved
+	return nil
+}
+
+// renderer prints the kind and number of a document.
+type renderer struct{}
+
+func (renderer) RenderPaymentDocument(doc *usecase.PaymentDocument) ([]byte, error) {
+	return []byte(doc.Kind + " " + doc.Number), nil
+}
+
+func TestDocumentUpdateLetterhead(t *testing.T) {
+	f := newFixture(t)
+	wantKind(t, f.usecases.Document.UpdateLetterhead(&entity.Letterhead{Phone: "0812"}), entity.ErrValidation, "name_required")
+
+	first := &entity.Letterhead{Name: "Kos Group", Phone: "0812", Footer: "Thank you"}
+	must(t, f.usecases.Document.UpdateLetterhead(first))
+	second := &entity.Letterhead{Name: "Kos Group", Phone: "0813"}
+	must(t, f.usecases.Document.UpdateLetterhead(second))
+
+	got, err := f.usecases.Document.GetLetterhead()
+	must(t, err)
+	if got.ID != first.ID || got.Phone != "0813" || got.Footer != "" || !got.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("letterhead = %+v, want the second one saved over the first, created at %v", got, first.CreatedAt)
+	}
+}
+
+func TestDocumentInvoice(t *testing.T) {
+	tests := []struct {
+		name string
+		// property holds the bill; its phone and email are set when given
+		propertyID  uint
+		phone       string
+		wantName    string
+		wantAddress string
+		wantPhone   string
+	}{
+		{"property details", 1, "0274 123", "Kos Melati", "Jl. Melati 1", "0274 123"},
+		{"letterhead phone", 2, "", "Kos Mawar", "Jl. Mawar 2", "0812"},
+		{"missing property", 99, "", "Kos Group", "Jl. Utama 1", "0812"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			must(t, f.usecases.Document.UpdateLetterhead(&entity.Letterhead{Name: "Kos Group", Address: "Jl. Utama 1", Phone: "0812"}))
+			if property, err := f.repos.Properties.FindByID(tt.propertyID); err == nil {
+				property.Phone = tt.phone
+				must(t, f.repos.Properties.Update(property))
+			}
+			tenant := &entity.Tenant{PropertyID: tt.propertyID, Name: "Budi", Phone: "0812", StartDate: date(2026, 1, 1)}
+			must(t, f.repos.Tenants.Create(tenant))
+			payment := &entity.Payment{TenantID: tenant.ID, Amount: money(t, "1500000"), DueDate: date(2026, 2, 1), Period: "2026-02"}
+			must(t, f.usecases.Payment.Create(payment))
+
+			data, doc, err := f.usecases.Document.Invoice(payment.ID)
+			must(t, err)
+			wantNumber := fmt.Sprintf("INV/2026/%06d", payment.ID)
+			if doc.Kind != "invoice" || doc.Number != wantNumber || string(data) != "invoice "+wantNumber {
+				t.Errorf("document = %s %s rendered as %q, want invoice %s", doc.Kind, doc.Number, data, wantNumber)
+			}
+			if doc.Letterhead.Name != tt.wantName || doc.Letterhead.Address != tt.wantAddress || doc.Letterhead.Phone != tt.wantPhone {
+				t.Errorf("letterhead = %+v, want %s, %s, %s", doc.Letterhead, tt.wantName, tt.wantAddress, tt.wantPhone)
+			}
+			wantMoney(t, "invoiced", doc.Payment.Balance(), "1500000.00")
+		})
+	}
+
+	f := newFixture(t)
+	_, _, err := f.usecases.Document.Invoice(99)
+	wantKind(t, err, entity.ErrNotFound, "not_found")
+}
+
+func TestDocumentReceiptUnpaid(t *testing.T) {
+	f := newFixture(t)
+	budi := f.tenant(t, "Budi", f.room(t, "101", "1500000"), date(2026, 1, 1))
+	bill := f.payment(t, budi.ID, "2026-01")
+	_, err := f.usecases.Payment.RecordTransaction(bill.ID, &entity.PaymentTransaction{Amount: money(t, "1000000"), Method: "cash"})
+	must(t, err)
+
+	_, _, err = f.usecases.Document.Receipt(bill.ID)
+	wantKind(t, err, entity.ErrConflict, "payment_not_paid")
+	if len(f.receipts.rows) != 0 {
+		t.Errorf("receipts = %+v, want none for a partly paid bill", f.receipts.rows)
+	}
+}
+
+func TestDocumentReceipt(t *testing.T) {
+	f := newFixture(t)
+	budi := f.tenant(t, "Budi", f.room(t, "101", "1500000"), date(2026, 1, 1))
+	ani := f.tenant(t, "Ani", f.room(t, "102", "1500000"), date(2026, 1, 1))
+	pay := func(tenantID uint) uint {
+		bill := f.payment(t, tenantID, "2026-01")
+		_, err := f.usecases.Payment.RecordTransaction(bill.ID, &entity.PaymentTransaction{Amount: money(t, "1500000"), Method: "cash", PaidAt: date(2026, 1, 3)})
+		must(t, err)
+		return bill.ID
+	}
+	budiBill, aniBill := pay(budi.ID), pay(ani.ID)
+
+	// Receipts are numbered in the order they are first printed; a reprint
+	// keeps its number
+	tests := []struct {
+		name      string
+		paymentID uint
+		want      string
+	}{
+		{"Ani's first", aniBill, "MLT/2026/000001"},
+		{"Budi's first", budiBill, "MLT/2026/000002"},
+		{"Ani's reprint", aniBill, "MLT/2026/000001"},
+		{"Budi's reprint", budiBill, "MLT/2026/000002"},
+	}
+	for _, tt := range tests {
+		data, doc, err := f.usecases.Document.Receipt(tt.paymentID)
+		must(t, err)
+		if doc.Kind != "receipt" || doc.Number != tt.want || string(data) != "receipt "+tt.want {
+			t.Errorf("%s: %s %s rendered as %q, want receipt %s", tt.name, doc.Kind, doc.Number, data, tt.want)
+		}
+	}
+	if len(f.receipts.rows) != 2 {
+		t.Errorf("receipts = %+v, want one per bill", f.receipts.rows)
+	}
+}
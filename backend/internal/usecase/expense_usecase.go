@@ -9,28 +9,36 @@ import (
 // Expense Usecase
 type ExpenseUsecase interface {
 	Create(expense *entity.Expense) error
-	GetAll() ([]entity.Expense, error)
+	GetAll(query repository.ExpenseQuery) ([]entity.Expense, int64, error)
 	GetByID(id uint) (*entity.Expense, error)
 	Update(expense *entity.Expense) error
 	Delete(id uint) error
 }
 
 type expenseUsecase struct {
-	expenseRepo repository.ExpenseRepository
+	expenseRepo  repository.ExpenseRepository
+	propertyRepo repository.PropertyRepository
 }
 
-func NewExpenseUsecase(expenseRepo repository.ExpenseRepository) ExpenseUsecase {
-	return &expenseUsecase{expenseRepo: expenseRepo}
+func NewExpenseUsecase(expenseRepo repository.ExpenseRepository, propertyRepo repository.PropertyRepository) ExpenseUsecase {
+	return &expenseUsecase{
+		expenseRepo:  expenseRepo,
+		propertyRepo: propertyRepo,
+	}
 }
 
 func (u *expenseUsecase) Create(expense *entity.Expense) error {
+	if err := u.checkProperty(expense.PropertyID); err != nil {
+		return err
+	}
 	expense.CreatedAt = time.Now()
 	expense.UpdatedAt = time.Now()
 	return u.expenseRepo.Create(expense)
 }
 
-func (u *expenseUsecase) GetAll() ([]entity.Expense, error) {
-	return u.expenseRepo.FindAll()
+func (u *expenseUsecase) GetAll(query repository.ExpenseQuery) ([]entity.Expense, int64, error) {
+	limitPage(&query.Page)
+	return u.expenseRepo.Find(query)
 }
 
 func (u *expenseUsecase) GetByID(id uint) (*entity.Expense, error) {
@@ -38,6 +46,9 @@ func (u *expenseUsecase) GetByID(id uint) (*entity.Expense, error) {
 }
 
 func (u *expenseUsecase) Update(expense *entity.Expense) error {
+	if err := u.checkProperty(expense.PropertyID); err != nil {
+		return err
+	}
 	expense.UpdatedAt = time.Now()
 	return u.expenseRepo.Update(expense)
 }
@@ -45,3 +56,13 @@ func (u *expenseUsecase) Update(expense *entity.Expense) error {
 func (u *expenseUsecase) Delete(id uint) error {
 	return u.expenseRepo.Delete(id)
 }
+
+func (u *expenseUsecase) checkProperty(propertyID uint) error {
+	if propertyID == 0 {
+		return entity.Invalid("property_required", "property is required")
+	}
+	if _, err := u.propertyRepo.FindByID(propertyID); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"testing"
+)
+
+func TestExpenseChecksProperty(t *testing.T) {
+	tests := []struct {
+		name       string
+		propertyID uint
+		kind       error
+		code       string
+	}{
+		{"no property", 0, entity.ErrValidation, "property_required"},
+		{"missing property", 99, entity.ErrNotFound, "not_found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			expense := &entity.Expense{PropertyID: 1, Description: "Listrik", Amount: money(t, "250000"), ExpenseDate: date(2026, 2, 3)}
+			must(t, f.usecases.Expense.Create(expense))
+
+			err := f.usecases.Expense.Create(&entity.Expense{PropertyID: tt.propertyID, Description: "Air", Amount: money(t, "1000"), ExpenseDate: date(2026, 2, 3)})
+			wantKind(t, err, tt.kind, tt.code)
+
+			moved := *expense
+			moved.PropertyID = tt.propertyID
+			wantKind(t, f.usecases.Expense.Update(&moved), tt.kind, tt.code)
+
+			expenses, total, err := f.usecases.Expense.GetAll(repository.ExpenseQuery{})
+			must(t, err)
+			if total != 1 || expenses[0].PropertyID != 1 {
+				t.Errorf("expenses = %+v, want only the first, on property 1", expenses)
+			}
+		})
+	}
+}
+
+func TestExpenseUpdateAndDelete(t *testing.T) {
+	f := newFixture(t)
+	expense := &entity.Expense{PropertyID: 1, Description: "Listrik", Amount: money(t, "250000"), ExpenseDate: date(2026, 2, 3)}
+	must(t, f.usecases.Expense.Create(expense))
+	if expense.ID == 0 || expense.CreatedAt.IsZero() {
+		t.Fatalf("created expense = %+v, want an id and a creation time", expense)
+	}
+
+	expense.PropertyID = 2
+	expense.Amount = money(t, "275000.50")
+	must(t, f.usecases.Expense.Update(expense))
+	found, err := f.usecases.Expense.GetByID(expense.ID)
+	must(t, err)
+	if found.PropertyID != 2 {
+		t.Errorf("property = %d, want 2", found.PropertyID)
+	}
+	wantMoney(t, "amount", found.Amount, "275000.50")
+
+	must(t, f.usecases.Expense.Delete(expense.ID))
+	_, err = f.usecases.Expense.GetByID(expense.ID)
+	wantKind(t, err, entity.ErrNotFound, "not_found")
+}
+
+func TestExpenseGetAllLimitsPage(t *testing.T) {
+	f := newFixture(t)
+	for i := 1; i <= 3; i++ {
+		must(t, f.usecases.Expense.Create(&entity.Expense{PropertyID: 1, Description: "Air", Amount: money(t, "1000"), ExpenseDate: date(2026, 2, i)}))
+	}
+
+	tests := []struct {
+		name  string
+		page  repository.Page
+		count int
+	}{
+		{"default limit", repository.Page{}, 3},
+		{"negative offset", repository.Page{Limit: 2, Offset: -5}, 2},
+		{"limit", repository.Page{Limit: 1, Offset: 1}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expenses, total, err := f.usecases.Expense.GetAll(repository.ExpenseQuery{Page: tt.page})
+			must(t, err)
+			if len(expenses) != tt.count || total != 3 {
+				t.Errorf("got %d of %d expenses, want %d of 3", len(expenses), total, tt.count)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"fmt"
+	"time"
+)
+
+const (
+	LateFeeFlat       = "flat"
+	LateFeePercentage = "percentage"
+	LateFeePerDay     = "per_day"
+)
+
+// Late Fee Usecase
+type LateFeeResult struct {
+	Charged int `json:"charged"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+}
+
+type LateFeeUsecase interface {
+	GetPolicy(propertyID uint) (*entity.LateFeePolicy, error)
+	UpdatePolicy(propertyID uint, policy *entity.LateFeePolicy) error
+	Apply(now time.Time) (*LateFeeResult, error)
+	Waive(paymentID, itemID, userID uint, reason string) (*entity.PaymentLineItem, error)
+}
+
+type lateFeeUsecase struct {
+	uow          repository.UnitOfWork
+	policyRepo   repository.LateFeePolicyRepository
+	paymentRepo  repository.PaymentRepository
+	propertyRepo repository.PropertyRepository
+	ledger       *paymentLedger
+}
+
+func NewLateFeeUsecase(
+	uow repository.UnitOfWork,
+	policyRepo repository.LateFeePolicyRepository,
+	paymentRepo repository.PaymentRepository,
+	propertyRepo repository.PropertyRepository,
+	tenantRepo repository.TenantRepository,
+) LateFeeUsecase {
+	return &lateFeeUsecase{
+		uow:          uow,
+		policyRepo:   policyRepo,
+		paymentRepo:  paymentRepo,
+		propertyRepo: propertyRepo,
+		ledger:       newPaymentLedger(paymentRepo, tenantRepo),
+	}
+}
+
+// withRepos returns a copy of the usecase working on repos of a unit of work.
+func (u *lateFeeUsecase) withRepos(repos *repository.Repositories) *lateFeeUsecase {
+	return &lateFeeUsecase{
+		uow:          u.uow,
+		policyRepo:   repos.LateFeePolicies,
+		paymentRepo:  repos.Payments,
+		propertyRepo: repos.Properties,
+		ledger:       newPaymentLedger(repos.Payments, repos.Tenants),
+	}
+}
+
+// GetPolicy returns the late fee policy of a property.
+func (u *lateFeeUsecase) GetPolicy(propertyID uint) (*entity.LateFeePolicy, error) {
+	if _, err := u.propertyRepo.FindByID(propertyID); err != nil {
+		return nil, err
+	}
+	return u.policyRepo.Get(propertyID)
+}
+
+// UpdatePolicy replaces the late fee policy of a property.
+func (u *lateFeeUsecase) UpdatePolicy(propertyID uint, policy *entity.LateFeePolicy) error {
+	switch policy.Type {
+	case LateFeeFlat, LateFeePercentage, LateFeePerDay:
+	default:
+		return entity.Invalid("invalid_policy_type", "type must be one of flat, percentage, per_day")
+	}
+	if policy.Amount.IsNegative() || policy.Percentage < 0 || policy.Cap.IsNegative() || policy.GraceDays < 0 {
+		return entity.Invalid("invalid_policy", "amount, percentage, cap and grace days must not be negative")
+	}
+
+	if _, err := u.propertyRepo.FindByID(propertyID); err != nil {
+		return err
+	}
+	current, err := u.policyRepo.Get(propertyID)
+	if err != nil {
+		return err
+	}
+	policy.ID = current.ID
+	policy.PropertyID = propertyID
+	policy.CreatedAt = current.CreatedAt
+	if policy.ID == 0 {
+		policy.CreatedAt = time.Now()
+	}
+	policy.UpdatedAt = time.Now()
+	return u.policyRepo.Save(policy)
+}
+
+// Apply charges the late fee of each payment's property on every overdue
+// payment past its grace period; payments of properties whose policy is
+// disabled are skipped. Each payment carries at most one late fee line item;
+// per-day fees are raised on later runs until the cap, and waived fees are
+// left alone.
+func (u *lateFeeUsecase) Apply(now time.Time) (*LateFeeResult, error) {
+	result := &LateFeeResult{}
+
+	overdue, err := u.paymentRepo.FindOverdue(now)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make(map[uint]*entity.LateFeePolicy)
+	for _, payment := range overdue {
+		policy, ok := policies[payment.PropertyID]
+		if !ok {
+			if policy, err = u.policyRepo.Get(payment.PropertyID); err != nil {
+				return nil, err
+			}
+			policies[payment.PropertyID] = policy
+		}
+		if !policy.Enabled {
+			result.Skipped++
+			continue
+		}
+
+		daysLate := int(now.Sub(payment.DueDate).Hours() / 24)
+		fee := lateFeeAmount(policy, payment.Amount, daysLate)
+		if !fee.IsPositive() {
+			result.Skipped++
+			continue
+		}
+
+		existing := findLineItem(payment.LineItems, "late_fee")
+		if existing == nil {
+			if err := u.paymentRepo.AddLineItem(&entity.PaymentLineItem{
+				PaymentID:   payment.ID,
+				Type:        "late_fee",
+				Description: fmt.Sprintf("Late fee (%d days overdue)", daysLate),
+				Amount:      fee,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}); err != nil {
+				return nil, err
+			}
+			result.Charged++
+			continue
+		}
+
+		if existing.Waived || !fee.GreaterThan(existing.Amount) {
+			result.Skipped++
+			continue
+		}
+		existing.Amount = fee
+		existing.Description = fmt.Sprintf("Late fee (%d days overdue)", daysLate)
+		existing.UpdatedAt = now
+		if err := u.paymentRepo.UpdateLineItem(existing); err != nil {
+			return nil, err
+		}
+		result.Updated++
+	}
+
+	return result, nil
+}
+
+// Waive cancels a line item without deleting it, keeping who waived it and
+// why. The bill is settled again on its lower total, and whatever that leaves
+// paid above the total is credited to the tenant.
+func (u *lateFeeUsecase) Waive(paymentID, itemID, userID uint, reason string) (*entity.PaymentLineItem, error) {
+	if reason == "" {
+		return nil, entity.Invalid("reason_required", "reason is required to waive a fee")
+	}
+
+	var item *entity.PaymentLineItem
+	err := u.uow.Do(func(repos *repository.Repositories) error {
+		var err error
+		item, err = u.withRepos(repos).waive(paymentID, itemID, userID, reason)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (u *lateFeeUsecase) waive(paymentID, itemID, userID uint, reason string) (*entity.PaymentLineItem, error) {
+	item, err := u.paymentRepo.FindLineItemByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item.PaymentID != paymentID {
+		return nil, entity.NotFound("not_found", "line item does not belong to this payment")
+	}
+	if item.Waived {
+		return nil, entity.Conflict("line_item_waived", "line item is already waived")
+	}
+	payment, err := u.paymentRepo.FindByID(paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	item.Waived = true
+	item.WaivedBy = &userID
+	item.WaivedAt = &now
+	item.WaiverReason = reason
+	item.UpdatedAt = now
+	if err := u.paymentRepo.UpdateLineItem(item); err != nil {
+		return nil, err
+	}
+
+	before := overpayment(payment)
+	for i := range payment.LineItems {
+		if payment.LineItems[i].ID == item.ID {
+			payment.LineItems[i] = *item
+		}
+	}
+	if err := u.ledger.CreditOverpayment(payment, before); err != nil {
+		return nil, err
+	}
+	return item, u.ledger.Settle(payment)
+}
+
+func lateFeeAmount(policy *entity.LateFeePolicy, amount entity.Money, daysLate int) entity.Money {
+	var fee entity.Money
+	if daysLate <= policy.GraceDays {
+		return fee
+	}
+
+	switch policy.Type {
+	case LateFeeFlat:
+		fee = policy.Amount
+	case LateFeePercentage:
+		fee = amount.Percent(policy.Percentage)
+	case LateFeePerDay:
+		fee = policy.Amount.Mul(int64(daysLate - policy.GraceDays))
+	}
+
+	if policy.Cap.IsPositive() && fee.GreaterThan(policy.Cap) {
+		fee = policy.Cap
+	}
+	return fee
+}
+
+func findLineItem(items []entity.PaymentLineItem, itemType string) *entity.PaymentLineItem {
+	for i := range items {
+		if items[i].Type == itemType {
+			return &items[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"testing"
+)
+
+func TestLateFeePolicyPerProperty(t *testing.T) {
+	f := newFixture(t)
+	must(t, f.usecases.LateFee.UpdatePolicy(1, &entity.LateFeePolicy{Type: "flat", Amount: money(t, "50000"), Enabled: true}))
+	must(t, f.usecases.LateFee.UpdatePolicy(2, &entity.LateFeePolicy{Type: "per_day", Amount: money(t, "1000"), GraceDays: 2}))
+	// Saving again replaces the property's policy
+	must(t, f.usecases.LateFee.UpdatePolicy(1, &entity.LateFeePolicy{Type: "flat", Amount: money(t, "75000"), Enabled: true}))
+
+	tests := []struct {
+		propertyID  uint
+		wantType    string
+		wantAmount  string
+		wantEnabled bool
+	}{
+		{1, "flat", "75000.00", true},
+		{2, "per_day", "1000.00", false},
+	}
+	for _, tt := range tests {
+		policy, err := f.usecases.LateFee.GetPolicy(tt.propertyID)
+		must(t, err)
+		if policy.PropertyID != tt.propertyID || policy.Type != tt.wantType || policy.Enabled != tt.wantEnabled {
+			t.Errorf("policy of property %d = %+v", tt.propertyID, policy)
+		}
+		wantMoney(t, "amount", policy.Amount, tt.wantAmount)
+	}
+	if len(f.policies) != 2 {
+		t.Errorf("%d policies stored, want one per property", len(f.policies))
+	}
+
+	_, err := f.usecases.LateFee.GetPolicy(99)
+	wantKind(t, err, entity.ErrNotFound, "not_found")
+	err = f.usecases.LateFee.UpdatePolicy(99, &entity.LateFeePolicy{Type: "flat"})
+	wantKind(t, err, entity.ErrNotFound, "not_found")
+}
+
+func TestLateFeeApplyUsesPropertyPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		policies    map[uint]entity.LateFeePolicy
+		wantCharged int
+		wantSkipped int
+		wantFees    map[uint]string
+	}{
+		{
+			name:        "no policies",
+			wantSkipped: 2,
+			wantFees:    map[uint]string{},
+		},
+		{
+			name: "one property enabled",
+			policies: map[uint]entity.LateFeePolicy{
+				1: {Type: "flat", Amount: money(t, "50000"), Enabled: true},
+				2: {Type: "flat", Amount: money(t, "70000")},
+			},
+			wantCharged: 1,
+			wantSkipped: 1,
+			wantFees:    map[uint]string{1: "50000.00"},
+		},
+		{
+			name: "each property its own fee",
+			policies: map[uint]entity.LateFeePolicy{
+				1: {Type: "flat", Amount: money(t, "50000"), Enabled: true},
+				2: {Type: "per_day", Amount: money(t, "1000"), GraceDays: 2, Enabled: true},
+			},
+			wantCharged: 2,
+			wantFees:    map[uint]string{1: "50000.00", 2: "8000.00"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			for propertyID, policy := range tt.policies {
+				must(t, f.usecases.LateFee.UpdatePolicy(propertyID, &policy))
+			}
+			overdue := make(map[uint]uint)
+			for _, propertyID := range []uint{1, 2} {
+				tenant := &entity.Tenant{PropertyID: propertyID, Name: "Budi", Phone: "0812", StartDate: date(2026, 1, 1)}
+				must(t, f.repos.Tenants.Create(tenant))
+				payment := &entity.Payment{TenantID: tenant.ID, Amount: money(t, "1000000"), DueDate: date(2026, 2, 1), Period: "2026-02"}
+				must(t, f.usecases.Payment.Create(payment))
+				overdue[propertyID] = payment.ID
+			}
+
+			result, err := f.usecases.LateFee.Apply(date(2026, 2, 11))
+			must(t, err)
+			if result.Charged != tt.wantCharged || result.Skipped != tt.wantSkipped {
+				t.Errorf("result = %+v, want %d charged and %d skipped", result, tt.wantCharged, tt.wantSkipped)
+			}
+			for propertyID, paymentID := range overdue {
+				payment, err := f.usecases.Payment.GetByID(paymentID)
+				must(t, err)
+				want, charged := tt.wantFees[propertyID]
+				if !charged {
+					if len(payment.LineItems) != 0 {
+						t.Errorf("property %d bill charged %+v, want no fee", propertyID, payment.LineItems)
+					}
+					continue
+				}
+				if len(payment.LineItems) != 1 {
+					t.Fatalf("property %d bill has line items %+v, want one late fee", propertyID, payment.LineItems)
+				}
+				wantMoney(t, "late fee", payment.LineItems[0].Amount, want)
+			}
+		})
+	}
+}
+
+func TestLateFeeWaive(t *testing.T) {
+	tests := []struct {
+		name string
+		// paid is what was paid on the 1,000,000 bill once the 50,000 late
+		// fee was charged
+		paid        string
+		wantBalance string
+		wantCredit  string
+		wantOverdue bool
+	}{
+		{"unpaid bill", "", "1000000.00", "0.00", true},
+		{"settles a bill owing only the fee", "1000000", "0.00", "0.00", false},
+		{"credits what was paid toward the fee", "1020000", "0.00", "20000.00", false},
+		{"credits a paid fee", "1050000", "0.00", "50000.00", false},
+		{"credits the fee on top of an overpayment once", "1100000", "0.00", "100000.00", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			must(t, f.usecases.LateFee.UpdatePolicy(1, &entity.LateFeePolicy{Type: "flat", Amount: money(t, "50000"), Enabled: true}))
+			tenant := &entity.Tenant{PropertyID: 1, Name: "Budi", Phone: "0812", StartDate: date(2026, 1, 1)}
+			must(t, f.repos.Tenants.Create(tenant))
+			payment := &entity.Payment{TenantID: tenant.ID, Amount: money(t, "1000000"), DueDate: date(2026, 2, 1), Period: "2026-02"}
+			must(t, f.usecases.Payment.Create(payment))
+			_, err := f.usecases.LateFee.Apply(date(2026, 2, 11))
+			must(t, err)
+			if tt.paid != "" {
+				_, err := f.usecases.Payment.RecordTransaction(payment.ID, &entity.PaymentTransaction{
+					Amount: money(t, tt.paid),
+					Method: "cash",
+					PaidAt: date(2026, 2, 12),
+				})
+				must(t, err)
+			}
+			payment, err = f.usecases.Payment.GetByID(payment.ID)
+			must(t, err)
+			if len(payment.LineItems) != 1 {
+				t.Fatalf("line items = %+v, want one late fee", payment.LineItems)
+			}
+
+			item, err := f.usecases.LateFee.Waive(payment.ID, payment.LineItems[0].ID, 1, "First time late")
+			must(t, err)
+			if !item.Waived || item.WaivedBy == nil || *item.WaivedBy != 1 || item.WaiverReason != "First time late" {
+				t.Errorf("waived item = %+v", item)
+			}
+
+			payment, err = f.usecases.Payment.GetByID(payment.ID)
+			must(t, err)
+			wantMoney(t, "balance", payment.Balance(), tt.wantBalance)
+			if settled := payment.PaidAt != nil; settled != payment.Balance().IsZero() {
+				t.Errorf("bill paid at %v with a balance of %s", payment.PaidAt, payment.Balance())
+			}
+			tenant, err = f.usecases.Tenant.GetByID(tenant.ID)
+			must(t, err)
+			wantMoney(t, "credit", tenant.CreditBalance, tt.wantCredit)
+			overdue, err := f.repos.Payments.FindOverdue(date(2026, 2, 13))
+			must(t, err)
+			if got := len(overdue) == 1; got != tt.wantOverdue {
+				t.Errorf("overdue = %+v, want overdue %v", overdue, tt.wantOverdue)
+			}
+
+			_, err = f.usecases.LateFee.Waive(payment.ID, item.ID, 1, "Again")
+			wantKind(t, err, entity.ErrConflict, "line_item_waived")
+		})
+	}
+}
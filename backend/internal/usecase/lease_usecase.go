@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"time"
+)
+
+const (
+	BillingMonthly   = "monthly"
+	BillingQuarterly = "quarterly"
+	BillingYearly    = "yearly"
+)
+
+// Lease Usecase
+type LeaseUsecase interface {
+	Create(lease *entity.Lease) error
+	GetAll() ([]entity.Lease, error)
+	GetByID(id uint) (*entity.Lease, error)
+	GetByTenantID(tenantID uint) ([]entity.Lease, error)
+	Renew(id uint, termMonths int, rent entity.Money) (*entity.Lease, error)
+	Terminate(id uint, date time.Time, reason string) (*entity.Lease, error)
+	GetExpiring(days int) ([]entity.Lease, error)
+}
+
+type leaseUsecase struct {
+	uow         repository.UnitOfWork
+	leaseRepo   repository.LeaseRepository
+	tenantRepo  repository.TenantRepository
+	roomRepo    repository.RoomRepository
+	paymentRepo repository.PaymentRepository
+	proration   ProrationPolicy
+	ledger      *paymentLedger
+}
+
+func NewLeaseUsecase(
+	uow repository.UnitOfWork,
+	leaseRepo repository.LeaseRepository,
+	tenantRepo repository.TenantRepository,
+	roomRepo repository.RoomRepository,
+	paymentRepo repository.PaymentRepository,
+	proration ProrationPolicy,
+) LeaseUsecase {
+	return &leaseUsecase{
+		uow:         uow,
+		leaseRepo:   leaseRepo,
+		tenantRepo:  tenantRepo,
+		roomRepo:    roomRepo,
+		paymentRepo: paymentRepo,
+		proration:   proration,
+		ledger:      newPaymentLedger(paymentRepo, tenantRepo),
+	}
+}
+
+// withRepos returns a copy of the usecase working on repos of a unit of work.
+func (u *leaseUsecase) withRepos(repos *repository.Repositories) *leaseUsecase {
+	return &leaseUsecase{
+		uow:         u.uow,
+		leaseRepo:   repos.Leases,
+		tenantRepo:  repos.Tenants,
+		roomRepo:    repos.Rooms,
+		paymentRepo: repos.Payments,
+		proration:   u.proration,
+		ledger:      newPaymentLedger(repos.Payments, repos.Tenants),
+	}
+}
+
+// Create signs a lease for a tenant's current room. Rent defaults to the
+// room's price. An unpaid first bill already issued at the room price for the
+// lease's first month is re-priced at the agreed rent.
+func (u *leaseUsecase) Create(lease *entity.Lease) error {
+	return u.uow.Do(func(repos *repository.Repositories) error {
+		return u.withRepos(repos).create(lease)
+	})
+}
+
+func (u *leaseUsecase) create(lease *entity.Lease) error {
+	tenant, err := u.tenantRepo.FindByID(lease.TenantID)
+	if err != nil {
+		return err
+	}
+	if tenant.Status != "active" || tenant.RoomID == nil {
+		return entity.Conflict("tenant_not_placed", "tenant must be active and assigned to a room")
+	}
+	if lease.RoomID == 0 {
+		lease.RoomID = *tenant.RoomID
+	}
+	if lease.RoomID != *tenant.RoomID {
+		return entity.Invalid("room_mismatch", "lease room must be the tenant's current room")
+	}
+	if lease.StartDate.IsZero() {
+		lease.StartDate = tenant.StartDate
+	}
+	if lease.Rent.IsZero() {
+		lease.Rent = tenant.Room.Price
+	}
+	if err := validateLease(lease); err != nil {
+		return err
+	}
+
+	if current, err := u.leaseRepo.FindForTenantOn(tenant.ID, lease.StartDate); err != nil {
+		return err
+	} else if current != nil && current.Status == "active" && !current.EndDate.Before(truncateDay(lease.StartDate)) {
+		return entity.Conflict("lease_overlap", "tenant already has an active lease for this period; renew it instead")
+	}
+
+	lease.StartDate = truncateDay(lease.StartDate)
+	lease.EndDate = leaseEnd(lease.StartDate, lease.TermMonths)
+	lease.Status = "active"
+	lease.TerminatedAt = nil
+	lease.TerminationReason = ""
+	lease.RenewedFromID = nil
+	lease.CreatedAt = time.Now()
+	lease.UpdatedAt = time.Now()
+	if err := u.leaseRepo.Create(lease); err != nil {
+		return err
+	}
+
+	return u.repriceFirstBill(tenant, lease)
+}
+
+func (u *leaseUsecase) GetAll() ([]entity.Lease, error) {
+	return u.leaseRepo.FindAll()
+}
+
+func (u *leaseUsecase) GetByID(id uint) (*entity.Lease, error) {
+	return u.leaseRepo.FindByID(id)
+}
+
+func (u *leaseUsecase) GetByTenantID(tenantID uint) ([]entity.Lease, error) {
+	return u.leaseRepo.FindByTenantID(tenantID)
+}
+
+// Renew starts a new lease the day after the current term ends, keeping the
+// billing cycle, deposit and notice period. A zero term or rent keeps the
+// current one.
+func (u *leaseUsecase) Renew(id uint, termMonths int, rent entity.Money) (*entity.Lease, error) {
+	var renewal *entity.Lease
+	err := u.uow.Do(func(repos *repository.Repositories) error {
+		var err error
+		renewal, err = u.withRepos(repos).renew(id, termMonths, rent)
+		return err
+	})
+	return renewal, err
+}
+
+func (u *leaseUsecase) renew(id uint, termMonths int, rent entity.Money) (*entity.Lease, error) {
+	current, err := u.leaseRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status != "active" {
+		return nil, entity.Conflict("lease_not_active", "only active leases can be renewed, this one is %s", current.Status)
+	}
+
+	if termMonths == 0 {
+		termMonths = current.TermMonths
+	}
+	if rent.IsZero() {
+		rent = current.Rent
+	}
+
+	start := current.EndDate.AddDate(0, 0, 1)
+	renewal := &entity.Lease{
+		TenantID:      current.TenantID,
+		RoomID:        current.RoomID,
+		StartDate:     start,
+		EndDate:       leaseEnd(start, termMonths),
+		TermMonths:    termMonths,
+		Rent:          rent,
+		BillingCycle:  current.BillingCycle,
+		Deposit:       current.Deposit,
+		NoticeDays:    current.NoticeDays,
+		Status:        "active",
+		RenewedFromID: &current.ID,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	if err := validateLease(renewal); err != nil {
+		return nil, err
+	}
+	if err := u.leaseRepo.Create(renewal); err != nil {
+		return nil, err
+	}
+
+	current.Status = "renewed"
+	current.UpdatedAt = time.Now()
+	if err := u.leaseRepo.Update(current); err != nil {
+		return nil, err
+	}
+	return renewal, nil
+}
+
+// Terminate ends a lease before its term, giving at least the lease's notice
+// period. The tenant's end date is set so billing stops after that date; the
+// move-out itself is still done through the tenant.
+func (u *leaseUsecase) Terminate(id uint, date time.Time, reason string) (*entity.Lease, error) {
+	var lease *entity.Lease
+	err := u.uow.Do(func(repos *repository.Repositories) error {
+		var err error
+		lease, err = u.withRepos(repos).terminate(id, date, reason)
+		return err
+	})
+	return lease, err
+}
+
+func (u *leaseUsecase) terminate(id uint, date time.Time, reason string) (*entity.Lease, error) {
+	if reason == "" {
+		return nil, entity.Invalid("reason_required", "reason is required to terminate a lease")
+	}
+
+	lease, err := u.leaseRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if lease.Status != "active" {
+		return nil, entity.Conflict("lease_not_active", "only active leases can be terminated, this one is %s", lease.Status)
+	}
+
+	date = truncateDay(date)
+	if date.Before(lease.StartDate) || date.After(lease.EndDate) {
+		return nil, entity.Invalid("invalid_termination_date", "termination date must fall within the lease term")
+	}
+	if earliest := truncateDay(time.Now()).AddDate(0, 0, lease.NoticeDays); date.Before(earliest) {
+		return nil, entity.Invalid("notice_period", "lease requires %d days notice; earliest termination date is %s",
+			lease.NoticeDays, earliest.Format("2006-01-02"))
+	}
+
+	lease.Status = "terminated"
+	lease.TerminatedAt = &date
+	lease.TerminationReason = reason
+	lease.UpdatedAt = time.Now()
+	if err := u.leaseRepo.Update(lease); err != nil {
+		return nil, err
+	}
+
+	tenant, err := u.tenantRepo.FindByID(lease.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	tenant.EndDate = &date
+	tenant.UpdatedAt = time.Now()
+	if err := u.tenantRepo.Update(tenant); err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+// GetExpiring lists active leases whose term ends within the next days days.
+func (u *leaseUsecase) GetExpiring(days int) ([]entity.Lease, error) {
+	if days <= 0 {
+		return nil, entity.Invalid("invalid_days", "days must be greater than zero")
+	}
+	from := truncateDay(time.Now())
+	return u.leaseRepo.FindExpiring(from, from.AddDate(0, 0, days+1))
+}
+
+func (u *leaseUsecase) repriceFirstBill(tenant *entity.Tenant, lease *entity.Lease) error {
+	charge := u.proration.MoveIn(lease.Rent, lease.StartDate)
+	if !firstOfMonth(tenant.StartDate).Equal(firstOfMonth(lease.StartDate)) {
+		charge.Amount = lease.Rent
+	}
+
+	payment, err := u.paymentRepo.FindByTenantAndPeriod(tenant.ID, charge.Period)
+	if err != nil || payment == nil {
+		return err
+	}
+	if payment.PaidAt != nil || len(payment.Transactions) > 0 || payment.Amount.Cmp(charge.Amount) == 0 {
+		return nil
+	}
+	payment.Amount = charge.Amount
+	return u.ledger.Settle(payment)
+}
+
+func validateLease(lease *entity.Lease) error {
+	if lease.TermMonths <= 0 {
+		return entity.Invalid("invalid_term", "term_months must be greater than zero")
+	}
+	if !lease.Rent.IsPositive() {
+		return entity.Invalid("invalid_rent", "rent must be greater than zero")
+	}
+	if lease.Deposit.IsNegative() || lease.NoticeDays < 0 {
+		return entity.Invalid("invalid_lease_terms", "deposit and notice days must not be negative")
+	}
+	switch lease.BillingCycle {
+	case "":
+		lease.BillingCycle = BillingMonthly
+	case BillingMonthly, BillingQuarterly, BillingYearly:
+	default:
+		return entity.Invalid("invalid_billing_cycle", "billing_cycle must be one of monthly, quarterly, yearly")
+	}
+	return nil
+}
+
+// leaseEnd is the last day of a term of months starting on start.
+func leaseEnd(start time.Time, months int) time.Time {
+	return truncateDay(start).AddDate(0, months, -1)
+}
+
+// monthlyRent is the rent a tenant owes per month on date: the agreed rent of
+// their lease, or the room's current price when they have none.
+func monthlyRent(leaseRepo repository.LeaseRepository, tenant *entity.Tenant, date time.Time) (entity.Money, *entity.Lease, error) {
+	lease, err := leaseRepo.FindForTenantOn(tenant.ID, date)
+	if err != nil {
+		return entity.Money{}, nil, err
+	}
+	if lease != nil {
+		return lease.Rent, lease, nil
+	}
+	if tenant.Room == nil {
+		return entity.Money{}, nil, nil
+	}
+	return tenant.Room.Price, nil, nil
+}
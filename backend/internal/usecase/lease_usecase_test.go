@@ -0,0 +1,241 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLeaseCreate(t *testing.T) {
+	tests := []struct {
+		name string
+		// lease is signed by Budi, who moved into room 101 at 3,100,000 on
+		// January 1; otherRoom signs it for room 102 instead
+		lease     entity.Lease
+		otherRoom bool
+		kind      error
+		code      string
+		wantRent  string
+		wantEnd   time.Time
+		wantBill  string
+	}{
+		{"at the room price", entity.Lease{TermMonths: 12}, false, nil, "", "3100000.00", date(2026, 12, 31), "3100000.00"},
+		{"at an agreed rent", entity.Lease{TermMonths: 6, Rent: money(t, "2800000")}, false, nil, "", "2800000.00", date(2026, 6, 30), "2800000.00"},
+		{"for another room", entity.Lease{TermMonths: 12}, true, entity.ErrValidation, "room_mismatch", "", time.Time{}, "3100000.00"},
+		{"without a term", entity.Lease{}, false, entity.ErrValidation, "invalid_term", "", time.Time{}, "3100000.00"},
+		{"unknown billing cycle", entity.Lease{TermMonths: 12, BillingCycle: "weekly"}, false, entity.ErrValidation, "invalid_billing_cycle", "", time.Time{}, "3100000.00"},
+		{"negative deposit", entity.Lease{TermMonths: 12, Deposit: money(t, "-1")}, false, entity.ErrValidation, "invalid_lease_terms", "", time.Time{}, "3100000.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			room := f.room(t, "101", "3100000")
+			other := f.room(t, "102", "3100000")
+			budi := f.tenant(t, "Budi", room, date(2026, 1, 1))
+			tt.lease.TenantID = budi.ID
+			if tt.otherRoom {
+				tt.lease.RoomID = other.ID
+			}
+
+			err := f.usecases.Lease.Create(&tt.lease)
+			wantMoney(t, "January bill", f.payment(t, budi.ID, "2026-01").Amount, tt.wantBill)
+			if tt.kind != nil {
+				wantKind(t, err, tt.kind, tt.code)
+				if len(f.leases.rows) != 0 {
+					t.Errorf("leases = %+v, want none", f.leases.rows)
+				}
+				return
+			}
+			must(t, err)
+
+			lease, err := f.usecases.Lease.GetByID(tt.lease.ID)
+			must(t, err)
+			if lease.RoomID != room.ID || lease.Status != "active" || lease.BillingCycle != "monthly" ||
+				!lease.StartDate.Equal(date(2026, 1, 1)) || !lease.EndDate.Equal(tt.wantEnd) {
+				t.Errorf("lease = %+v, want an active monthly lease on room 101 from January 1 to %v", lease, tt.wantEnd)
+			}
+			wantMoney(t, "rent", lease.Rent, tt.wantRent)
+		})
+	}
+}
+
+func TestLeaseCreateRejects(t *testing.T) {
+	f := newFixture(t)
+	budi := f.tenant(t, "Budi", f.room(t, "101", "3100000"), date(2026, 1, 1))
+	must(t, f.usecases.Lease.Create(&entity.Lease{TenantID: budi.ID, TermMonths: 12}))
+	ani := &entity.Tenant{PropertyID: 1, Name: "Ani", Phone: "0813", StartDate: date(2026, 1, 1)}
+	must(t, f.usecases.Tenant.Create(ani))
+
+	tests := []struct {
+		name  string
+		lease entity.Lease
+		kind  error
+		code  string
+	}{
+		{"overlapping lease", entity.Lease{TenantID: budi.ID, StartDate: date(2026, 6, 1), TermMonths: 12}, entity.ErrConflict, "lease_overlap"},
+		{"tenant without a room", entity.Lease{TenantID: ani.ID, TermMonths: 12}, entity.ErrConflict, "tenant_not_placed"},
+		{"missing tenant", entity.Lease{TenantID: 99, TermMonths: 12}, entity.ErrNotFound, "not_found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantKind(t, f.usecases.Lease.Create(&tt.lease), tt.kind, tt.code)
+		})
+	}
+	if len(f.leases.rows) != 1 {
+		t.Errorf("leases = %+v, want only Budi's first", f.leases.rows)
+	}
+}
+
+func TestLeaseTerminate(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	start := today.AddDate(0, -2, 0)
+
+	tests := []struct {
+		name   string
+		date   time.Time
+		reason string
+		kind   error
+		code   string
+	}{
+		{"without a reason", today.AddDate(0, 0, 45), "", entity.ErrValidation, "reason_required"},
+		{"before the lease", start.AddDate(0, 0, -1), "Moving away", entity.ErrValidation, "invalid_termination_date"},
+		{"after the lease", start.AddDate(1, 0, 0), "Moving away", entity.ErrValidation, "invalid_termination_date"},
+		{"inside the notice period", today.AddDate(0, 0, 29), "Moving away", entity.ErrValidation, "notice_period"},
+		{"after the notice period", today.AddDate(0, 0, 30), "Moving away", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			budi := f.tenant(t, "Budi", f.room(t, "101", "3100000"), start)
+			lease := &entity.Lease{TenantID: budi.ID, TermMonths: 12, NoticeDays: 30}
+			must(t, f.usecases.Lease.Create(lease))
+
+			terminated, err := f.usecases.Lease.Terminate(lease.ID, tt.date, tt.reason)
+			tenant, _ := f.usecases.Tenant.GetByID(budi.ID)
+			if tt.kind != nil {
+				wantKind(t, err, tt.kind, tt.code)
+				lease, _ := f.usecases.Lease.GetByID(lease.ID)
+				if lease.Status != "active" || tenant.EndDate != nil {
+					t.Errorf("lease %s and tenant leaving on %v, want the lease active", lease.Status, tenant.EndDate)
+				}
+				return
+			}
+			must(t, err)
+			if terminated.Status != "terminated" || terminated.TerminatedAt == nil || !terminated.TerminatedAt.Equal(tt.date) ||
+				terminated.TerminationReason != tt.reason {
+				t.Errorf("lease = %+v, want terminated on %v", terminated, tt.date)
+			}
+			if tenant.EndDate == nil || !tenant.EndDate.Equal(tt.date) || tenant.Status != "active" {
+				t.Errorf("tenant = %+v, want still active and leaving on %v", tenant, tt.date)
+			}
+
+			_, err = f.usecases.Lease.Terminate(lease.ID, tt.date, tt.reason)
+			wantKind(t, err, entity.ErrConflict, "lease_not_active")
+		})
+	}
+}
+
+func TestLeaseRenew(t *testing.T) {
+	tests := []struct {
+		name     string
+		term     int
+		rent     string
+		missing  bool
+		kind     error
+		code     string
+		wantEnd  time.Time
+		wantRent string
+	}{
+		{"keeping the term and rent", 0, "0", false, nil, "", date(2027, 12, 31), "2800000.00"},
+		{"with a new term and rent", 6, "3000000", false, nil, "", date(2027, 6, 30), "3000000.00"},
+		{"negative term", -1, "0", false, entity.ErrValidation, "invalid_term", time.Time{}, ""},
+		{"negative rent", 0, "-1", false, entity.ErrValidation, "invalid_rent", time.Time{}, ""},
+		{"missing lease", 0, "0", true, entity.ErrNotFound, "not_found", time.Time{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			budi := f.tenant(t, "Budi", f.room(t, "101", "3100000"), date(2026, 1, 1))
+			lease := &entity.Lease{TenantID: budi.ID, TermMonths: 12, Rent: money(t, "2800000"),
+				BillingCycle: "quarterly", Deposit: money(t, "1000000"), NoticeDays: 30}
+			must(t, f.usecases.Lease.Create(lease))
+			id := lease.ID
+			if tt.missing {
+				id = 99
+			}
+
+			renewal, err := f.usecases.Lease.Renew(id, tt.term, money(t, tt.rent))
+			current, _ := f.usecases.Lease.GetByID(lease.ID)
+			if tt.kind != nil {
+				wantKind(t, err, tt.kind, tt.code)
+				if len(f.leases.rows) != 1 || current.Status != "active" {
+					t.Errorf("leases = %+v, want only the first, still active", f.leases.rows)
+				}
+				return
+			}
+			must(t, err)
+			if current.Status != "renewed" {
+				t.Errorf("renewed lease is %s, want renewed", current.Status)
+			}
+			if renewal.Status != "active" || renewal.RenewedFromID == nil || *renewal.RenewedFromID != lease.ID ||
+				renewal.TenantID != budi.ID || renewal.RoomID != lease.RoomID ||
+				!renewal.StartDate.Equal(date(2027, 1, 1)) || !renewal.EndDate.Equal(tt.wantEnd) {
+				t.Errorf("renewal = %+v, want an active lease renewing %d from January 1, 2027 to %v", renewal, lease.ID, tt.wantEnd)
+			}
+			if renewal.BillingCycle != "quarterly" || renewal.NoticeDays != 30 {
+				t.Errorf("renewal = %+v, want the quarterly cycle and 30 days notice kept", renewal)
+			}
+			wantMoney(t, "rent", renewal.Rent, tt.wantRent)
+			wantMoney(t, "deposit", renewal.Deposit, "1000000.00")
+
+			_, err = f.usecases.Lease.Renew(lease.ID, tt.term, money(t, tt.rent))
+			wantKind(t, err, entity.ErrConflict, "lease_not_active")
+		})
+	}
+}
+
+func TestLeaseGetExpiring(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	f := newFixture(t)
+	// Each lease ends on its day offset from today
+	for _, lease := range []struct {
+		days   int
+		status string
+	}{{-1, "active"}, {0, "active"}, {30, "active"}, {31, "active"}, {10, "renewed"}, {10, "terminated"}} {
+		must(t, f.leases.Create(&entity.Lease{TenantID: 1, RoomID: 1, StartDate: today.AddDate(-1, 0, 0),
+			EndDate: today.AddDate(0, 0, lease.days), TermMonths: 12, Status: lease.status}))
+	}
+
+	tests := []struct {
+		name string
+		days int
+		kind error
+		code string
+		want []uint
+	}{
+		{"within a week", 7, nil, "", []uint{2}},
+		{"up to the last day", 30, nil, "", []uint{2, 3}},
+		{"zero days", 0, entity.ErrValidation, "invalid_days", nil},
+		{"negative days", -7, entity.ErrValidation, "invalid_days", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leases, err := f.usecases.Lease.GetExpiring(tt.days)
+			if tt.kind != nil {
+				wantKind(t, err, tt.kind, tt.code)
+				return
+			}
+			must(t, err)
+			var got []uint
+			for _, lease := range leases {
+				got = append(got, lease.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("expiring leases = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
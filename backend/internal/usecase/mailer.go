@@ -0,0 +1,13 @@
+package usecase
+
+// Mail is a plain text email.
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers mail, e.g. over SMTP or into a log for local development.
+type Mailer interface {
+	Send(mail Mail) error
+}
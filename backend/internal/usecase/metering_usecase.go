@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"fmt"
+	"time"
+)
+
+const (
+	UtilityElectricity = "electricity"
+	UtilityWater       = "water"
+)
+
+// Metering Usecase
+type MeteringUsecase interface {
+	GetTariffs() ([]entity.UtilityTariff, error)
+	SaveTariff(tariff *entity.UtilityTariff) error
+	RecordReading(reading *entity.MeterReading) error
+	GetReadingsByRoom(roomID uint) ([]entity.MeterReading, error)
+	GetFlagged() ([]entity.MeterReading, error)
+}
+
+type meteringUsecase struct {
+	uow         repository.UnitOfWork
+	readingRepo repository.MeterReadingRepository
+	tariffRepo  repository.UtilityTariffRepository
+	roomRepo    repository.RoomRepository
+	paymentRepo repository.PaymentRepository
+	biller      *utilityBiller
+}
+
+func NewMeteringUsecase(
+	uow repository.UnitOfWork,
+	readingRepo repository.MeterReadingRepository,
+	tariffRepo repository.UtilityTariffRepository,
+	roomRepo repository.RoomRepository,
+	paymentRepo repository.PaymentRepository,
+	tenantRepo repository.TenantRepository,
+) MeteringUsecase {
+	return &meteringUsecase{
+		uow:         uow,
+		readingRepo: readingRepo,
+		tariffRepo:  tariffRepo,
+		roomRepo:    roomRepo,
+		paymentRepo: paymentRepo,
+		biller:      newUtilityBiller(readingRepo, paymentRepo, tenantRepo),
+	}
+}
+
+// withRepos returns a copy of the usecase working on repos of a unit of work.
+func (u *meteringUsecase) withRepos(repos *repository.Repositories) *meteringUsecase {
+	return &meteringUsecase{
+		uow:         u.uow,
+		readingRepo: repos.MeterReadings,
+		tariffRepo:  repos.UtilityTariffs,
+		roomRepo:    repos.Rooms,
+		paymentRepo: repos.Payments,
+		biller:      newUtilityBiller(repos.MeterReadings, repos.Payments, repos.Tenants),
+	}
+}
+
+func (u *meteringUsecase) GetTariffs() ([]entity.UtilityTariff, error) {
+	return u.tariffRepo.FindAll()
+}
+
+func (u *meteringUsecase) SaveTariff(tariff *entity.UtilityTariff) error {
+	if tariff.Utility != UtilityElectricity && tariff.Utility != UtilityWater {
+		return entity.Invalid("invalid_utility", "utility must be electricity or water")
+	}
+	if len(tariff.Tiers) == 0 {
+		return entity.Invalid("tiers_required", "at least one tariff tier is required")
+	}
+	if tariff.FixedCharge.IsNegative() || tariff.SpikeThresholdPercent < 0 {
+		return entity.Invalid("invalid_tariff", "fixed charge and spike threshold must not be negative")
+	}
+
+	var lower float64
+	for i, tier := range tariff.Tiers {
+		if tier.Rate.IsNegative() {
+			return entity.Invalid("invalid_tariff", "tier rates must not be negative")
+		}
+		if tier.UpTo == 0 {
+			if i != len(tariff.Tiers)-1 {
+				return entity.Invalid("invalid_tariff", "only the last tier may be open-ended")
+			}
+			continue
+		}
+		if tier.UpTo <= lower {
+			return entity.Invalid("invalid_tariff", "tier limits must be ascending")
+		}
+		lower = tier.UpTo
+	}
+
+	if existing, _ := u.tariffRepo.FindByUtility(tariff.Utility); existing != nil {
+		tariff.ID = existing.ID
+		tariff.CreatedAt = existing.CreatedAt
+	} else {
+		tariff.CreatedAt = time.Now()
+	}
+	tariff.UpdatedAt = time.Now()
+	return u.tariffRepo.Save(tariff)
+}
+
+// RecordReading stores a monthly meter reading and prices the consumption
+// since the previous one. A first reading only sets the baseline. Readings
+// lower than the previous one are flagged "backwards" and not charged;
+// readings whose consumption jumps past the tariff's spike threshold are
+// flagged "spike" but still charged.
+func (u *meteringUsecase) RecordReading(reading *entity.MeterReading) error {
+	return u.uow.Do(func(repos *repository.Repositories) error {
+		return u.withRepos(repos).recordReading(reading)
+	})
+}
+
+func (u *meteringUsecase) recordReading(reading *entity.MeterReading) error {
+	if _, err := time.Parse("2006-01", reading.Period); err != nil {
+		return entity.Invalid("invalid_period", "period must be in YYYY-MM format")
+	}
+	if reading.Reading < 0 {
+		return entity.Invalid("invalid_reading", "reading must not be negative")
+	}
+
+	tariff, err := u.tariffRepo.FindByUtility(reading.Utility)
+	if errors.Is(err, entity.ErrNotFound) {
+		return entity.Conflict("tariff_missing", "no tariff configured for %s", reading.Utility)
+	}
+	if err != nil {
+		return err
+	}
+
+	room, err := u.roomRepo.FindByID(reading.RoomID)
+	if err != nil {
+		return err
+	}
+
+	previous, err := u.readingRepo.FindPrevious(reading.RoomID, reading.Utility, reading.Period)
+	if err != nil {
+		return err
+	}
+
+	reading.Flag = ""
+	reading.Consumption = 0
+	reading.Charge = entity.Money{}
+	if previous != nil {
+		consumption := reading.Reading - previous.Reading
+		if consumption < 0 {
+			reading.Flag = "backwards"
+		} else {
+			reading.Consumption = consumption
+			reading.Charge = tariffCharge(tariff, consumption)
+			threshold := previous.Consumption * (1 + tariff.SpikeThresholdPercent/100)
+			if tariff.SpikeThresholdPercent > 0 && previous.Consumption > 0 && consumption > threshold {
+				reading.Flag = "spike"
+			}
+		}
+	}
+
+	reading.PaymentID = nil
+	reading.CreatedAt = time.Now()
+	reading.UpdatedAt = time.Now()
+	if err := u.readingRepo.Create(reading); err != nil {
+		return err
+	}
+
+	// Add the charge to this month's rent bill if it has already been issued
+	if room.Tenant == nil || room.Tenant.Status != "active" || !reading.Charge.IsPositive() {
+		return nil
+	}
+	payment, err := u.paymentRepo.FindByTenantAndPeriod(room.Tenant.ID, reading.Period)
+	if err != nil || payment == nil {
+		return err
+	}
+	if err := u.biller.Attach(payment, reading.RoomID); err != nil {
+		return err
+	}
+	reading.PaymentID = &payment.ID
+	return nil
+}
+
+func (u *meteringUsecase) GetReadingsByRoom(roomID uint) ([]entity.MeterReading, error) {
+	return u.readingRepo.FindByRoomID(roomID)
+}
+
+func (u *meteringUsecase) GetFlagged() ([]entity.MeterReading, error) {
+	return u.readingRepo.FindFlagged()
+}
+
+// utilityBiller moves priced meter readings onto a rent bill as line items.
+type utilityBiller struct {
+	readingRepo repository.MeterReadingRepository
+	paymentRepo repository.PaymentRepository
+	ledger      *paymentLedger
+}
+
+func newUtilityBiller(
+	readingRepo repository.MeterReadingRepository,
+	paymentRepo repository.PaymentRepository,
+	tenantRepo repository.TenantRepository,
+) *utilityBiller {
+	return &utilityBiller{
+		readingRepo: readingRepo,
+		paymentRepo: paymentRepo,
+		ledger:      newPaymentLedger(paymentRepo, tenantRepo),
+	}
+}
+
+// Attach adds every unbilled reading of the room for the bill's period.
+func (b *utilityBiller) Attach(payment *entity.Payment, roomID uint) error {
+	readings, err := b.readingRepo.FindUnbilled(roomID, payment.Period)
+	if err != nil || len(readings) == 0 {
+		return err
+	}
+
+	for _, reading := range readings {
+		item := &entity.PaymentLineItem{
+			PaymentID:   payment.ID,
+			Type:        reading.Utility,
+			Description: fmt.Sprintf("%s %s: %.2f used", reading.Utility, reading.Period, reading.Consumption),
+			Amount:      reading.Charge,
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
+		if err := b.paymentRepo.AddLineItem(item); err != nil {
+			return err
+		}
+		payment.LineItems = append(payment.LineItems, *item)
+
+		reading.PaymentID = &payment.ID
+		reading.UpdatedAt = time.Now()
+		if err := b.readingRepo.Update(&reading); err != nil {
+			return err
+		}
+	}
+
+	return b.ledger.Settle(payment)
+}
+
+// tariffCharge prices consumption against the tariff's tiers in order, each
+// tier covering consumption up to its UpTo limit.
+func tariffCharge(tariff *entity.UtilityTariff, consumption float64) entity.Money {
+	charge := tariff.FixedCharge
+	remaining := consumption
+	var lower float64
+
+	for _, tier := range tariff.Tiers {
+		if remaining <= 0 {
+			break
+		}
+		used := remaining
+		if tier.UpTo > 0 {
+			if width := tier.UpTo - lower; used > width {
+				used = width
+			}
+			lower = tier.UpTo
+		}
+		charge = charge.Add(tier.Rate.MulFloat(used))
+		remaining -= used
+	}
+	return charge
+}
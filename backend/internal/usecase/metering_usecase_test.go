@@ -0,0 +1,248 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+// tariffs holds the tariff of each utility.
+type tariffs map[string]entity.UtilityTariff
+
+var _ repository.UtilityTariffRepository = tariffs{}
+
+func (r tariffs) FindAll() ([]entity.UtilityTariff, error) {
+	found := []entity.UtilityTariff{}
+	for _, utility := range slices.Sorted(maps.Keys(r)) {
+		found = append(found, r[utility])
+	}
+	return found, nil
+}
+
+func (r tariffs) FindByUtility(utility string) (*entity.UtilityTariff, error) {
+	tariff, ok := r[utility]
+	if !ok {
+		return nil, entity.NotFound("not_found", "utility tariff not found")
+	}
+	return &tariff, nil
+}
+
+func (r tariffs) Save(tariff *entity.UtilityTariff) error {
+	if tariff.ID == 0 {
+		tariff.ID = uint(len(r) + 1)
+	}
+	r[tariff.Utility] = *tariff
+	return nil
+}
+
+func tier(t *testing.T, upTo float64, rate string) entity.TariffTier {
+	t.Helper()
+	return entity.TariffTier{UpTo: upTo, Rate: money(t, rate)}
+}
+
+func TestMeteringSaveTariff(t *testing.T) {
+	tests := []struct {
+		name   string
+		tariff entity.UtilityTariff
+		code   string
+	}{
+		{"tiered", entity.UtilityTariff{Utility: "electricity", Tiers: []entity.TariffTier{tier(t, 100, "1000"), tier(t, 0, "1500")}}, ""},
+		{"flat", entity.UtilityTariff{Utility: "water", FixedCharge: money(t, "10000"), Tiers: []entity.TariffTier{tier(t, 0, "5000")}}, ""},
+		{"unknown utility", entity.UtilityTariff{Utility: "gas", Tiers: []entity.TariffTier{tier(t, 0, "1000")}}, "invalid_utility"},
+		{"without tiers", entity.UtilityTariff{Utility: "water"}, "tiers_required"},
+		{"negative fixed charge", entity.UtilityTariff{Utility: "water", FixedCharge: money(t, "-1"), Tiers: []entity.TariffTier{tier(t, 0, "5000")}}, "invalid_tariff"},
+		{"negative spike threshold", entity.UtilityTariff{Utility: "water", SpikeThresholdPercent: -1, Tiers: []entity.TariffTier{tier(t, 0, "5000")}}, "invalid_tariff"},
+		{"negative rate", entity.UtilityTariff{Utility: "water", Tiers: []entity.TariffTier{tier(t, 0, "-5000")}}, "invalid_tariff"},
+		{"open tier before the last", entity.UtilityTariff{Utility: "water", Tiers: []entity.TariffTier{tier(t, 0, "1000"), tier(t, 100, "1500")}}, "invalid_tariff"},
+		{"descending limits", entity.UtilityTariff{Utility: "water", Tiers: []entity.TariffTier{tier(t, 100, "1000"), tier(t, 50, "1500")}}, "invalid_tariff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			err := f.usecases.Metering.SaveTariff(&tt.tariff)
+			if tt.code != "" {
+				wantKind(t, err, entity.ErrValidation, tt.code)
+				if all, _ := f.usecases.Metering.GetTariffs(); len(all) != 0 {
+					t.Errorf("tariffs = %+v, want none", all)
+				}
+				return
+			}
+			must(t, err)
+
+			// Saving again replaces the utility's tariff
+			replacement := entity.UtilityTariff{Utility: tt.tariff.Utility, Tiers: []entity.TariffTier{tier(t, 0, "2000")}}
+			must(t, f.usecases.Metering.SaveTariff(&replacement))
+			all, err := f.usecases.Metering.GetTariffs()
+			must(t, err)
+			if len(all) != 1 || all[0].ID != tt.tariff.ID || !all[0].CreatedAt.Equal(tt.tariff.CreatedAt) || len(all[0].Tiers) != 1 {
+				t.Errorf("tariffs = %+v, want the replacement of %+v", all, tt.tariff)
+			}
+		})
+	}
+}
+
+func TestMeteringRecordReading(t *testing.T) {
+	tests := []struct {
+		name string
+		// readings are recorded on room 101 for January, February and so on;
+		// the last one is checked
+		readings        []float64
+		wantConsumption float64
+		wantCharge      string
+		wantFlag        string
+	}{
+		{"first reading", []float64{1200}, 0, "0.00", ""},
+		{"consumption", []float64{1200, 1300}, 100, "100000.00", ""},
+		{"backwards", []float64{1200, 1300, 1250}, 0, "0.00", "backwards"},
+		{"within the spike threshold", []float64{1200, 1300, 1450}, 150, "150000.00", ""},
+		{"spike", []float64{1200, 1300, 1451}, 151, "151000.00", "spike"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			room := f.room(t, "101", "1500000")
+			must(t, f.usecases.Metering.SaveTariff(&entity.UtilityTariff{
+				Utility:               "electricity",
+				SpikeThresholdPercent: 50,
+				Tiers:                 []entity.TariffTier{tier(t, 0, "1000")},
+			}))
+
+			var reading *entity.MeterReading
+			for i, value := range tt.readings {
+				reading = &entity.MeterReading{RoomID: room.ID, Utility: "electricity", Period: date(2026, 1, 1).AddDate(0, i, 0).Format("2006-01"), Reading: value}
+				must(t, f.usecases.Metering.RecordReading(reading))
+			}
+			if reading.Consumption != tt.wantConsumption || reading.Flag != tt.wantFlag {
+				t.Errorf("reading = %+v, want %.0f used and flag %q", reading, tt.wantConsumption, tt.wantFlag)
+			}
+			wantMoney(t, "charge", reading.Charge, tt.wantCharge)
+
+			readings, err := f.usecases.Metering.GetReadingsByRoom(room.ID)
+			must(t, err)
+			if len(readings) != len(tt.readings) {
+				t.Errorf("%d readings, want %d", len(readings), len(tt.readings))
+			}
+			flagged, err := f.usecases.Metering.GetFlagged()
+			must(t, err)
+			if (len(flagged) == 1) != (tt.wantFlag != "") {
+				t.Errorf("flagged = %+v, want flag %q", flagged, tt.wantFlag)
+			}
+		})
+	}
+}
+
+func TestMeteringRecordReadingRejects(t *testing.T) {
+	f := newFixture(t)
+	room := f.room(t, "101", "1500000")
+	must(t, f.usecases.Metering.SaveTariff(&entity.UtilityTariff{Utility: "electricity", Tiers: []entity.TariffTier{tier(t, 0, "1000")}}))
+
+	tests := []struct {
+		name    string
+		reading entity.MeterReading
+		kind    error
+		code    string
+	}{
+		{"bad period", entity.MeterReading{RoomID: room.ID, Utility: "electricity", Period: "January", Reading: 10}, entity.ErrValidation, "invalid_period"},
+		{"negative reading", entity.MeterReading{RoomID: room.ID, Utility: "electricity", Period: "2026-01", Reading: -1}, entity.ErrValidation, "invalid_reading"},
+		{"utility without a tariff", entity.MeterReading{RoomID: room.ID, Utility: "water", Period: "2026-01", Reading: 10}, entity.ErrConflict, "tariff_missing"},
+		{"missing room", entity.MeterReading{RoomID: 99, Utility: "electricity", Period: "2026-01", Reading: 10}, entity.ErrNotFound, "not_found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantKind(t, f.usecases.Metering.RecordReading(&tt.reading), tt.kind, tt.code)
+		})
+	}
+	if len(f.readings.rows) != 0 {
+		t.Errorf("readings = %+v, want none", f.readings.rows)
+	}
+}
+
+func TestMeteringTieredCharge(t *testing.T) {
+	// 20,000 a month, then 1,000 a kWh up to 50, 1,500 up to 150 and 2,000
+	// above
+	tariff := func(t *testing.T) *entity.UtilityTariff {
+		return &entity.UtilityTariff{
+			Utility:     "electricity",
+			FixedCharge: money(t, "20000"),
+			Tiers:       []entity.TariffTier{tier(t, 50, "1000"), tier(t, 150, "1500"), tier(t, 0, "2000")},
+		}
+	}
+	tests := []struct {
+		name string
+		used float64
+		want string
+	}{
+		{"nothing used", 0, "20000.00"},
+		{"within the first tier", 30, "50000.00"},
+		{"fraction of a unit", 12.5, "32500.00"},
+		{"up to the first limit", 50, "70000.00"},
+		{"into the second tier", 120, "175000.00"},
+		{"up to the second limit", 150, "220000.00"},
+		{"into the open tier", 200, "320000.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			room := f.room(t, "101", "1500000")
+			must(t, f.usecases.Metering.SaveTariff(tariff(t)))
+			must(t, f.usecases.Metering.RecordReading(&entity.MeterReading{RoomID: room.ID, Utility: "electricity", Period: "2026-01", Reading: 1000}))
+
+			reading := &entity.MeterReading{RoomID: room.ID, Utility: "electricity", Period: "2026-02", Reading: 1000 + tt.used}
+			must(t, f.usecases.Metering.RecordReading(reading))
+			wantMoney(t, "charge", reading.Charge, tt.want)
+		})
+	}
+}
+
+func TestMeteringBillsReading(t *testing.T) {
+	tests := []struct {
+		name string
+		// period is when the 120 kWh were read; Budi's only bill is for
+		// January
+		period       string
+		wantOnBill   bool
+		wantBalance  string
+		wantUnbilled int
+	}{
+		{"period with a bill", "2026-01", true, "1675000.00", 0},
+		{"period without a bill", "2026-02", false, "1500000.00", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			room := f.room(t, "101", "1500000")
+			budi := f.tenant(t, "Budi", room, date(2026, 1, 1))
+			must(t, f.usecases.Metering.SaveTariff(&entity.UtilityTariff{
+				Utility:     "electricity",
+				FixedCharge: money(t, "20000"),
+				Tiers:       []entity.TariffTier{tier(t, 50, "1000"), tier(t, 150, "1500"), tier(t, 0, "2000")},
+			}))
+			previous, err := time.Parse("2006-01", tt.period)
+			must(t, err)
+			must(t, f.usecases.Metering.RecordReading(&entity.MeterReading{RoomID: room.ID, Utility: "electricity", Period: previous.AddDate(0, -1, 0).Format("2006-01"), Reading: 1000}))
+
+			reading := &entity.MeterReading{RoomID: room.ID, Utility: "electricity", Period: tt.period, Reading: 1120}
+			must(t, f.usecases.Metering.RecordReading(reading))
+			bill := f.payment(t, budi.ID, "2026-01")
+			if (reading.PaymentID != nil) != tt.wantOnBill {
+				t.Errorf("reading billed on %v, want billed: %v", reading.PaymentID, tt.wantOnBill)
+			}
+			if tt.wantOnBill {
+				if *reading.PaymentID != bill.ID || len(bill.LineItems) != 1 || bill.LineItems[0].Type != "electricity" {
+					t.Errorf("bill = %+v, want the electricity charge on it", bill)
+				}
+				wantMoney(t, "line item", bill.LineItems[0].Amount, "175000.00")
+			}
+			wantMoney(t, "January balance", bill.Balance(), tt.wantBalance)
+
+			unbilled, err := f.repos.MeterReadings.FindUnbilled(room.ID, tt.period)
+			must(t, err)
+			if len(unbilled) != tt.wantUnbilled {
+				t.Errorf("unbilled readings = %+v, want %d", unbilled, tt.wantUnbilled)
+			}
+		})
+	}
+}
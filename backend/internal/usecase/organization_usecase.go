@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"time"
+)
+
+// Organization Usecase
+type OrganizationUsecase interface {
+	GetAll() ([]entity.Organization, error)
+	GetByID(id uint) (*entity.Organization, error)
+	Update(organization *entity.Organization) error
+}
+
+type organizationUsecase struct {
+	organizationRepo repository.OrganizationRepository
+}
+
+func NewOrganizationUsecase(organizationRepo repository.OrganizationRepository) OrganizationUsecase {
+	return &organizationUsecase{organizationRepo: organizationRepo}
+}
+
+func (u *organizationUsecase) GetAll() ([]entity.Organization, error) {
+	return u.organizationRepo.FindAll()
+}
+
+func (u *organizationUsecase) GetByID(id uint) (*entity.Organization, error) {
+	return u.organizationRepo.FindByID(id)
+}
+
+func (u *organizationUsecase) Update(organization *entity.Organization) error {
+	existing, err := u.organizationRepo.FindByID(organization.ID)
+	if err != nil {
+		return err
+	}
+	if organization.Name == "" {
+		return entity.Invalid("name_required", "name is required")
+	}
+	organization.CreatedAt = existing.CreatedAt
+	organization.UpdatedAt = time.Now()
+	return u.organizationRepo.Update(organization)
+}
@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/usecase"
+	"testing"
+)
+
+type organizations struct {
+	rows []entity.Organization
+}
+
+var _ repository.OrganizationRepository = (*organizations)(nil)
+
+func (o *organizations) Create(organization *entity.Organization) error {
+	organization.ID = uint(len(o.rows) + 1)
+	o.rows = append(o.rows, *organization)
+	return nil
+}
+
+func (o *organizations) FindAll() ([]entity.Organization, error) { return o.rows, nil }
+
+func (o *organizations) FindByID(id uint) (*entity.Organization, error) {
+	for _, organization := range o.rows {
+		if organization.ID == id {
+			return &organization, nil
+		}
+	}
+	return nil, entity.NotFound("not_found", "organization not found")
+}
+
+func (o *organizations) Update(organization *entity.Organization) error {
+	for i := range o.rows {
+		if o.rows[i].ID == organization.ID {
+			o.rows[i] = *organization
+			return nil
+		}
+	}
+	return entity.NotFound("not_found", "organization not found")
+}
+
+func TestOrganizationUpdate(t *testing.T) {
+	created := date(2026, 1, 1)
+	repo := &organizations{}
+	must(t, repo.Create(&entity.Organization{Name: "Kos Melati", CreatedAt: created}))
+	must(t, repo.Create(&entity.Organization{Name: "Kos Mawar", CreatedAt: created}))
+	organizations := usecase.NewOrganizationUsecase(repo)
+
+	tests := []struct {
+		name         string
+		organization entity.Organization
+		kind         error
+		code         string
+	}{
+		{"missing organization", entity.Organization{ID: 99, Name: "Kos Anggrek"}, entity.ErrNotFound, "not_found"},
+		{"without a name", entity.Organization{ID: 1}, entity.ErrValidation, "name_required"},
+		{"renamed", entity.Organization{ID: 1, Name: "Kos Melati Indah", RequireTwoFactor: true}, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := organizations.Update(&tt.organization)
+			if tt.kind != nil {
+				wantKind(t, err, tt.kind, tt.code)
+				return
+			}
+			must(t, err)
+
+			got, err := organizations.GetByID(tt.organization.ID)
+			must(t, err)
+			if got.Name != tt.organization.Name || !got.RequireTwoFactor || !got.CreatedAt.Equal(created) {
+				t.Errorf("organization = %+v, want %+v created at %v", got, tt.organization, created)
+			}
+		})
+	}
+
+	all, err := organizations.GetAll()
+	must(t, err)
+	if len(all) != 2 || all[0].Name != "Kos Melati Indah" || all[1].Name != "Kos Mawar" {
+		t.Errorf("organizations = %+v, want Kos Melati Indah and Kos Mawar", all)
+	}
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import "ezkost/internal/domain/repository"
+
+// Limits on how many rows one page of a room, tenant, payment or expense
+// list returns
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 500
+)
+
+func limitPage(page *repository.Page) {
+	if page.Limit <= 0 {
+		page.Limit = DefaultListLimit
+	}
+	page.Limit = min(page.Limit, MaxListLimit)
+	page.Offset = max(page.Offset, 0)
+}
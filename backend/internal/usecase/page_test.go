@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"fmt"
+	"testing"
+)
+
+func TestListPageBounds(t *testing.T) {
+	const rows = 510
+	f := newFixture(t)
+	for i := 1; i <= rows; i++ {
+		must(t, f.repos.Rooms.Create(&entity.Room{PropertyID: 1, RoomNumber: fmt.Sprint(i), Price: money(t, "1000000")}))
+		must(t, f.repos.Tenants.Create(&entity.Tenant{PropertyID: 1, Name: "Tenant", Phone: "0812", StartDate: date(2026, 1, 1)}))
+		must(t, f.repos.Payments.Create(&entity.Payment{PropertyID: 1, TenantID: 1, Amount: money(t, "1000000"),
+			DueDate: date(2026, 1, 1).AddDate(0, i, 0), Period: fmt.Sprint(i)}))
+		must(t, f.repos.Expenses.Create(&entity.Expense{PropertyID: 1, Description: "Air", Amount: money(t, "1000"), ExpenseDate: date(2026, 1, 1)}))
+	}
+
+	// Each list returns the ids of one page and the number of rows
+	lists := map[string]func(page repository.Page) ([]uint, int64, error){
+		"rooms": func(page repository.Page) ([]uint, int64, error) {
+			found, total, err := f.usecases.Room.GetAll(repository.RoomQuery{Page: page})
+			return ids(found, func(r entity.Room) uint { return r.ID }), total, err
+		},
+		"tenants": func(page repository.Page) ([]uint, int64, error) {
+			found, total, err := f.usecases.Tenant.GetAll(repository.TenantQuery{Page: page})
+			return ids(found, func(r entity.Tenant) uint { return r.ID }), total, err
+		},
+		"payments": func(page repository.Page) ([]uint, int64, error) {
+			found, total, err := f.usecases.Payment.GetAll(repository.PaymentQuery{Page: page})
+			return ids(found, func(r entity.Payment) uint { return r.ID }), total, err
+		},
+		"expenses": func(page repository.Page) ([]uint, int64, error) {
+			found, total, err := f.usecases.Expense.GetAll(repository.ExpenseQuery{Page: page})
+			return ids(found, func(r entity.Expense) uint { return r.ID }), total, err
+		},
+	}
+
+	tests := []struct {
+		name      string
+		page      repository.Page
+		wantCount int
+		wantFirst uint
+	}{
+		{"default limit", repository.Page{}, 50, 1},
+		{"negative limit", repository.Page{Limit: -1}, 50, 1},
+		{"own limit", repository.Page{Limit: 10, Offset: 20}, 10, 21},
+		{"capped limit", repository.Page{Limit: 1000}, 500, 1},
+		{"negative offset", repository.Page{Limit: 10, Offset: -5}, 10, 1},
+		{"last page", repository.Page{Limit: 10, Offset: 505}, 5, 506},
+		{"past the end", repository.Page{Offset: 1000}, 0, 0},
+		{"sorted descending", repository.Page{Limit: 1000, Sort: []repository.Sort{{Field: "id", Desc: true}}}, 500, 510},
+	}
+	for list, find := range lists {
+		for _, tt := range tests {
+			t.Run(list+" "+tt.name, func(t *testing.T) {
+				found, total, err := find(tt.page)
+				must(t, err)
+				if total != rows {
+					t.Errorf("total = %d, want %d", total, rows)
+				}
+				var first uint
+				if len(found) > 0 {
+					first = found[0]
+				}
+				if len(found) != tt.wantCount || first != tt.wantFirst {
+					t.Errorf("got %d rows from %d, want %d from %d", len(found), first, tt.wantCount, tt.wantFirst)
+				}
+			})
+		}
+	}
+}
+
+func ids[T any](rows []T, id func(T) uint) []uint {
+	found := make([]uint, len(rows))
+	for i, row := range rows {
+		found[i] = id(row)
+	}
+	return found
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"time"
+)
+
+// paymentLedger records money against bills and keeps each bill's status in
+// line with what has been paid: unpaid, partial, then paid (or late).
+type paymentLedger struct {
+	paymentRepo repository.PaymentRepository
+	tenantRepo  repository.TenantRepository
+}
+
+func newPaymentLedger(paymentRepo repository.PaymentRepository, tenantRepo repository.TenantRepository) *paymentLedger {
+	return &paymentLedger{
+		paymentRepo: paymentRepo,
+		tenantRepo:  tenantRepo,
+	}
+}
+
+// Pay records a transaction on the bill. Anything above the outstanding
+// balance is moved to the tenant's credit for their next bill.
+func (l *paymentLedger) Pay(payment *entity.Payment, amount entity.Money, method string, paidAt time.Time, note string) (*entity.PaymentTransaction, error) {
+	if !amount.IsPositive() {
+		return nil, entity.Invalid("invalid_amount", "amount must be greater than zero")
+	}
+
+	overpaid := amount.Sub(payment.Balance())
+
+	transaction := &entity.PaymentTransaction{
+		PaymentID: payment.ID,
+		Amount:    amount,
+		Method:    method,
+		PaidAt:    paidAt,
+		Note:      note,
+		CreatedAt: time.Now(),
+	}
+	if err := l.paymentRepo.AddTransaction(transaction); err != nil {
+		return nil, err
+	}
+	payment.Transactions = append(payment.Transactions, *transaction)
+
+	if overpaid.IsPositive() {
+		if err := l.tenantRepo.AdjustCredit(payment.TenantID, overpaid); err != nil {
+			return nil, err
+		}
+	}
+
+	return transaction, l.Settle(payment)
+}
+
+// ApplyCredit pays a newly created bill from the tenant's credit balance.
+func (l *paymentLedger) ApplyCredit(payment *entity.Payment) error {
+	tenant, err := l.tenantRepo.FindByID(payment.TenantID)
+	if err != nil {
+		return err
+	}
+
+	amount := entity.MinMoney(tenant.CreditBalance, payment.Balance())
+	if !amount.IsPositive() {
+		return nil
+	}
+
+	if err := l.tenantRepo.AdjustCredit(tenant.ID, amount.Neg()); err != nil {
+		return err
+	}
+	_, err = l.Pay(payment, amount, "credit", time.Now(), "Applied from tenant credit")
+	return err
+}
+
+// CreditOverpayment moves to the tenant's credit what a bill whose total was
+// lowered is now paid above it. Pay credited the overpayment before the
+// change already, so only what exceeds before is credited.
+func (l *paymentLedger) CreditOverpayment(payment *entity.Payment, before entity.Money) error {
+	credit := overpayment(payment).Sub(before)
+	if !credit.IsPositive() {
+		return nil
+	}
+	return l.tenantRepo.AdjustCredit(payment.TenantID, credit)
+}
+
+// Settle derives the bill status from its transactions and stores it.
+func (l *paymentLedger) Settle(payment *entity.Payment) error {
+	switch {
+	case !payment.AmountPaid().IsPositive():
+		payment.Status = "unpaid"
+		payment.PaidAt = nil
+	case payment.Balance().IsPositive():
+		payment.Status = "partial"
+		payment.PaidAt = nil
+	default:
+		paidAt := payment.Transactions[len(payment.Transactions)-1].PaidAt
+		payment.Status = "paid"
+		payment.PaidAt = &paidAt
+	}
+	payment.UpdatedAt = time.Now()
+	return l.paymentRepo.Update(payment)
+}
+
+// overpayment is what was paid above the bill's total, never negative.
+func overpayment(payment *entity.Payment) entity.Money {
+	overpaid := payment.AmountPaid().Sub(payment.TotalDue())
+	if overpaid.IsNegative() {
+		return overpaid.Sub(overpaid)
+	}
+	return overpaid
+}
@@ -9,33 +9,70 @@ import (
 // Payment Usecase
 type PaymentUsecase interface {
 	Create(payment *entity.Payment) error
-	GetAll() ([]entity.Payment, error)
+	GetAll(query repository.PaymentQuery) ([]entity.Payment, int64, error)
 	GetByID(id uint) (*entity.Payment, error)
 	GetByTenantID(tenantID uint) ([]entity.Payment, error)
 	GetOverdue() ([]entity.Payment, error)
 	Update(payment *entity.Payment) error
+	RecordTransaction(paymentID uint, transaction *entity.PaymentTransaction) (*entity.Payment, error)
 }
 
 type paymentUsecase struct {
+	uow         repository.UnitOfWork
 	paymentRepo repository.PaymentRepository
 	tenantRepo  repository.TenantRepository
+	ledger      *paymentLedger
 }
 
-func NewPaymentUsecase(paymentRepo repository.PaymentRepository, tenantRepo repository.TenantRepository) PaymentUsecase {
+func NewPaymentUsecase(
+	uow repository.UnitOfWork,
+	paymentRepo repository.PaymentRepository,
+	tenantRepo repository.TenantRepository,
+) PaymentUsecase {
 	return &paymentUsecase{
+		uow:         uow,
 		paymentRepo: paymentRepo,
 		tenantRepo:  tenantRepo,
+		ledger:      newPaymentLedger(paymentRepo, tenantRepo),
+	}
+}
+
+// withRepos returns a copy of the usecase working on repos of a unit of work.
+func (u *paymentUsecase) withRepos(repos *repository.Repositories) *paymentUsecase {
+	return &paymentUsecase{
+		uow:         u.uow,
+		paymentRepo: repos.Payments,
+		tenantRepo:  repos.Tenants,
+		ledger:      newPaymentLedger(repos.Payments, repos.Tenants),
 	}
 }
 
 func (u *paymentUsecase) Create(payment *entity.Payment) error {
+	return u.uow.Do(func(repos *repository.Repositories) error {
+		return u.withRepos(repos).create(payment)
+	})
+}
+
+func (u *paymentUsecase) create(payment *entity.Payment) error {
+	tenant, err := u.tenantRepo.FindByID(payment.TenantID)
+	if err != nil {
+		return err
+	}
+
+	payment.PropertyID = tenant.PropertyID
 	payment.CreatedAt = time.Now()
 	payment.UpdatedAt = time.Now()
-	return u.paymentRepo.Create(payment)
+	payment.Status = "unpaid"
+	payment.PaidAt = nil
+	if err := u.paymentRepo.Create(payment); err != nil {
+		return err
+	}
+	return u.ledger.ApplyCredit(payment)
 }
 
-func (u *paymentUsecase) GetAll() ([]entity.Payment, error) {
-	return u.paymentRepo.FindAll()
+func (u *paymentUsecase) GetAll(query repository.PaymentQuery) ([]entity.Payment, int64, error) {
+	limitPage(&query.Page)
+	return u.paymentRepo.Find(query)
 }
 
 func (u *paymentUsecase) GetByID(id uint) (*entity.Payment, error) {
@@ -50,7 +87,54 @@ func (u *paymentUsecase) GetOverdue() ([]entity.Payment, error) {
 	return u.paymentRepo.FindOverdue(time.Now())
 }
 
+// Update edits the bill itself. Status is derived from transactions; setting
+// PaidAt on a bill that still has a balance records one transaction for the
+// rest of it, so marking a bill paid in one step keeps working.
 func (u *paymentUsecase) Update(payment *entity.Payment) error {
-	payment.UpdatedAt = time.Now()
-	return u.paymentRepo.Update(payment)
+	return u.uow.Do(func(repos *repository.Repositories) error {
+		return u.withRepos(repos).update(payment)
+	})
+}
+
+func (u *paymentUsecase) update(payment *entity.Payment) error {
+	existing, err := u.paymentRepo.FindByID(payment.ID)
+	if err != nil {
+		return err
+	}
+
+	// Who and which month a bill is for stay as issued, so billing runs
+	// keep recognising it
+	paidAt := payment.PaidAt
+	payment.PropertyID = existing.PropertyID
+	payment.TenantID = existing.TenantID
+	payment.Period = existing.Period
+	payment.CreatedAt = existing.CreatedAt
+	payment.LineItems = existing.LineItems
+	payment.Transactions = existing.Transactions
+
+	if paidAt != nil && payment.Balance().IsPositive() {
+		_, err := u.ledger.Pay(payment, payment.Balance(), payment.PaymentMethod, *paidAt, "")
+		return err
+	}
+	return u.ledger.Settle(payment)
+}
+
+func (u *paymentUsecase) RecordTransaction(paymentID uint, transaction *entity.PaymentTransaction) (*entity.Payment, error) {
+	if transaction.PaidAt.IsZero() {
+		transaction.PaidAt = time.Now()
+	}
+
+	err := u.uow.Do(func(repos *repository.Repositories) error {
+		tx := u.withRepos(repos)
+		payment, err := tx.paymentRepo.FindByID(paymentID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ledger.Pay(payment, transaction.Amount, transaction.Method, transaction.PaidAt, transaction.Note)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.paymentRepo.FindByID(paymentID)
 }
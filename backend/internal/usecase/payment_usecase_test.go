@@ -0,0 +1,242 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// bill creates a tenant without a room and a 1,000,000 bill for February,
+// due in ten days so paying it in full makes it paid rather than late.
+func bill(t *testing.T, f *fixture, credit string) (*entity.Tenant, *entity.Payment) {
+	t.Helper()
+	tenant := &entity.Tenant{PropertyID: 1, Name: "Budi", Phone: "0812", StartDate: date(2026, 1, 1)}
+	must(t, f.repos.Tenants.Create(tenant))
+	if credit != "" {
+		must(t, f.repos.Tenants.AdjustCredit(tenant.ID, money(t, credit)))
+	}
+	payment := &entity.Payment{TenantID: tenant.ID, Amount: money(t, "1000000"), DueDate: time.Now().AddDate(0, 0, 10), Period: "2026-02"}
+	must(t, f.usecases.Payment.Create(payment))
+	return tenant, payment
+}
+
+func TestPaymentCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		credit      string
+		wantStatus  string
+		wantBalance string
+		wantCredit  string
+	}{
+		{"no credit", "", "unpaid", "1000000.00", "0.00"},
+		{"part paid from credit", "250000.50", "partial", "749999.50", "0.00"},
+		{"paid from credit", "1200000", "paid", "0.00", "200000.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			tenant, payment := bill(t, f, tt.credit)
+
+			found, err := f.usecases.Payment.GetByID(payment.ID)
+			must(t, err)
+			if found.Status != tt.wantStatus || found.PropertyID != 1 {
+				t.Errorf("status = %q, property = %d, want %q, 1", found.Status, found.PropertyID, tt.wantStatus)
+			}
+			wantMoney(t, "balance", found.Balance(), tt.wantBalance)
+			tenant, err = f.repos.Tenants.FindByID(tenant.ID)
+			must(t, err)
+			wantMoney(t, "credit", tenant.CreditBalance, tt.wantCredit)
+		})
+	}
+
+	f := newFixture(t)
+	err := f.usecases.Payment.Create(&entity.Payment{TenantID: 99, Amount: money(t, "1000000"), DueDate: date(2026, 2, 10)})
+	wantKind(t, err, entity.ErrNotFound, "not_found")
+}
+
+func TestPaymentRecordTransaction(t *testing.T) {
+	tests := []struct {
+		name        string
+		amounts     []string
+		wantStatus  string
+		wantBalance string
+		wantCredit  string
+	}{
+		{"partial", []string{"400000"}, "partial", "600000.00", "0.00"},
+		{"paid in two", []string{"400000", "600000"}, "paid", "0.00", "0.00"},
+		{"overpaid", []string{"1250000.50"}, "paid", "0.00", "250000.50"},
+		{"overpaid after partial", []string{"400000", "700000"}, "paid", "0.00", "100000.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			tenant, payment := bill(t, f, "")
+
+			var paid *entity.Payment
+			for i, amount := range tt.amounts {
+				var err error
+				paid, err = f.usecases.Payment.RecordTransaction(payment.ID, &entity.PaymentTransaction{
+					Amount: money(t, amount),
+					Method: "transfer",
+					PaidAt: date(2026, 2, 5+i),
+				})
+				must(t, err)
+			}
+
+			if paid.Status != tt.wantStatus || len(paid.Transactions) != len(tt.amounts) {
+				t.Errorf("status = %q with %d transactions, want %q with %d",
+					paid.Status, len(paid.Transactions), tt.wantStatus, len(tt.amounts))
+			}
+			wantMoney(t, "balance", paid.Balance(), tt.wantBalance)
+			if tt.wantStatus == "paid" && (paid.PaidAt == nil || !paid.PaidAt.Equal(date(2026, 2, 4+len(tt.amounts)))) {
+				t.Errorf("paid at = %v, want the last transaction", paid.PaidAt)
+			}
+			tenant, err := f.repos.Tenants.FindByID(tenant.ID)
+			must(t, err)
+			wantMoney(t, "credit", tenant.CreditBalance, tt.wantCredit)
+		})
+	}
+}
+
+func TestPaymentStatusTransitions(t *testing.T) {
+	f := newFixture(t)
+	tenant, payment := bill(t, f, "")
+
+	// Each step pays amount into the 1,000,000 bill; the first pays nothing
+	steps := []struct {
+		amount      string
+		wantStatus  string
+		wantBalance string
+		wantCredit  string
+	}{
+		{"", "unpaid", "1000000.00", "0.00"},
+		{"300000", "partial", "700000.00", "0.00"},
+		{"200000", "partial", "500000.00", "0.00"},
+		{"500000", "paid", "0.00", "0.00"},
+		{"100000", "paid", "0.00", "100000.00"},
+	}
+	for i, step := range steps {
+		if step.amount != "" {
+			_, err := f.usecases.Payment.RecordTransaction(payment.ID, &entity.PaymentTransaction{
+				Amount: money(t, step.amount),
+				Method: "cash",
+				PaidAt: date(2026, 2, 1+i),
+			})
+			must(t, err)
+		}
+		found, err := f.usecases.Payment.GetByID(payment.ID)
+		must(t, err)
+		if found.Status != step.wantStatus || (found.PaidAt != nil) != (step.wantStatus == "paid") {
+			t.Errorf("step %d: status %q paid at %v, want %q", i, found.Status, found.PaidAt, step.wantStatus)
+		}
+		wantMoney(t, fmt.Sprintf("step %d balance", i), found.Balance(), step.wantBalance)
+		tenant, err := f.repos.Tenants.FindByID(tenant.ID)
+		must(t, err)
+		wantMoney(t, fmt.Sprintf("step %d credit", i), tenant.CreditBalance, step.wantCredit)
+	}
+}
+
+func TestPaymentRecordTransactionRejects(t *testing.T) {
+	f := newFixture(t)
+	_, payment := bill(t, f, "")
+
+	tests := []struct {
+		name      string
+		paymentID uint
+		amount    string
+		kind      error
+		code      string
+	}{
+		{"zero amount", payment.ID, "0", entity.ErrValidation, "invalid_amount"},
+		{"negative amount", payment.ID, "-1", entity.ErrValidation, "invalid_amount"},
+		{"missing bill", 99, "1000", entity.ErrNotFound, "not_found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.usecases.Payment.RecordTransaction(tt.paymentID, &entity.PaymentTransaction{Amount: money(t, tt.amount), Method: "cash"})
+			wantKind(t, err, tt.kind, tt.code)
+		})
+	}
+
+	found, err := f.usecases.Payment.GetByID(payment.ID)
+	must(t, err)
+	if found.Status != "unpaid" || len(found.Transactions) != 0 {
+		t.Errorf("rejected transactions changed the bill: %+v", found)
+	}
+}
+
+func TestPaymentUpdateMarksPaid(t *testing.T) {
+	tests := []struct {
+		name       string
+		due        time.Time
+		wantStatus string
+	}{
+		{"before the due date", time.Now().AddDate(0, 0, 1), "paid"},
+		{"past the due date", time.Now().AddDate(0, 0, -1), "late"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			_, payment := bill(t, f, "")
+			_, err := f.usecases.Payment.RecordTransaction(payment.ID, &entity.PaymentTransaction{Amount: money(t, "400000"), Method: "cash", PaidAt: date(2026, 2, 1)})
+			must(t, err)
+
+			paidAt := date(2026, 2, 10)
+			payment.DueDate = tt.due
+			payment.PaidAt = &paidAt
+			payment.PaymentMethod = "transfer"
+			must(t, f.usecases.Payment.Update(payment))
+
+			found, err := f.usecases.Payment.GetByID(payment.ID)
+			must(t, err)
+			if found.Status != tt.wantStatus || len(found.Transactions) != 2 {
+				t.Errorf("status = %q with %d transactions, want %q with 2", found.Status, len(found.Transactions), tt.wantStatus)
+			}
+			wantMoney(t, "last transaction", found.Transactions[len(found.Transactions)-1].Amount, "600000.00")
+		})
+	}
+}
+
+// A PUT body carries only the editable fields; the bill must still belong to
+// its tenant and month afterwards, or the next billing run issues it again.
+func TestPaymentUpdateKeepsBillingIdempotent(t *testing.T) {
+	f := newFixture(t)
+	room := f.room(t, "101", "3100000")
+	tenant := f.tenant(t, "Budi", room, date(2026, 1, 15))
+	_, err := f.usecases.Billing.RunMonthly(date(2026, 2, 1))
+	must(t, err)
+	issued := f.payment(t, tenant.ID, "2026-02")
+
+	tests := []struct {
+		name   string
+		update entity.Payment
+	}{
+		{"new amount", entity.Payment{Amount: money(t, "3000000"), DueDate: date(2026, 2, 20)}},
+		{"other tenant and month", entity.Payment{TenantID: 99, PropertyID: 2, Period: "2026-05", Amount: money(t, "3000000"), DueDate: date(2026, 2, 20)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := tt.update
+			update.ID = issued.ID
+			must(t, f.usecases.Payment.Update(&update))
+
+			found, err := f.usecases.Payment.GetByID(issued.ID)
+			must(t, err)
+			if found.TenantID != tenant.ID || found.PropertyID != 1 || found.Period != "2026-02" {
+				t.Errorf("bill = tenant %d, property %d, period %q, want tenant %d, property 1, period 2026-02",
+					found.TenantID, found.PropertyID, found.Period, tenant.ID)
+			}
+			if !found.CreatedAt.Equal(issued.CreatedAt) {
+				t.Errorf("created at = %v, want %v", found.CreatedAt, issued.CreatedAt)
+			}
+			wantMoney(t, "amount", found.Amount, "3000000.00")
+
+			again, err := f.usecases.Billing.RunMonthly(date(2026, 2, 1))
+			must(t, err)
+			if len(again.Created) != 0 || again.Skipped != 1 {
+				t.Errorf("billing again created %d and skipped %d, want the tenant skipped", len(again.Created), again.Skipped)
+			}
+		})
+	}
+}
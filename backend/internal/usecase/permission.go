@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Permission actions. Route groups check resource:action, e.g. "rooms:delete".
+const (
+	ActionRead   = "read"
+	ActionWrite  = "write"
+	ActionDelete = "delete"
+)
+
+// PermissionMatrix grants each role a list of permissions. A permission is
+// "resource:action"; either side may be "*", and "*" alone grants everything.
+type PermissionMatrix map[string][]string
+
+// DefaultPermissions is used when no permission file is configured. Staff run
+// the day-to-day operation but cannot delete records, touch expenses or see
+// the dashboard's income and profit; accountants handle money; viewers only
+// read. Reading users and the audit log is left to owners.
+func DefaultPermissions() PermissionMatrix {
+	readable := []string{
+		"dashboard:read", "organization:read", "properties:read",
+		"rooms:read", "tenants:read", "leases:read", "deposits:read",
+		"payments:read", "expenses:read", "late-fees:read",
+		"metering:read", "settings:read",
+	}
+	return PermissionMatrix{
+		"owner": {"*"},
+		"staff": {
+			"organization:read", "properties:read",
+			"rooms:read", "rooms:write",
+			"tenants:read", "tenants:write",
+			"leases:read", "leases:write",
+			"deposits:read", "deposits:write",
+			"payments:read", "payments:write",
+			"metering:read", "metering:write",
+			"billing:write", "late-fees:read", "settings:read",
+		},
+		"accountant": append([]string{
+			"payments:write", "payments:waive",
+			"deposits:write",
+			"expenses:write", "expenses:delete",
+			"billing:write", "late-fees:write",
+		}, readable...),
+		"viewer": readable,
+	}
+}
+
+// ParsePermissions reads a matrix from JSON such as
+// {"owner": ["*"], "staff": ["rooms:read"]}.
+func ParsePermissions(data []byte) (PermissionMatrix, error) {
+	var matrix PermissionMatrix
+	if err := json.Unmarshal(data, &matrix); err != nil {
+		return nil, fmt.Errorf("invalid permission matrix: %w", err)
+	}
+	if len(matrix) == 0 {
+		return nil, errors.New("permission matrix defines no roles")
+	}
+	for role, permissions := range matrix {
+		for _, permission := range permissions {
+			if permission != "*" && strings.Count(permission, ":") != 1 {
+				return nil, fmt.Errorf("role %s: permission %q must be resource:action", role, permission)
+			}
+		}
+	}
+	return matrix, nil
+}
+
+// Allows reports whether role holds permission.
+func (m PermissionMatrix) Allows(role, permission string) bool {
+	resource, action, _ := strings.Cut(permission, ":")
+	for _, granted := range m[role] {
+		if granted == "*" || granted == permission {
+			return true
+		}
+		grantedResource, grantedAction, _ := strings.Cut(granted, ":")
+		if (grantedResource == "*" || grantedResource == resource) &&
+			(grantedAction == "*" || grantedAction == action) {
+			return true
+		}
+	}
+	return false
+}
+
+// Roles lists the roles the matrix knows about.
+func (m PermissionMatrix) Roles() []string {
+	roles := make([]string, 0, len(m))
+	for role := range m {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
@@ -0,0 +1,40 @@
+package usecase_test
+
+import (
+	"ezkost/internal/usecase"
+	"testing"
+)
+
+func TestDefaultPermissions(t *testing.T) {
+	tests := []struct {
+		role       string
+		permission string
+		want       bool
+	}{
+		{"owner", "users:write", true},
+		{"owner", "audit:read", true},
+		{"staff", "rooms:write", true},
+		{"staff", "rooms:delete", false},
+		{"staff", "dashboard:read", false},
+		{"accountant", "payments:waive", true},
+		{"accountant", "expenses:delete", true},
+		{"accountant", "tenants:read", true},
+		{"accountant", "tenants:write", false},
+		{"accountant", "users:read", false},
+		{"accountant", "audit:read", false},
+		{"viewer", "dashboard:read", true},
+		{"viewer", "settings:read", true},
+		{"viewer", "payments:write", false},
+		{"viewer", "users:read", false},
+		{"viewer", "audit:read", false},
+		{"stranger", "rooms:read", false},
+	}
+	permissions := usecase.DefaultPermissions()
+	for _, tt := range tests {
+		t.Run(tt.role+" "+tt.permission, func(t *testing.T) {
+			if got := permissions.Allows(tt.role, tt.permission); got != tt.want {
+				t.Errorf("Allows(%q, %q) = %v, want %v", tt.role, tt.permission, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"time"
+)
+
+// Property Usecase
+type PropertyUsecase interface {
+	Create(property *entity.Property) error
+	GetAll() ([]entity.Property, error)
+	GetByID(id uint) (*entity.Property, error)
+	Update(property *entity.Property) error
+	Delete(id uint) error
+}
+
+type propertyUsecase struct {
+	propertyRepo repository.PropertyRepository
+}
+
+func NewPropertyUsecase(propertyRepo repository.PropertyRepository) PropertyUsecase {
+	return &propertyUsecase{propertyRepo: propertyRepo}
+}
+
+func (u *propertyUsecase) Create(property *entity.Property) error {
+	if err := validateProperty(property); err != nil {
+		return err
+	}
+	property.CreatedAt = time.Now()
+	property.UpdatedAt = time.Now()
+	return u.propertyRepo.Create(property)
+}
+
+func (u *propertyUsecase) GetAll() ([]entity.Property, error) {
+	return u.propertyRepo.FindAll()
+}
+
+func (u *propertyUsecase) GetByID(id uint) (*entity.Property, error) {
+	return u.propertyRepo.FindByID(id)
+}
+
+func (u *propertyUsecase) Update(property *entity.Property) error {
+	existing, err := u.propertyRepo.FindByID(property.ID)
+	if err != nil {
+		return err
+	}
+	if err := validateProperty(property); err != nil {
+		return err
+	}
+	property.CreatedAt = existing.CreatedAt
+	property.UpdatedAt = time.Now()
+	return u.propertyRepo.Update(property)
+}
+
+// Delete only removes properties without rooms.
+func (u *propertyUsecase) Delete(id uint) error {
+	rooms, err := u.propertyRepo.CountRooms(id)
+	if err != nil {
+		return err
+	}
+	if rooms > 0 {
+		return entity.Conflict("property_in_use", "property still has rooms")
+	}
+	return u.propertyRepo.Delete(id)
+}
+
+func validateProperty(property *entity.Property) error {
+	if property.Name == "" {
+		return entity.Invalid("name_required", "name is required")
+	}
+	if property.Floors < 1 {
+		property.Floors = 1
+	}
+	if property.ReceiptPrefix == "" {
+		property.ReceiptPrefix = "RCP"
+	}
+	if len(property.ReceiptPrefix) > 10 {
+		return entity.Invalid("invalid_receipt_prefix", "receipt prefix must be at most 10 characters")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"testing"
+)
+
+func TestPropertyCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		property   entity.Property
+		wantFloors int
+		wantPrefix string
+		code       string
+	}{
+		{"defaults", entity.Property{Name: "Kos Anggrek"}, 1, "RCP", ""},
+		{"keeps its settings", entity.Property{Name: "Kos Anggrek", Floors: 4, ReceiptPrefix: "ANG"}, 4, "ANG", ""},
+		{"without a name", entity.Property{Floors: 2}, 0, "", "name_required"},
+		{"long receipt prefix", entity.Property{Name: "Kos Anggrek", ReceiptPrefix: "ANGGREK-KOS"}, 0, "", "invalid_receipt_prefix"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			err := f.usecases.Property.Create(&tt.property)
+			if tt.code != "" {
+				wantKind(t, err, entity.ErrValidation, tt.code)
+				properties, err := f.usecases.Property.GetAll()
+				must(t, err)
+				if len(properties) != 2 {
+					t.Errorf("properties = %+v, want only the two of the fixture", properties)
+				}
+				return
+			}
+			must(t, err)
+
+			got, err := f.usecases.Property.GetByID(tt.property.ID)
+			must(t, err)
+			if got.Floors != tt.wantFloors || got.ReceiptPrefix != tt.wantPrefix || got.CreatedAt.IsZero() {
+				t.Errorf("property = %+v, want %d floors and prefix %q", got, tt.wantFloors, tt.wantPrefix)
+			}
+		})
+	}
+}
+
+func TestPropertyUpdate(t *testing.T) {
+	f := newFixture(t)
+	created := &entity.Property{Name: "Kos Anggrek", Floors: 2}
+	must(t, f.usecases.Property.Create(created))
+
+	tests := []struct {
+		name     string
+		property entity.Property
+		kind     error
+		code     string
+	}{
+		{"missing property", entity.Property{ID: 99, Name: "Kos Anggrek"}, entity.ErrNotFound, "not_found"},
+		{"without a name", entity.Property{ID: created.ID}, entity.ErrValidation, "name_required"},
+		{"renamed", entity.Property{ID: created.ID, Name: "Kos Anggrek Baru", Floors: 3}, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := f.usecases.Property.Update(&tt.property)
+			if tt.kind != nil {
+				wantKind(t, err, tt.kind, tt.code)
+				return
+			}
+			must(t, err)
+
+			got, err := f.usecases.Property.GetByID(created.ID)
+			must(t, err)
+			if got.Name != tt.property.Name || got.Floors != tt.property.Floors || !got.CreatedAt.Equal(created.CreatedAt) {
+				t.Errorf("property = %+v, want %+v created at %v", got, tt.property, created.CreatedAt)
+			}
+		})
+	}
+}
+
+func TestPropertyDelete(t *testing.T) {
+	tests := []struct {
+		name string
+		id   uint
+		kind error
+		code string
+	}{
+		{"with rooms", 1, entity.ErrConflict, "property_in_use"},
+		{"missing property", 99, entity.ErrNotFound, "not_found"},
+		{"without rooms", 2, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.room(t, "101", "1500000")
+
+			err := f.usecases.Property.Delete(tt.id)
+			if tt.kind != nil {
+				wantKind(t, err, tt.kind, tt.code)
+				return
+			}
+			must(t, err)
+			_, err = f.usecases.Property.GetByID(tt.id)
+			wantKind(t, err, entity.ErrNotFound, "not_found")
+		})
+	}
+}
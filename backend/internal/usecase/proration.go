@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"ezkost/internal/domain/entity"
+	"time"
+)
+
+const (
+	ProrationBasisCalendar = "calendar"
+	ProrationBasis30Days   = "30day"
+)
+
+// ProrationPolicy decides how a partial month of rent is charged.
+// Basis selects the daily rate divisor (actual days in the month, or a flat
+// 30 days) and RoundTo rounds the result to the nearest multiple, e.g. 1000 IDR.
+type ProrationPolicy struct {
+	Basis   string
+	RoundTo entity.Money
+}
+
+type ProratedCharge struct {
+	Period    string       `json:"period"`
+	From      time.Time    `json:"from"`
+	To        time.Time    `json:"to"`
+	Days      int          `json:"days"`
+	DailyRate entity.Money `json:"daily_rate"`
+	Amount    entity.Money `json:"amount"`
+}
+
+type ProrationPreview struct {
+	RoomID  uint            `json:"room_id"`
+	Price   entity.Money    `json:"price"`
+	Basis   string          `json:"basis"`
+	MoveIn  *ProratedCharge `json:"move_in,omitempty"`
+	MoveOut *ProratedCharge `json:"move_out,omitempty"`
+}
+
+// MoveIn charges from the move-in date up to the end of that calendar month.
+func (p ProrationPolicy) MoveIn(price entity.Money, start time.Time) ProratedCharge {
+	from := truncateDay(start)
+	to := firstOfMonth(from).AddDate(0, 1, -1)
+	return p.charge(price, from, to)
+}
+
+// MoveOut charges from the first of the calendar month up to the move-out date.
+func (p ProrationPolicy) MoveOut(price entity.Money, end time.Time) ProratedCharge {
+	to := truncateDay(end)
+	from := firstOfMonth(to)
+	return p.charge(price, from, to)
+}
+
+// Between charges for the inclusive day range from..to, which must fall in a
+// single calendar month.
+func (p ProrationPolicy) Between(price entity.Money, from, to time.Time) ProratedCharge {
+	return p.charge(price, truncateDay(from), truncateDay(to))
+}
+
+func (p ProrationPolicy) charge(price entity.Money, from, to time.Time) ProratedCharge {
+	daysInMonth := firstOfMonth(from).AddDate(0, 1, -1).Day()
+	days := int(to.Sub(from).Hours()/24) + 1
+	if days < 0 {
+		days = 0
+	}
+
+	divisor := int64(daysInMonth)
+	if p.Basis == ProrationBasis30Days {
+		divisor = 30
+	}
+
+	amount := price.MulDiv(int64(days), divisor)
+	if days >= daysInMonth || amount.GreaterThan(price) {
+		amount = price
+	}
+	amount = amount.RoundTo(p.RoundTo)
+
+	return ProratedCharge{
+		Period:    from.Format("2006-01"),
+		From:      from,
+		To:        to,
+		Days:      days,
+		DailyRate: price.MulDiv(1, divisor),
+		Amount:    amount,
+	}
+}
@@ -0,0 +1,100 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/repository/memory"
+	"ezkost/internal/usecase"
+	"testing"
+	"time"
+)
+
+func TestProrationPolicy(t *testing.T) {
+	calendar := usecase.ProrationPolicy{Basis: usecase.ProrationBasisCalendar}
+	thirtyDays := usecase.ProrationPolicy{Basis: usecase.ProrationBasis30Days}
+	rounded := func(policy usecase.ProrationPolicy, unit string) usecase.ProrationPolicy {
+		policy.RoundTo = money(t, unit)
+		return policy
+	}
+
+	tests := []struct {
+		name   string
+		policy usecase.ProrationPolicy
+		price  string
+		charge func(p usecase.ProrationPolicy, price entity.Money) usecase.ProratedCharge
+		// want is the number of days charged, the daily rate and the amount
+		wantDays  int
+		wantRate  string
+		wantTotal string
+	}{
+		{"calendar move-in in January", calendar, "3100000", moveIn(date(2026, 1, 15)), 17, "100000.00", "1700000.00"},
+		{"calendar move-in in February", calendar, "3000000", moveIn(date(2026, 2, 15)), 14, "107142.86", "1500000.00"},
+		{"calendar move-in in a leap February", calendar, "2900000", moveIn(date(2028, 2, 15)), 15, "100000.00", "1500000.00"},
+		{"calendar move-out", calendar, "3100000", moveOut(date(2026, 3, 10)), 10, "100000.00", "1000000.00"},
+		{"calendar days between", calendar, "1550000", between(date(2026, 1, 11), date(2026, 1, 30)), 20, "50000.00", "1000000.00"},
+		{"calendar whole month", calendar, "3100000", moveIn(date(2026, 1, 1)), 31, "100000.00", "3100000.00"},
+		{"30-day move-in in January", thirtyDays, "3000000", moveIn(date(2026, 1, 15)), 17, "100000.00", "1700000.00"},
+		{"30-day move-in in February", thirtyDays, "3000000", moveIn(date(2026, 2, 15)), 14, "100000.00", "1400000.00"},
+		{"30-day move-out", thirtyDays, "3000000", moveOut(date(2026, 3, 10)), 10, "100000.00", "1000000.00"},
+		{"30-day whole 31-day month stays at the price", thirtyDays, "3000000", moveIn(date(2026, 1, 1)), 31, "100000.00", "3000000.00"},
+		{"30-day whole February charges the price", thirtyDays, "3000000", moveOut(date(2026, 2, 28)), 28, "100000.00", "3000000.00"},
+		{"30-day thirty days of a 31-day month", thirtyDays, "3000000", between(date(2026, 1, 1), date(2026, 1, 30)), 30, "100000.00", "3000000.00"},
+		{"no rounding", calendar, "1000000", moveIn(date(2026, 1, 15)), 17, "32258.06", "548387.10"},
+		{"rounded to 1000", rounded(calendar, "1000"), "1000000", moveIn(date(2026, 1, 15)), 17, "32258.06", "548000.00"},
+		{"rounded to 5000", rounded(calendar, "5000"), "1000000", moveIn(date(2026, 1, 15)), 17, "32258.06", "550000.00"},
+		{"rounded half away from zero", rounded(thirtyDays, "1000"), "45000", moveIn(date(2026, 4, 30)), 1, "1500.00", "2000.00"},
+		{"30-day rounded", rounded(thirtyDays, "1000"), "3100000", moveIn(date(2026, 1, 15)), 17, "103333.33", "1757000.00"},
+		{"end before start", calendar, "3100000", between(date(2026, 1, 15), date(2026, 1, 10)), 0, "100000.00", "0.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			charge := tt.charge(tt.policy, money(t, tt.price))
+			if charge.Days != tt.wantDays {
+				t.Errorf("%d days, want %d", charge.Days, tt.wantDays)
+			}
+			wantMoney(t, "daily rate", charge.DailyRate, tt.wantRate)
+			wantMoney(t, "amount", charge.Amount, tt.wantTotal)
+		})
+	}
+}
+
+func moveIn(start time.Time) func(usecase.ProrationPolicy, entity.Money) usecase.ProratedCharge {
+	return func(p usecase.ProrationPolicy, price entity.Money) usecase.ProratedCharge {
+		return p.MoveIn(price, start)
+	}
+}
+
+func moveOut(end time.Time) func(usecase.ProrationPolicy, entity.Money) usecase.ProratedCharge {
+	return func(p usecase.ProrationPolicy, price entity.Money) usecase.ProratedCharge {
+		return p.MoveOut(price, end)
+	}
+}
+
+func between(from, to time.Time) func(usecase.ProrationPolicy, entity.Money) usecase.ProratedCharge {
+	return func(p usecase.ProrationPolicy, price entity.Money) usecase.ProratedCharge {
+		return p.Between(price, from, to)
+	}
+}
+
+// TestTenantCreateProration bills a tenant moving in on February 15 under
+// each basis, rounded to 1000.
+func TestTenantCreateProration(t *testing.T) {
+	tests := []struct {
+		basis string
+		want  string
+	}{
+		{usecase.ProrationBasisCalendar, "1650000.00"},
+		{usecase.ProrationBasis30Days, "1540000.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.basis, func(t *testing.T) {
+			f := newFixture(t)
+			policy := usecase.ProrationPolicy{Basis: tt.basis, RoundTo: money(t, "1000")}
+			tenants := usecase.NewTenantUsecase(memory.NewUnitOfWork(f.repos), f.repos.Tenants, f.repos.Rooms, f.repos.Payments, f.repos.Leases, f.repos.Occupancies, policy)
+			room := f.room(t, "101", "3300000")
+
+			budi := &entity.Tenant{Name: "Budi", Phone: "0812", RoomID: &room.ID, StartDate: date(2026, 2, 15)}
+			must(t, tenants.Create(budi))
+			wantMoney(t, "February bill", f.payment(t, budi.ID, "2026-02").Amount, tt.want)
+		})
+	}
+}
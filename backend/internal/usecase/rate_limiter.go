@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// RateLimiter hands out requests from a token bucket per key, e.g. per IP
+// address or per account. The in-memory implementation can be swapped for a
+// shared store when several instances run.
+type RateLimiter interface {
+	// Allow takes a token for key, or reports how long until one is
+	// available.
+	Allow(key string) (ok bool, retryAfter time.Duration, err error)
+}
+
+// RetryError is returned when a request was refused for now, because of a
+// rate limit or a locked account, and may be retried after RetryAfter.
+type RetryError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%s; try again in %d seconds", e.Reason, e.RetrySeconds())
+}
+
+// RetrySeconds rounds RetryAfter up to whole seconds, as used by the
+// Retry-After header.
+func (e *RetryError) RetrySeconds() int {
+	return int(math.Max(1, math.Ceil(e.RetryAfter.Seconds())))
+}
@@ -9,35 +9,62 @@ import (
 // Room Usecase
 type RoomUsecase interface {
 	Create(room *entity.Room) error
-	GetAll() ([]entity.Room, error)
+	GetAll(query repository.RoomQuery) ([]entity.Room, int64, error)
 	GetByID(id uint) (*entity.Room, error)
 	Update(room *entity.Room) error
 	Delete(id uint) error
+	GetHistory(id uint) ([]entity.Occupancy, error)
 }
 
 type roomUsecase struct {
-	roomRepo repository.RoomRepository
+	roomRepo      repository.RoomRepository
+	propertyRepo  repository.PropertyRepository
+	occupancyRepo repository.OccupancyRepository
 }
 
-func NewRoomUsecase(roomRepo repository.RoomRepository) RoomUsecase {
-	return &roomUsecase{roomRepo: roomRepo}
+func NewRoomUsecase(
+	roomRepo repository.RoomRepository,
+	propertyRepo repository.PropertyRepository,
+	occupancyRepo repository.OccupancyRepository,
+) RoomUsecase {
+	return &roomUsecase{
+		roomRepo:      roomRepo,
+		propertyRepo:  propertyRepo,
+		occupancyRepo: occupancyRepo,
+	}
 }
 
 func (u *roomUsecase) Create(room *entity.Room) error {
+	if err := u.checkPlacement(room); err != nil {
+		return err
+	}
 	room.CreatedAt = time.Now()
 	room.UpdatedAt = time.Now()
 	return u.roomRepo.Create(room)
 }
 
-func (u *roomUsecase) GetAll() ([]entity.Room, error) {
-	return u.roomRepo.FindAll()
+func (u *roomUsecase) GetAll(query repository.RoomQuery) ([]entity.Room, int64, error) {
+	limitPage(&query.Page)
+	return u.roomRepo.Find(query)
 }
 
 func (u *roomUsecase) GetByID(id uint) (*entity.Room, error) {
 	return u.roomRepo.FindByID(id)
 }
 
+// Update refuses to move a room with a tenant to another property, which
+// would leave the tenant and their bills behind in the old one.
 func (u *roomUsecase) Update(room *entity.Room) error {
+	if err := u.checkPlacement(room); err != nil {
+		return err
+	}
+	existing, err := u.roomRepo.FindByID(room.ID)
+	if err != nil {
+		return err
+	}
+	if existing.PropertyID != room.PropertyID && existing.Tenant != nil {
+		return entity.Conflict("room_occupied", "room %s has a tenant and cannot move to another property", existing.RoomNumber)
+	}
 	room.UpdatedAt = time.Now()
 	return u.roomRepo.Update(room)
 }
@@ -45,3 +72,27 @@ func (u *roomUsecase) Update(room *entity.Room) error {
 func (u *roomUsecase) Delete(id uint) error {
 	return u.roomRepo.Delete(id)
 }
+
+// GetHistory lists every stay in the room, most recent first.
+func (u *roomUsecase) GetHistory(id uint) ([]entity.Occupancy, error) {
+	if _, err := u.roomRepo.FindByID(id); err != nil {
+		return nil, err
+	}
+	return u.occupancyRepo.FindByRoomID(id)
+}
+
+// checkPlacement makes sure the room belongs to a property and sits on one of
+// its floors.
+func (u *roomUsecase) checkPlacement(room *entity.Room) error {
+	if room.PropertyID == 0 {
+		return entity.Invalid("property_required", "property is required")
+	}
+	property, err := u.propertyRepo.FindByID(room.PropertyID)
+	if err != nil {
+		return err
+	}
+	if room.Floor < 0 || room.Floor > property.Floors {
+		return entity.Invalid("invalid_floor", "floor must be between 0 and %d", property.Floors)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"testing"
+)
+
+func TestRoomCreate(t *testing.T) {
+	tests := []struct {
+		name string
+		room entity.Room
+		kind error
+		code string
+	}{
+		{"on the top floor", entity.Room{PropertyID: 1, RoomNumber: "301", Floor: 3}, nil, ""},
+		{"without a property", entity.Room{RoomNumber: "101", Floor: 1}, entity.ErrValidation, "property_required"},
+		{"missing property", entity.Room{PropertyID: 99, RoomNumber: "101", Floor: 1}, entity.ErrNotFound, "not_found"},
+		{"below the ground floor", entity.Room{PropertyID: 1, RoomNumber: "B01", Floor: -1}, entity.ErrValidation, "invalid_floor"},
+		{"above the top floor", entity.Room{PropertyID: 2, RoomNumber: "201", Floor: 2}, entity.ErrValidation, "invalid_floor"},
+		{"taken room number", entity.Room{PropertyID: 1, RoomNumber: "101", Floor: 1}, entity.ErrConflict, "duplicate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			f.room(t, "101", "1500000")
+			tt.room.Price = money(t, "1500000")
+
+			err := f.usecases.Room.Create(&tt.room)
+			if tt.kind != nil {
+				wantKind(t, err, tt.kind, tt.code)
+				_, total, err := f.usecases.Room.GetAll(repository.RoomQuery{})
+				must(t, err)
+				if total != 1 {
+					t.Errorf("%d rooms, want only room 101", total)
+				}
+				return
+			}
+			must(t, err)
+			got, err := f.usecases.Room.GetByID(tt.room.ID)
+			must(t, err)
+			if got.RoomNumber != tt.room.RoomNumber || got.Status != "empty" {
+				t.Errorf("room = %+v, want an empty room %s", got, tt.room.RoomNumber)
+			}
+		})
+	}
+}
+
+func TestRoomUpdate(t *testing.T) {
+	tests := []struct {
+		name string
+		// change is made to room 101, which lies on the first floor of Kos
+		// Melati; occupied moves Budi into it first
+		change   func(room *entity.Room)
+		occupied bool
+		kind     error
+		code     string
+	}{
+		{"new price and floor", func(room *entity.Room) { room.Price, room.Floor = money(t, "1750000"), 2 }, false, nil, ""},
+		{"occupied room's price", func(room *entity.Room) { room.Price = money(t, "1750000") }, true, nil, ""},
+		{"to another property", func(room *entity.Room) { room.PropertyID = 2 }, false, nil, ""},
+		{"occupied room to another property", func(room *entity.Room) { room.PropertyID = 2 }, true, entity.ErrConflict, "room_occupied"},
+		{"above the top floor", func(room *entity.Room) { room.Floor = 4 }, false, entity.ErrValidation, "invalid_floor"},
+		{"to a missing property", func(room *entity.Room) { room.PropertyID = 99 }, false, entity.ErrNotFound, "not_found"},
+		{"missing room", func(room *entity.Room) { room.ID = 99 }, false, entity.ErrNotFound, "not_found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			room := f.room(t, "101", "1500000")
+			room.Floor = 1
+			must(t, f.repos.Rooms.Update(room))
+			if tt.occupied {
+				f.tenant(t, "Budi", room, date(2026, 1, 1))
+			}
+			want, err := f.usecases.Room.GetByID(room.ID)
+			must(t, err)
+
+			changed := *want
+			tt.change(&changed)
+			err = f.usecases.Room.Update(&changed)
+			if tt.kind != nil {
+				wantKind(t, err, tt.kind, tt.code)
+			} else {
+				must(t, err)
+				want = &changed
+			}
+
+			got, err := f.usecases.Room.GetByID(room.ID)
+			must(t, err)
+			if got.PropertyID != want.PropertyID || got.Floor != want.Floor || got.Price.Cmp(want.Price) != 0 {
+				t.Errorf("room = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestRoomDelete(t *testing.T) {
+	tests := []struct {
+		name string
+		// occupied moves a tenant into the room first
+		occupied bool
+		id       uint
+		kind     error
+		code     string
+	}{
+		{"empty room", false, 1, nil, ""},
+		{"occupied room", true, 1, entity.ErrConflict, "in_use"},
+		{"missing room", false, 99, entity.ErrNotFound, "not_found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			room := f.room(t, "101", "1500000")
+			if tt.occupied {
+				f.tenant(t, "Budi", room, date(2026, 1, 1))
+			}
+
+			err := f.usecases.Room.Delete(tt.id)
+			if tt.kind != nil {
+				wantKind(t, err, tt.kind, tt.code)
+				_, err = f.usecases.Room.GetByID(room.ID)
+				must(t, err)
+				return
+			}
+			must(t, err)
+			_, err = f.usecases.Room.GetByID(room.ID)
+			wantKind(t, err, entity.ErrNotFound, "not_found")
+		})
+	}
+}
+
+func TestRoomHistory(t *testing.T) {
+	f := newFixture(t)
+	room := f.room(t, "101", "3100000")
+	other := f.room(t, "102", "3100000")
+	budi := f.tenant(t, "Budi", room, date(2026, 1, 1))
+	_, err := f.usecases.Tenant.Transfer(budi.ID, other.ID, date(2026, 3, 11), "")
+	must(t, err)
+	ani := f.tenant(t, "Ani", room, date(2026, 3, 15))
+
+	stays, err := f.usecases.Room.GetHistory(room.ID)
+	must(t, err)
+	if len(stays) != 2 {
+		t.Fatalf("stays = %+v, want Ani's and Budi's", stays)
+	}
+	if stays[0].TenantID != ani.ID || stays[0].EndDate != nil {
+		t.Errorf("latest stay = %+v, want Ani's, still open", stays[0])
+	}
+	if stays[1].TenantID != budi.ID || stays[1].EndDate == nil || !stays[1].EndDate.Equal(date(2026, 3, 10)) {
+		t.Errorf("first stay = %+v, want Budi's until March 10", stays[1])
+	}
+
+	_, err = f.usecases.Room.GetHistory(99)
+	wantKind(t, err, entity.ErrNotFound, "not_found")
+}
@@ -9,78 +9,468 @@ import (
 // Tenant Usecase
 type TenantUsecase interface {
 	Create(tenant *entity.Tenant) error
-	GetAll() ([]entity.Tenant, error)
+	GetAll(query repository.TenantQuery) ([]entity.Tenant, int64, error)
 	GetByID(id uint) (*entity.Tenant, error)
 	Update(oldRoomID *uint, tenant *entity.Tenant) error
 	Delete(id uint) error
+	Transfer(id, roomID uint, date time.Time, reason string) (*entity.Tenant, error)
 }
 
 type tenantUsecase struct {
-	tenantRepo repository.TenantRepository
-	roomRepo   repository.RoomRepository
+	uow           repository.UnitOfWork
+	tenantRepo    repository.TenantRepository
+	roomRepo      repository.RoomRepository
+	paymentRepo   repository.PaymentRepository
+	leaseRepo     repository.LeaseRepository
+	occupancyRepo repository.OccupancyRepository
+	proration     ProrationPolicy
+	ledger        *paymentLedger
 }
 
-func NewTenantUsecase(tenantRepo repository.TenantRepository, roomRepo repository.RoomRepository) TenantUsecase {
+func NewTenantUsecase(
+	uow repository.UnitOfWork,
+	tenantRepo repository.TenantRepository,
+	roomRepo repository.RoomRepository,
+	paymentRepo repository.PaymentRepository,
+	leaseRepo repository.LeaseRepository,
+	occupancyRepo repository.OccupancyRepository,
+	proration ProrationPolicy,
+) TenantUsecase {
 	return &tenantUsecase{
-		tenantRepo: tenantRepo,
-		roomRepo:   roomRepo,
+		uow:           uow,
+		tenantRepo:    tenantRepo,
+		roomRepo:      roomRepo,
+		paymentRepo:   paymentRepo,
+		leaseRepo:     leaseRepo,
+		occupancyRepo: occupancyRepo,
+		proration:     proration,
+		ledger:        newPaymentLedger(paymentRepo, tenantRepo),
+	}
+}
+
+// withRepos returns a copy of the usecase working on repos of a unit of work.
+func (u *tenantUsecase) withRepos(repos *repository.Repositories) *tenantUsecase {
+	return &tenantUsecase{
+		uow:           u.uow,
+		tenantRepo:    repos.Tenants,
+		roomRepo:      repos.Rooms,
+		paymentRepo:   repos.Payments,
+		leaseRepo:     repos.Leases,
+		occupancyRepo: repos.Occupancies,
+		proration:     u.proration,
+		ledger:        newPaymentLedger(repos.Payments, repos.Tenants),
 	}
 }
 
 func (u *tenantUsecase) Create(tenant *entity.Tenant) error {
+	return u.uow.Do(func(repos *repository.Repositories) error {
+		return u.withRepos(repos).create(tenant)
+	})
+}
+
+func (u *tenantUsecase) create(tenant *entity.Tenant) error {
 	tenant.CreatedAt = time.Now()
 	tenant.UpdatedAt = time.Now()
+	tenant.CreditBalance = entity.Money{}
 
-	// Update room status to occupied
+	// Move into the room, which must still be vacant
 	if tenant.RoomID != nil {
+		room, err := u.vacantRoom(*tenant.RoomID)
+		if err != nil {
+			return err
+		}
+		tenant.PropertyID = room.PropertyID
 		if err := u.roomRepo.UpdateStatus(*tenant.RoomID, "occupied"); err != nil {
 			return err
 		}
 	}
+	if tenant.PropertyID == 0 {
+		return entity.Invalid("property_required", "property is required for a tenant without a room")
+	}
+
+	if err := u.tenantRepo.Create(tenant); err != nil {
+		return err
+	}
 
-	return u.tenantRepo.Create(tenant)
+	// Bill the partial first month
+	if tenant.RoomID != nil {
+		if err := u.openStay(tenant.ID, *tenant.RoomID, tenant.StartDate, "Move-in"); err != nil {
+			return err
+		}
+		return u.billMoveIn(tenant)
+	}
+	return nil
 }
 
-func (u *tenantUsecase) GetAll() ([]entity.Tenant, error) {
-	return u.tenantRepo.FindAll()
+func (u *tenantUsecase) GetAll(query repository.TenantQuery) ([]entity.Tenant, int64, error) {
+	limitPage(&query.Page)
+	return u.tenantRepo.Find(query)
 }
 
 func (u *tenantUsecase) GetByID(id uint) (*entity.Tenant, error) {
 	return u.tenantRepo.FindByID(id)
 }
 
+// Update saves the tenant's details. Changing the room goes through the same
+// workflow as Transfer, effective today.
 func (u *tenantUsecase) Update(oldRoomID *uint, tenant *entity.Tenant) error {
+	return u.uow.Do(func(repos *repository.Repositories) error {
+		return u.withRepos(repos).update(oldRoomID, tenant)
+	})
+}
+
+func (u *tenantUsecase) update(oldRoomID *uint, tenant *entity.Tenant) error {
 	tenant.UpdatedAt = time.Now()
+	now := time.Now()
 
-	// Update old room to empty
-	if oldRoomID != nil {
-		if err := u.roomRepo.UpdateStatus(*oldRoomID, "empty"); err != nil {
+	existing, err := u.tenantRepo.FindByID(tenant.ID)
+	if err != nil {
+		return err
+	}
+	if tenant.PropertyID == 0 {
+		tenant.PropertyID = existing.PropertyID
+	}
+	if tenant.RoomID != nil {
+		room, err := u.roomRepo.FindByID(*tenant.RoomID)
+		if err != nil {
 			return err
 		}
+		tenant.PropertyID = room.PropertyID
 	}
 
-	// Update new room to occupied
-	if tenant.RoomID != nil {
+	switch {
+	case oldRoomID != nil && tenant.RoomID != nil && *oldRoomID != *tenant.RoomID:
+		if err := u.transfer(tenant.ID, *tenant.RoomID, now, "Room changed"); err != nil {
+			return err
+		}
+	case oldRoomID == nil && tenant.RoomID != nil:
+		if _, err := u.vacantRoom(*tenant.RoomID); err != nil {
+			return err
+		}
 		if err := u.roomRepo.UpdateStatus(*tenant.RoomID, "occupied"); err != nil {
 			return err
 		}
+		if err := u.openStay(tenant.ID, *tenant.RoomID, now, "Room assigned"); err != nil {
+			return err
+		}
+	case oldRoomID != nil && tenant.RoomID == nil:
+		if err := u.roomRepo.UpdateStatus(*oldRoomID, "empty"); err != nil {
+			return err
+		}
+		if err := u.closeStay(tenant.ID, now); err != nil {
+			return err
+		}
+	}
+
+	return u.tenantRepo.Update(tenant)
+}
+
+// Transfer moves an active tenant into another vacant room on date, closing
+// the current stay and opening a new one. The transfer month's bill is
+// re-priced as old room rent up to the day before plus new room rent from
+// date, and an active lease continues on the new room at its price.
+func (u *tenantUsecase) Transfer(id, roomID uint, date time.Time, reason string) (*entity.Tenant, error) {
+	err := u.uow.Do(func(repos *repository.Repositories) error {
+		return u.withRepos(repos).transfer(id, roomID, date, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.tenantRepo.FindByID(id)
+}
+
+func (u *tenantUsecase) transfer(id, roomID uint, date time.Time, reason string) error {
+	tenant, err := u.tenantRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if tenant.Status != "active" || tenant.RoomID == nil || tenant.Room == nil {
+		return entity.Conflict("tenant_not_transferable", "only active tenants living in a room can be transferred")
+	}
+	if *tenant.RoomID == roomID {
+		return entity.Conflict("same_room", "tenant already lives in this room")
+	}
+	if reason == "" {
+		reason = "Transfer"
+	}
+
+	room, err := u.vacantRoom(roomID)
+	if err != nil {
+		return err
+	}
+
+	date = truncateDay(date)
+	if date.Before(truncateDay(tenant.StartDate)) {
+		return entity.Invalid("invalid_transfer_date", "transfer date must not be before the tenant's start date")
+	}
+
+	oldRent, lease, err := monthlyRent(u.leaseRepo, tenant, date.AddDate(0, 0, -1))
+	if err != nil {
+		return err
+	}
+	newRent := room.Price
+	if lease != nil && lease.Status == "active" {
+		if err := u.moveLease(lease, room, date); err != nil {
+			return err
+		}
+	}
+	if err := u.billTransfer(tenant, oldRent, newRent, date); err != nil {
+		return err
+	}
+
+	if err := u.closeStay(tenant.ID, date.AddDate(0, 0, -1)); err != nil {
+		return err
+	}
+	if err := u.openStay(tenant.ID, roomID, date, reason); err != nil {
+		return err
+	}
+
+	if err := u.roomRepo.UpdateStatus(*tenant.RoomID, "empty"); err != nil {
+		return err
+	}
+	if err := u.roomRepo.UpdateStatus(roomID, "occupied"); err != nil {
+		return err
 	}
 
+	tenant.RoomID = &roomID
+	tenant.PropertyID = room.PropertyID
+	tenant.UpdatedAt = time.Now()
 	return u.tenantRepo.Update(tenant)
 }
 
+// Delete moves the tenant out: the final month is prorated up to EndDate
+// (today when unset), the room is released, an active lease is ended and the
+// tenant is kept as inactive so their payment history survives.
 func (u *tenantUsecase) Delete(id uint) error {
+	return u.uow.Do(func(repos *repository.Repositories) error {
+		return u.withRepos(repos).delete(id)
+	})
+}
+
+func (u *tenantUsecase) delete(id uint) error {
 	tenant, err := u.tenantRepo.FindByID(id)
 	if err != nil {
 		return err
 	}
 
+	if tenant.EndDate == nil {
+		now := time.Now()
+		tenant.EndDate = &now
+	}
+
 	// Update room to empty
 	if tenant.RoomID != nil {
+		if tenant.Room != nil {
+			if err := u.billMoveOut(tenant); err != nil {
+				return err
+			}
+		}
 		if err := u.roomRepo.UpdateStatus(*tenant.RoomID, "empty"); err != nil {
 			return err
 		}
 	}
 
-	return u.tenantRepo.Delete(id)
+	if err := u.endLease(tenant); err != nil {
+		return err
+	}
+	if err := u.closeStay(tenant.ID, *tenant.EndDate); err != nil {
+		return err
+	}
+
+	tenant.Status = "inactive"
+	tenant.RoomID = nil
+	tenant.UpdatedAt = time.Now()
+	return u.tenantRepo.Update(tenant)
+}
+
+func (u *tenantUsecase) billMoveIn(tenant *entity.Tenant) error {
+	room, err := u.roomRepo.FindByID(*tenant.RoomID)
+	if err != nil {
+		return err
+	}
+
+	charge := u.proration.MoveIn(room.Price, tenant.StartDate)
+	exists, err := u.paymentRepo.ExistsForPeriod(tenant.ID, charge.Period)
+	if err != nil || exists {
+		return err
+	}
+
+	payment := &entity.Payment{
+		PropertyID: tenant.PropertyID,
+		TenantID:   tenant.ID,
+		Amount:     charge.Amount,
+		DueDate:    charge.From,
+		Status:     "unpaid",
+		Period:     charge.Period,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	if err := u.paymentRepo.Create(payment); err != nil {
+		return err
+	}
+	return u.ledger.ApplyCredit(payment)
+}
+
+func (u *tenantUsecase) billMoveOut(tenant *entity.Tenant) error {
+	rent, _, err := monthlyRent(u.leaseRepo, tenant, *tenant.EndDate)
+	if err != nil {
+		return err
+	}
+
+	charge := u.proration.MoveOut(rent, *tenant.EndDate)
+	if firstOfMonth(tenant.StartDate).Equal(firstOfMonth(*tenant.EndDate)) {
+		charge = u.proration.Between(rent, tenant.StartDate, *tenant.EndDate)
+	}
+
+	existing, err := u.paymentRepo.FindByTenantAndPeriod(tenant.ID, charge.Period)
+	if err != nil {
+		return err
+	}
+
+	// Shrink a full-month bill that has not been settled yet; whatever was
+	// already paid above the prorated amount becomes tenant credit
+	if existing != nil {
+		if existing.PaidAt != nil || !existing.Amount.GreaterThan(charge.Amount) {
+			return nil
+		}
+		existing.Amount = charge.Amount
+		if overpaid := existing.AmountPaid().Sub(existing.TotalDue()); overpaid.IsPositive() {
+			if err := u.tenantRepo.AdjustCredit(tenant.ID, overpaid); err != nil {
+				return err
+			}
+		}
+		return u.ledger.Settle(existing)
+	}
+
+	payment := &entity.Payment{
+		PropertyID: tenant.PropertyID,
+		TenantID:   tenant.ID,
+		Amount:     charge.Amount,
+		DueDate:    charge.To,
+		Status:     "unpaid",
+		Period:     charge.Period,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+	if err := u.paymentRepo.Create(payment); err != nil {
+		return err
+	}
+	return u.ledger.ApplyCredit(payment)
+}
+
+func (u *tenantUsecase) endLease(tenant *entity.Tenant) error {
+	lease, err := u.leaseRepo.FindForTenantOn(tenant.ID, *tenant.EndDate)
+	if err != nil || lease == nil || lease.Status != "active" {
+		return err
+	}
+
+	lease.Status = "ended"
+	if tenant.EndDate.Before(lease.EndDate) {
+		lease.TerminatedAt = tenant.EndDate
+		lease.TerminationReason = "Tenant moved out"
+	}
+	lease.UpdatedAt = time.Now()
+	return u.leaseRepo.Update(lease)
+}
+
+// billTransfer re-prices the bill of the transfer month, if already issued
+// and not yet settled, with each room charged for the days the tenant spent
+// in it.
+func (u *tenantUsecase) billTransfer(tenant *entity.Tenant, oldRent, newRent entity.Money, date time.Time) error {
+	payment, err := u.paymentRepo.FindByTenantAndPeriod(tenant.ID, date.Format("2006-01"))
+	if err != nil || payment == nil || payment.PaidAt != nil {
+		return err
+	}
+
+	amount := u.proration.MoveIn(newRent, date).Amount
+	if date.Day() > 1 {
+		from := firstOfMonth(date)
+		if tenant.StartDate.After(from) {
+			from = tenant.StartDate
+		}
+		amount = amount.Add(u.proration.Between(oldRent, from, date.AddDate(0, 0, -1)).Amount)
+	}
+	if amount.Cmp(payment.Amount) == 0 {
+		return nil
+	}
+
+	before := overpayment(payment)
+	payment.Amount = amount
+	if err := u.ledger.CreditOverpayment(payment, before); err != nil {
+		return err
+	}
+	return u.ledger.Settle(payment)
+}
+
+// moveLease carries an active lease over to the new room for the rest of its
+// term at the new room's price.
+func (u *tenantUsecase) moveLease(lease *entity.Lease, room *entity.Room, date time.Time) error {
+	months := 1
+	for date.AddDate(0, months, -1).Before(lease.EndDate) {
+		months++
+	}
+
+	moved := &entity.Lease{
+		TenantID:      lease.TenantID,
+		RoomID:        room.ID,
+		StartDate:     date,
+		EndDate:       lease.EndDate,
+		TermMonths:    months,
+		Rent:          room.Price,
+		BillingCycle:  lease.BillingCycle,
+		Deposit:       lease.Deposit,
+		NoticeDays:    lease.NoticeDays,
+		Status:        "active",
+		RenewedFromID: &lease.ID,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	if err := u.leaseRepo.Create(moved); err != nil {
+		return err
+	}
+
+	end := date.AddDate(0, 0, -1)
+	lease.Status = "transferred"
+	lease.TerminatedAt = &end
+	lease.TerminationReason = "Transferred to room " + room.RoomNumber
+	lease.UpdatedAt = time.Now()
+	return u.leaseRepo.Update(lease)
+}
+
+// vacantRoom returns the room, or a conflict when someone already lives in it.
+func (u *tenantUsecase) vacantRoom(roomID uint) (*entity.Room, error) {
+	room, err := u.roomRepo.FindByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.Status == "occupied" {
+		return nil, entity.Conflict("room_occupied", "room %s is already occupied", room.RoomNumber)
+	}
+	return room, nil
+}
+
+func (u *tenantUsecase) openStay(tenantID, roomID uint, from time.Time, reason string) error {
+	return u.occupancyRepo.Create(&entity.Occupancy{
+		RoomID:    roomID,
+		TenantID:  tenantID,
+		StartDate: truncateDay(from),
+		Reason:    reason,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	})
+}
+
+func (u *tenantUsecase) closeStay(tenantID uint, to time.Time) error {
+	stay, err := u.occupancyRepo.FindOpenByTenantID(tenantID)
+	if err != nil || stay == nil {
+		return err
+	}
+
+	end := truncateDay(to)
+	if end.Before(stay.StartDate) {
+		end = stay.StartDate
+	}
+	stay.EndDate = &end
+	stay.UpdatedAt = time.Now()
+	return u.occupancyRepo.Update(stay)
 }
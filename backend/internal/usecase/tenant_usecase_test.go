@@ -0,0 +1,238 @@
+package usecase_test
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"testing"
+	"time"
+)
+
+func TestTenantCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		start      time.Time
+		wantPeriod string
+		wantAmount string
+	}{
+		{"first of the month", date(2026, 1, 1), "2026-01", "3100000.00"},
+		{"mid month", date(2026, 1, 15), "2026-01", "1700000.00"},
+		{"last day of a short month", date(2026, 2, 28), "2026-02", "110714.29"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			room := f.room(t, "101", "3100000")
+			tenant := f.tenant(t, "Budi", room, tt.start)
+
+			if tenant.PropertyID != room.PropertyID {
+				t.Errorf("property = %d, want the room's %d", tenant.PropertyID, room.PropertyID)
+			}
+			room, err := f.repos.Rooms.FindByID(room.ID)
+			must(t, err)
+			if room.Status != "occupied" {
+				t.Errorf("room status = %q, want occupied", room.Status)
+			}
+			stay, err := f.repos.Occupancies.FindOpenByTenantID(tenant.ID)
+			must(t, err)
+			if stay == nil || stay.RoomID != room.ID || !stay.StartDate.Equal(tt.start) {
+				t.Errorf("stay = %+v, want room %d from %v", stay, room.ID, tt.start)
+			}
+
+			payment := f.payment(t, tenant.ID, tt.wantPeriod)
+			wantMoney(t, "first bill", payment.Amount, tt.wantAmount)
+			if !payment.DueDate.Equal(tt.start) || payment.Status != "unpaid" {
+				t.Errorf("first bill = due %v, status %q, want due %v, unpaid", payment.DueDate, payment.Status, tt.start)
+			}
+		})
+	}
+}
+
+func TestTenantCreateRejects(t *testing.T) {
+	missing := uint(99)
+	tests := []struct {
+		name string
+		// tenant is moved in; occupied is set to the room Ani lives in
+		tenant   entity.Tenant
+		occupied bool
+		kind     error
+		code     string
+	}{
+		{"occupied room", entity.Tenant{Name: "Budi", StartDate: date(2026, 1, 15)}, true, entity.ErrConflict, "room_occupied"},
+		{"missing room", entity.Tenant{Name: "Budi", RoomID: &missing, StartDate: date(2026, 1, 15)}, false, entity.ErrNotFound, "not_found"},
+		{"no room and no property", entity.Tenant{Name: "Budi", StartDate: date(2026, 1, 15)}, false, entity.ErrValidation, "property_required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			room := f.room(t, "101", "3100000")
+			ani := f.tenant(t, "Ani", room, date(2026, 1, 1))
+			if tt.occupied {
+				tt.tenant.RoomID = &room.ID
+			}
+
+			wantKind(t, f.usecases.Tenant.Create(&tt.tenant), tt.kind, tt.code)
+
+			tenants, _, err := f.usecases.Tenant.GetAll(repository.TenantQuery{})
+			must(t, err)
+			if len(tenants) != 1 || tenants[0].ID != ani.ID {
+				t.Errorf("tenants = %+v, want only Ani", tenants)
+			}
+			stays, err := f.repos.Occupancies.FindByRoomID(room.ID)
+			must(t, err)
+			if len(stays) != 1 || stays[0].TenantID != ani.ID {
+				t.Errorf("stays in room 101 = %+v, want only Ani's", stays)
+			}
+		})
+	}
+}
+
+func TestTenantDelete(t *testing.T) {
+	tests := []struct {
+		name string
+		// billed is whether February was billed before the move-out
+		billed     bool
+		paid       string
+		wantAmount string
+		wantCredit string
+	}{
+		{"bills the last days", false, "", "1107142.86", "0.00"},
+		{"shrinks the month's bill", true, "", "1107142.86", "0.00"},
+		{"credits what was paid above the last days", true, "1500000", "1107142.86", "392857.14"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			room := f.room(t, "101", "3100000")
+			tenant := f.tenant(t, "Budi", room, date(2026, 1, 15))
+			if tt.billed {
+				_, err := f.usecases.Billing.RunMonthly(date(2026, 2, 1))
+				must(t, err)
+			}
+			if tt.paid != "" {
+				_, err := f.usecases.Payment.RecordTransaction(f.payment(t, tenant.ID, "2026-02").ID, &entity.PaymentTransaction{
+					Amount: money(t, tt.paid),
+					Method: "cash",
+					PaidAt: date(2026, 2, 1),
+				})
+				must(t, err)
+			}
+
+			end := date(2026, 2, 10)
+			tenant.EndDate = &end
+			must(t, f.repos.Tenants.Update(tenant))
+			must(t, f.usecases.Tenant.Delete(tenant.ID))
+
+			gone, err := f.usecases.Tenant.GetByID(tenant.ID)
+			must(t, err)
+			if gone.Status != "inactive" || gone.RoomID != nil {
+				t.Errorf("tenant = status %q, room %v, want inactive without a room", gone.Status, gone.RoomID)
+			}
+			wantMoney(t, "credit", gone.CreditBalance, tt.wantCredit)
+			room, err = f.repos.Rooms.FindByID(room.ID)
+			must(t, err)
+			if room.Status != "empty" {
+				t.Errorf("room status = %q, want empty", room.Status)
+			}
+			stay, err := f.repos.Occupancies.FindOpenByTenantID(tenant.ID)
+			must(t, err)
+			if stay != nil {
+				t.Errorf("stay still open: %+v", stay)
+			}
+			wantMoney(t, "last bill", f.payment(t, tenant.ID, "2026-02").Amount, tt.wantAmount)
+		})
+	}
+}
+
+func TestTenantTransfer(t *testing.T) {
+	f := newFixture(t)
+	from := f.room(t, "101", "3100000")
+	to := f.room(t, "102", "6200000")
+	taken := f.room(t, "103", "3100000")
+	tenant := f.tenant(t, "Budi", from, date(2026, 1, 1))
+	f.tenant(t, "Ani", taken, date(2026, 1, 1))
+	_, err := f.usecases.Billing.RunMonthly(date(2026, 3, 1))
+	must(t, err)
+
+	tests := []struct {
+		name   string
+		roomID uint
+		date   time.Time
+		kind   error
+		code   string
+	}{
+		{"same room", from.ID, date(2026, 3, 11), entity.ErrConflict, "same_room"},
+		{"occupied room", taken.ID, date(2026, 3, 11), entity.ErrConflict, "room_occupied"},
+		{"missing room", 99, date(2026, 3, 11), entity.ErrNotFound, "not_found"},
+		{"before the move-in", to.ID, date(2025, 12, 31), entity.ErrValidation, "invalid_transfer_date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.usecases.Tenant.Transfer(tenant.ID, tt.roomID, tt.date, "")
+			wantKind(t, err, tt.kind, tt.code)
+		})
+	}
+
+	moved, err := f.usecases.Tenant.Transfer(tenant.ID, to.ID, date(2026, 3, 11), "")
+	must(t, err)
+	if moved.RoomID == nil || *moved.RoomID != to.ID {
+		t.Fatalf("room = %v, want %d", moved.RoomID, to.ID)
+	}
+	// Ten days at 100,000 a day, then 21 days at 200,000
+	wantMoney(t, "March bill", f.payment(t, tenant.ID, "2026-03").Amount, "5200000.00")
+	for _, want := range []struct {
+		room   *entity.Room
+		status string
+	}{{from, "empty"}, {to, "occupied"}} {
+		room, err := f.repos.Rooms.FindByID(want.room.ID)
+		must(t, err)
+		if room.Status != want.status {
+			t.Errorf("room %s status = %q, want %q", room.RoomNumber, room.Status, want.status)
+		}
+	}
+}
+
+func TestTenantTransferBill(t *testing.T) {
+	tests := []struct {
+		name string
+		// rent of the room moved into on March 11 and what was paid on the
+		// March bill before the transfer
+		rent        string
+		paid        string
+		wantAmount  string
+		wantBalance string
+		wantCredit  string
+	}{
+		{"re-prices an unpaid bill", "6200000", "", "5200000.00", "5200000.00", "0.00"},
+		{"re-prices a partial bill", "6200000", "1000000", "5200000.00", "4200000.00", "0.00"},
+		{"credits what a partial bill is now overpaid", "1550000", "2500000", "2050000.00", "0.00", "450000.00"},
+		{"keeps a paid bill", "1550000", "3100000", "3100000.00", "0.00", "0.00"},
+		{"credits an overpaid bill once", "1550000", "4000000", "3100000.00", "0.00", "900000.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			from := f.room(t, "101", "3100000")
+			to := f.room(t, "102", tt.rent)
+			tenant := f.tenant(t, "Budi", from, date(2026, 3, 1))
+			if tt.paid != "" {
+				_, err := f.usecases.Payment.RecordTransaction(f.payment(t, tenant.ID, "2026-03").ID, &entity.PaymentTransaction{
+					Amount: money(t, tt.paid),
+					Method: "cash",
+					PaidAt: date(2026, 3, 1),
+				})
+				must(t, err)
+			}
+
+			moved, err := f.usecases.Tenant.Transfer(tenant.ID, to.ID, date(2026, 3, 11), "")
+			must(t, err)
+
+			payment := f.payment(t, tenant.ID, "2026-03")
+			wantMoney(t, "March bill", payment.Amount, tt.wantAmount)
+			wantMoney(t, "March balance", payment.Balance(), tt.wantBalance)
+			if settled := payment.PaidAt != nil; settled != payment.Balance().IsZero() {
+				t.Errorf("March bill paid at %v with a balance of %s", payment.PaidAt, payment.Balance())
+			}
+			wantMoney(t, "credit", moved.CreditBalance, tt.wantCredit)
+		})
+	}
+}
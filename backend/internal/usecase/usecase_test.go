@@ -0,0 +1,366 @@
+package usecase_test
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/memory"
+	"ezkost/internal/usecase"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fixture is one organization's usecases on in-memory repositories. Rooms,
+// tenants, payments and expenses live in the memory store; everything else
+// in the small fakes below and next to the tests of the usecase using them.
+// The organization starts with two properties: Kos Melati with three floors
+// and Kos Mawar with one.
+type fixture struct {
+	repos    *repository.Repositories
+	leases   *leases
+	readings *readings
+	policies lateFeePolicies
+	receipts *receipts
+	usecases *usecase.Usecases
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{
+		repos:    memory.NewRepositories(memory.NewStore()),
+		leases:   &leases{},
+		readings: &readings{},
+		policies: lateFeePolicies{},
+		receipts: &receipts{},
+	}
+	f.repos.Properties = &properties{
+		rows: map[uint]entity.Property{
+			1: {ID: 1, Name: "Kos Melati", Address: "Jl. Melati 1", Floors: 3, ReceiptPrefix: "MLT"},
+			2: {ID: 2, Name: "Kos Mawar", Address: "Jl. Mawar 2", Floors: 1, ReceiptPrefix: "RCP"},
+		},
+		rooms: f.repos.Rooms,
+	}
+	f.repos.Leases = f.leases
+	f.repos.Occupancies = &occupancies{}
+	f.repos.MeterReadings = f.readings
+	f.repos.LateFeePolicies = f.policies
+	f.repos.Deposits = &deposits{}
+	f.repos.UtilityTariffs = tariffs{}
+	f.repos.Receipts = f.receipts
+	f.repos.Letterheads = &letterheads{}
+	f.repos.Audit = &auditLog{}
+	f.usecases = usecase.NewUsecases(memory.NewUnitOfWork(f.repos), f.repos, usecase.UsecaseOptions{
+		Proration: usecase.ProrationPolicy{Basis: usecase.ProrationBasisCalendar},
+		Renderer:  renderer{},
+		Accounts:  f.repos.Users,
+	})
+	return f
+}
+
+func (f *fixture) room(t *testing.T, number, price string) *entity.Room {
+	t.Helper()
+	room := &entity.Room{PropertyID: 1, RoomNumber: number, Price: money(t, price), Status: "empty"}
+	must(t, f.repos.Rooms.Create(room))
+	return room
+}
+
+// tenant moves a tenant into room on start through the tenant usecase, which
+// also bills the partial first month.
+func (f *fixture) tenant(t *testing.T, name string, room *entity.Room, start time.Time) *entity.Tenant {
+	t.Helper()
+	tenant := &entity.Tenant{Name: name, Phone: "0812", RoomID: &room.ID, StartDate: start}
+	must(t, f.usecases.Tenant.Create(tenant))
+	return tenant
+}
+
+func (f *fixture) payment(t *testing.T, tenantID uint, period string) *entity.Payment {
+	t.Helper()
+	payment, err := f.repos.Payments.FindByTenantAndPeriod(tenantID, period)
+	must(t, err)
+	if payment == nil {
+		t.Fatalf("no %s bill for tenant %d", period, tenantID)
+	}
+	return payment
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func money(t *testing.T, amount string) entity.Money {
+	t.Helper()
+	m, err := entity.ParseMoney(amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// wantKind fails unless err is a domain error of kind with code.
+func wantKind(t *testing.T, err error, kind error, code string) {
+	t.Helper()
+	var domainErr *entity.Error
+	if !errors.As(err, &domainErr) || domainErr.Kind != kind || domainErr.Code != code {
+		t.Fatalf("got error %v, want %v %q", err, kind, code)
+	}
+}
+
+func wantMoney(t *testing.T, name string, got entity.Money, want string) {
+	t.Helper()
+	if got.String() != want {
+		t.Errorf("%s = %s, want %s", name, got, want)
+	}
+}
+
+// properties holds the organization's properties; their rooms are counted
+// in the room repository.
+type properties struct {
+	rows  map[uint]entity.Property
+	rooms repository.RoomRepository
+}
+
+var _ repository.PropertyRepository = (*properties)(nil)
+
+func (p *properties) Create(property *entity.Property) error {
+	property.ID = 1
+	for id := range p.rows {
+		property.ID = max(property.ID, id+1)
+	}
+	p.rows[property.ID] = *property
+	return nil
+}
+
+func (p *properties) FindAll() ([]entity.Property, error) {
+	found := []entity.Property{}
+	for _, id := range slices.Sorted(maps.Keys(p.rows)) {
+		found = append(found, p.rows[id])
+	}
+	return found, nil
+}
+
+func (p *properties) FindByID(id uint) (*entity.Property, error) {
+	property, ok := p.rows[id]
+	if !ok {
+		return nil, entity.NotFound("not_found", "property not found")
+	}
+	return &property, nil
+}
+
+func (p *properties) Update(property *entity.Property) error {
+	if _, err := p.FindByID(property.ID); err != nil {
+		return err
+	}
+	p.rows[property.ID] = *property
+	return nil
+}
+
+func (p *properties) Delete(id uint) error {
+	if _, err := p.FindByID(id); err != nil {
+		return err
+	}
+	delete(p.rows, id)
+	return nil
+}
+
+func (p *properties) CountRooms(id uint) (int64, error) { return p.rooms.Count(id) }
+
+type leases struct {
+	rows []entity.Lease
+}
+
+var _ repository.LeaseRepository = (*leases)(nil)
+
+func (l *leases) Create(lease *entity.Lease) error {
+	lease.ID = uint(len(l.rows) + 1)
+	l.rows = append(l.rows, *lease)
+	return nil
+}
+
+func (l *leases) FindAll() ([]entity.Lease, error) { return l.rows, nil }
+
+func (l *leases) FindByID(id uint) (*entity.Lease, error) {
+	for _, lease := range l.rows {
+		if lease.ID == id {
+			return &lease, nil
+		}
+	}
+	return nil, entity.NotFound("not_found", "lease not found")
+}
+
+func (l *leases) FindByTenantID(tenantID uint) ([]entity.Lease, error) {
+	var found []entity.Lease
+	for _, lease := range l.rows {
+		if lease.TenantID == tenantID {
+			found = append(found, lease)
+		}
+	}
+	return found, nil
+}
+
+func (l *leases) FindForTenantOn(tenantID uint, date time.Time) (*entity.Lease, error) {
+	var latest *entity.Lease
+	for i, lease := range l.rows {
+		if lease.TenantID == tenantID && !lease.StartDate.After(date) &&
+			(latest == nil || lease.StartDate.After(latest.StartDate)) {
+			latest = &l.rows[i]
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	found := *latest
+	return &found, nil
+}
+
+func (l *leases) FindExpiring(from, to time.Time) ([]entity.Lease, error) {
+	var found []entity.Lease
+	for _, lease := range l.rows {
+		if lease.Status == "active" && !lease.EndDate.Before(from) && lease.EndDate.Before(to) {
+			found = append(found, lease)
+		}
+	}
+	return found, nil
+}
+
+func (l *leases) Update(lease *entity.Lease) error {
+	for i := range l.rows {
+		if l.rows[i].ID == lease.ID {
+			l.rows[i] = *lease
+			return nil
+		}
+	}
+	return entity.NotFound("not_found", "lease not found")
+}
+
+type occupancies struct {
+	rows []entity.Occupancy
+}
+
+var _ repository.OccupancyRepository = (*occupancies)(nil)
+
+func (o *occupancies) Create(occupancy *entity.Occupancy) error {
+	occupancy.ID = uint(len(o.rows) + 1)
+	o.rows = append(o.rows, *occupancy)
+	return nil
+}
+
+// FindByRoomID returns the latest stay first; stays are created in order.
+func (o *occupancies) FindByRoomID(roomID uint) ([]entity.Occupancy, error) {
+	var found []entity.Occupancy
+	for i := len(o.rows) - 1; i >= 0; i-- {
+		if o.rows[i].RoomID == roomID {
+			found = append(found, o.rows[i])
+		}
+	}
+	return found, nil
+}
+
+func (o *occupancies) FindOpenByTenantID(tenantID uint) (*entity.Occupancy, error) {
+	for _, stay := range o.rows {
+		if stay.TenantID == tenantID && stay.EndDate == nil {
+			return &stay, nil
+		}
+	}
+	return nil, nil
+}
+
+func (o *occupancies) Update(occupancy *entity.Occupancy) error {
+	for i := range o.rows {
+		if o.rows[i].ID == occupancy.ID {
+			o.rows[i] = *occupancy
+			return nil
+		}
+	}
+	return entity.NotFound("not_found", "occupancy not found")
+}
+
+type readings struct {
+	rows []entity.MeterReading
+}
+
+var _ repository.MeterReadingRepository = (*readings)(nil)
+
+func (r *readings) Create(reading *entity.MeterReading) error {
+	reading.ID = uint(len(r.rows) + 1)
+	r.rows = append(r.rows, *reading)
+	return nil
+}
+
+func (r *readings) Update(reading *entity.MeterReading) error {
+	for i := range r.rows {
+		if r.rows[i].ID == reading.ID {
+			r.rows[i] = *reading
+			return nil
+		}
+	}
+	return entity.NotFound("not_found", "meter reading not found")
+}
+
+func (r *readings) FindByRoomID(roomID uint) ([]entity.MeterReading, error) {
+	return r.find(func(reading entity.MeterReading) bool { return reading.RoomID == roomID }), nil
+}
+
+func (r *readings) FindPrevious(roomID uint, utility, period string) (*entity.MeterReading, error) {
+	var latest *entity.MeterReading
+	for i, reading := range r.rows {
+		if reading.RoomID == roomID && reading.Utility == utility && reading.Period < period &&
+			(latest == nil || reading.Period > latest.Period) {
+			latest = &r.rows[i]
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	found := *latest
+	return &found, nil
+}
+
+func (r *readings) FindUnbilled(roomID uint, period string) ([]entity.MeterReading, error) {
+	return r.find(func(reading entity.MeterReading) bool {
+		return reading.RoomID == roomID && reading.Period == period && reading.PaymentID == nil && reading.Charge.IsPositive()
+	}), nil
+}
+
+func (r *readings) FindFlagged() ([]entity.MeterReading, error) {
+	return r.find(func(reading entity.MeterReading) bool { return reading.Flag != "" }), nil
+}
+
+func (r *readings) find(match func(entity.MeterReading) bool) []entity.MeterReading {
+	var found []entity.MeterReading
+	for _, reading := range r.rows {
+		if match(reading) {
+			found = append(found, reading)
+		}
+	}
+	return found
+}
+
+// lateFeePolicies holds the policy of each property.
+type lateFeePolicies map[uint]entity.LateFeePolicy
+
+var _ repository.LateFeePolicyRepository = lateFeePolicies{}
+
+func (p lateFeePolicies) Get(propertyID uint) (*entity.LateFeePolicy, error) {
+	policy, ok := p[propertyID]
+	if !ok {
+		return &entity.LateFeePolicy{PropertyID: propertyID, Type: "flat"}, nil
+	}
+	return &policy, nil
+}
+
+func (p lateFeePolicies) Save(policy *entity.LateFeePolicy) error {
+	if policy.ID == 0 {
+		policy.ID = uint(len(p) + 1)
+	}
+	p[policy.PropertyID] = *policy
+	return nil
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"time"
+)
+
+// Usecases bundles the usecases working on one organization's data. They are
+// built per organization from repositories scoped to it.
+type Usecases struct {
+	Property  PropertyUsecase
+	Room      RoomUsecase
+	Tenant    TenantUsecase
+	Payment   PaymentUsecase
+	Dashboard DashboardUsecase
+	Expense   ExpenseUsecase
+	Billing   BillingUsecase
+	Deposit   DepositUsecase
+	LateFee   LateFeeUsecase
+	Metering  MeteringUsecase
+	Lease     LeaseUsecase
+	Document  DocumentUsecase
+	Audit     AuditUsecase
+	User      UserUsecase
+}
+
+// UsecaseOptions are the settings shared by every organization.
+type UsecaseOptions struct {
+	Proration ProrationPolicy
+	Renderer  DocumentRenderer
+	// Roles are the roles users may be given; InvitationTTL is how long an
+	// invitation stays valid.
+	Roles         []string
+	InvitationTTL time.Duration
+	// Accounts finds users across every organization, so that an email
+	// taken elsewhere cannot be invited.
+	Accounts repository.UserRepository
+}
+
+// UsecaseFactory returns the usecases of one organization, making changes
+// on behalf of actor.
+type UsecaseFactory func(organizationID uint, actor entity.Actor) *Usecases
+
+func NewUsecases(uow repository.UnitOfWork, repos *repository.Repositories, opts UsecaseOptions) *Usecases {
+	return &Usecases{
+		Property:  NewPropertyUsecase(repos.Properties),
+		Room:      NewRoomUsecase(repos.Rooms, repos.Properties, repos.Occupancies),
+		Tenant:    NewTenantUsecase(uow, repos.Tenants, repos.Rooms, repos.Payments, repos.Leases, repos.Occupancies, opts.Proration),
+		Payment:   NewPaymentUsecase(uow, repos.Payments, repos.Tenants),
+		Dashboard: NewDashboardUsecase(repos.Properties, repos.Rooms, repos.Tenants, repos.Payments, repos.Expenses, repos.Leases),
+		Expense:   NewExpenseUsecase(repos.Expenses, repos.Properties),
+		Billing:   NewBillingUsecase(uow, repos.Payments, repos.Tenants, repos.Rooms, repos.MeterReadings, repos.Leases, opts.Proration),
+		Deposit:   NewDepositUsecase(uow, repos.Deposits, repos.Tenants, repos.Payments),
+		LateFee:   NewLateFeeUsecase(uow, repos.LateFeePolicies, repos.Payments, repos.Properties, repos.Tenants),
+		Metering:  NewMeteringUsecase(uow, repos.MeterReadings, repos.UtilityTariffs, repos.Rooms, repos.Payments, repos.Tenants),
+		Lease:     NewLeaseUsecase(uow, repos.Leases, repos.Tenants, repos.Rooms, repos.Payments, opts.Proration),
+		Document:  NewDocumentUsecase(repos.Payments, repos.Receipts, repos.Letterheads, repos.Properties, opts.Renderer),
+		Audit:     NewAuditUsecase(repos.Audit),
+		User:      NewUserUsecase(repos.Users, repos.Invitations, opts.Accounts, opts.Roles, opts.InvitationTTL),
+	}
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"strings"
+	"time"
+)
+
+// User Usecase
+type UserUsecase interface {
+	GetAll() ([]entity.User, error)
+	// Invite creates an invitation and returns its token, which is shown
+	// only once.
+	Invite(invitation *entity.Invitation) (string, error)
+	GetInvitations() ([]entity.Invitation, error)
+	RevokeInvitation(id uint) error
+	ChangeRole(id uint, role string) (*entity.User, error)
+	Deactivate(id uint) (*entity.User, error)
+	Activate(id uint) (*entity.User, error)
+	// Unlock lifts a lockout after failed logins.
+	Unlock(id uint) (*entity.User, error)
+}
+
+type userUsecase struct {
+	userRepo       repository.UserRepository
+	invitationRepo repository.InvitationRepository
+	// accounts finds users in every organization; an email belongs to one
+	// account only.
+	accounts      repository.UserRepository
+	roles         []string
+	invitationTTL time.Duration
+}
+
+func NewUserUsecase(
+	userRepo repository.UserRepository,
+	invitationRepo repository.InvitationRepository,
+	accounts repository.UserRepository,
+	roles []string,
+	invitationTTL time.Duration,
+) UserUsecase {
+	return &userUsecase{
+		userRepo:       userRepo,
+		invitationRepo: invitationRepo,
+		accounts:       accounts,
+		roles:          roles,
+		invitationTTL:  invitationTTL,
+	}
+}
+
+func (u *userUsecase) GetAll() ([]entity.User, error) {
+	return u.userRepo.FindAll()
+}
+
+func (u *userUsecase) Invite(invitation *entity.Invitation) (string, error) {
+	invitation.Email = strings.ToLower(strings.TrimSpace(invitation.Email))
+	if invitation.Email == "" {
+		return "", entity.Invalid("email_required", "email is required")
+	}
+	if err := u.checkRole(invitation.Role); err != nil {
+		return "", err
+	}
+	existing, err := u.accounts.FindByEmail(invitation.Email)
+	if err != nil && !errors.Is(err, entity.ErrNotFound) {
+		return "", err
+	}
+	if existing != nil {
+		return "", entity.Conflict("email_taken", "email already registered")
+	}
+
+	token, hash, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	invitation.ID = 0
+	invitation.TokenHash = hash
+	invitation.ExpiresAt = time.Now().Add(u.invitationTTL)
+	invitation.AcceptedAt = nil
+	invitation.CreatedAt = time.Now()
+	invitation.UpdatedAt = time.Now()
+	if err := u.invitationRepo.Create(invitation); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (u *userUsecase) GetInvitations() ([]entity.Invitation, error) {
+	return u.invitationRepo.FindPending(time.Now())
+}
+
+func (u *userUsecase) RevokeInvitation(id uint) error {
+	invitation, err := u.invitationRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if invitation.AcceptedAt != nil {
+		return entity.Conflict("invitation_accepted", "invitation was already accepted")
+	}
+	return u.invitationRepo.Delete(id)
+}
+
+func (u *userUsecase) ChangeRole(id uint, role string) (*entity.User, error) {
+	if err := u.checkRole(role); err != nil {
+		return nil, err
+	}
+	user, err := u.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == "owner" && role != "owner" {
+		if err := u.keepOwner(user.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	user.Role = role
+	user.UpdatedAt = time.Now()
+	return user, u.userRepo.Update(user)
+}
+
+func (u *userUsecase) Deactivate(id uint) (*entity.User, error) {
+	user, err := u.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == "owner" {
+		if err := u.keepOwner(user.ID); err != nil {
+			return nil, err
+		}
+	}
+	return u.setStatus(user, "inactive")
+}
+
+func (u *userUsecase) Activate(id uint) (*entity.User, error) {
+	user, err := u.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return u.setStatus(user, "active")
+}
+
+func (u *userUsecase) Unlock(id uint) (*entity.User, error) {
+	user, err := u.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	user.FailedLogins = 0
+	user.LockedUntil = nil
+	user.UpdatedAt = time.Now()
+	return user, u.userRepo.Update(user)
+}
+
+func (u *userUsecase) setStatus(user *entity.User, status string) (*entity.User, error) {
+	user.Status = status
+	user.UpdatedAt = time.Now()
+	return user, u.userRepo.Update(user)
+}
+
+func (u *userUsecase) checkRole(role string) error {
+	for _, known := range u.roles {
+		if role == known {
+			return nil
+		}
+	}
+	return entity.Invalid("invalid_role", "role must be one of %s", strings.Join(u.roles, ", "))
+}
+
+// keepOwner refuses to leave the organization without an active owner other
+// than the user being changed.
+func (u *userUsecase) keepOwner(userID uint) error {
+	users, err := u.userRepo.FindAll()
+	if err != nil {
+		return err
+	}
+	for _, other := range users {
+		if other.ID != userID && other.Role == "owner" && other.Status == "active" {
+			return nil
+		}
+	}
+	return entity.Conflict("last_owner", "the organization needs at least one active owner")
+}
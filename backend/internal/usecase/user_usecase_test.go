@@ -0,0 +1,88 @@
+package usecase_test
+
+import (
+	"errors"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/domain/repository"
+	"ezkost/internal/repository/memory"
+	"ezkost/internal/usecase"
+	"testing"
+	"time"
+)
+
+// failingUsers fails every lookup by email with err.
+type failingUsers struct {
+	repository.UserRepository
+	err error
+}
+
+func (u failingUsers) FindByEmail(email string) (*entity.User, error) { return nil, u.err }
+
+type invitations struct {
+	rows []entity.Invitation
+}
+
+var _ repository.InvitationRepository = (*invitations)(nil)
+
+func (i *invitations) Create(invitation *entity.Invitation) error {
+	invitation.ID = uint(len(i.rows) + 1)
+	i.rows = append(i.rows, *invitation)
+	return nil
+}
+
+func (i *invitations) FindPending(now time.Time) ([]entity.Invitation, error) { return i.rows, nil }
+func (i *invitations) FindByID(id uint) (*entity.Invitation, error)           { return nil, nil }
+func (i *invitations) FindByTokenHash(hash string) (*entity.Invitation, error) {
+	return nil, nil
+}
+func (i *invitations) Update(invitation *entity.Invitation) error { return nil }
+func (i *invitations) Delete(id uint) error                       { return nil }
+
+func TestUserInvite(t *testing.T) {
+	f := newFixture(t)
+	must(t, f.repos.Users.Create(&entity.User{Name: "Sari", Email: "sari@example.com", Role: "staff"}))
+	// elsewhere holds the accounts of every organization, including Dewi,
+	// who works for another one
+	elsewhere := memory.NewRepositories(memory.NewStore()).Users
+	must(t, elsewhere.Create(&entity.User{Name: "Sari", Email: "sari@example.com", Role: "staff"}))
+	must(t, elsewhere.Create(&entity.User{Name: "Dewi", Email: "dewi@example.com", Role: "owner"}))
+	dbDown := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		accounts repository.UserRepository
+		email    string
+		wantErr  func(t *testing.T, err error)
+	}{
+		{"new email", elsewhere, " Budi@Example.com ", nil},
+		{"registered email", elsewhere, "SARI@example.com", func(t *testing.T, err error) {
+			wantKind(t, err, entity.ErrConflict, "email_taken")
+		}},
+		{"email of another organization", elsewhere, "dewi@example.com", func(t *testing.T, err error) {
+			wantKind(t, err, entity.ErrConflict, "email_taken")
+		}},
+		{"lookup fails", failingUsers{elsewhere, dbDown}, "budi@example.com", func(t *testing.T, err error) {
+			if !errors.Is(err, dbDown) {
+				t.Fatalf("got error %v, want %v", err, dbDown)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invited := &invitations{}
+			users := usecase.NewUserUsecase(f.repos.Users, invited, tt.accounts, []string{"owner", "staff"}, time.Hour)
+			token, err := users.Invite(&entity.Invitation{Email: tt.email, Role: "staff"})
+			if tt.wantErr != nil {
+				tt.wantErr(t, err)
+				if len(invited.rows) != 0 {
+					t.Errorf("invitation stored: %+v", invited.rows)
+				}
+				return
+			}
+			must(t, err)
+			if token == "" || len(invited.rows) != 1 || invited.rows[0].Email != "budi@example.com" {
+				t.Errorf("token %q, invitations %+v", token, invited.rows)
+			}
+		})
+	}
+}
@@ -1,40 +1,271 @@
 package database
 
 import (
+	_ "embed"
 	"ezkost/internal/config"
-	"ezkost/internal/repository/model"
 	"fmt"
 	"log"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func ConnectDB(cfg *config.Config) *gorm.DB {
-	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		cfg.DBHost, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBPort,
-	)
+// Drivers selectable with DB_DRIVER
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
+
+// ConnectDB opens the database chosen by cfg.DBDriver: Postgres, an SQLite
+// file at cfg.DBPath, or an in-memory SQLite database that lives as long as
+// the process.
+func ConnectDB(cfg *config.Config) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch cfg.DBDriver {
+	case DriverPostgres:
+		dsn := fmt.Sprintf(
+			"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
+			cfg.DBHost, cfg.DBUser, cfg.DBPassword, cfg.DBName, cfg.DBPort,
+		)
+		dialector = postgres.Open(dsn)
+	case DriverSQLite:
+		dialector = sqlite.Open("file:" + cfg.DBPath + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	case DriverMemory:
+		dialector = sqlite.Open("file::memory:?_pragma=foreign_keys(1)")
+	default:
+		return nil, fmt.Errorf("unknown database driver %q (available: postgres, sqlite, memory)", cfg.DBDriver)
+	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(dialector, &gorm.Config{})
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	log.Println("Database connected successfully")
-	return db
+	// SQLite allows one writer at a time, and every connection to an
+	// in-memory database would open a database of its own
+	if cfg.DBDriver != DriverPostgres {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	log.Printf("Database connected successfully (%s)", cfg.DBDriver)
+	return db, nil
 }
 
-func AutoMigrate(db *gorm.DB) {
-	err := db.AutoMigrate(
-		&model.User{},
-		&model.Room{},
-		&model.Tenant{},
-		&model.Payment{},
-		&model.Expense{},
-	)
+// legacyUpgradeScript adds what the baseline has and a schema created by
+// AutoMigrate may lack. It describes the baseline, not the current models,
+// so later migrations apply on top of it as they do on a fresh database.
+//
+//go:embed legacy_upgrade.sql
+var legacyUpgradeScript string
+
+// upgradeLegacySchema brings a database created by AutoMigrate, at any
+// earlier version, up to the baseline migration. It converts the columns and
+// backfills the data that changed shape along the way.
+func upgradeLegacySchema(db *gorm.DB) error {
+	migrator := db.Migrator()
+	splitPercentage := migrator.HasTable("late_fee_policies") &&
+		!migrator.HasColumn("late_fee_policies", "percentage")
+
+	if err := migrateMoneyColumns(db); err != nil {
+		return fmt.Errorf("failed to migrate money columns: %w", err)
+	}
+	if err := dropGlobalUniques(db); err != nil {
+		return fmt.Errorf("failed to drop global unique constraints: %w", err)
+	}
+	hadReceiptSequences := migrator.HasTable("receipt_sequences")
+
+	if err := db.Exec(legacyUpgradeScript).Error; err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	// Percentage policies used to keep their rate in the amount column
+	if splitPercentage {
+		err := db.Exec(`
+			UPDATE late_fee_policies SET percentage = amount, amount = 0
+			WHERE type = 'percentage'
+		`).Error
+		if err != nil {
+			return fmt.Errorf("failed to migrate late fee policies: %w", err)
+		}
+	}
+
+	if err := assignDefaultProperty(db, hadReceiptSequences); err != nil {
+		return fmt.Errorf("failed to assign existing data to a property: %w", err)
+	}
+	if err := assignDefaultOrganization(db); err != nil {
+		return fmt.Errorf("failed to assign existing data to an organization: %w", err)
+	}
+
+	// Tenants who moved in before occupancy history existed get an open stay
+	err := db.Exec(`
+		INSERT INTO occupancies (organization_id, room_id, tenant_id, start_date, reason, created_at, updated_at)
+		SELECT t.organization_id, t.room_id, t.id, t.start_date, 'Move-in', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+		FROM tenants t
+		WHERE t.room_id IS NOT NULL AND t.status = 'active'
+		AND NOT EXISTS (SELECT 1 FROM occupancies o WHERE o.tenant_id = t.id)
+	`).Error
 	if err != nil {
-		log.Fatal("Failed to migrate database:", err)
+		return fmt.Errorf("failed to backfill occupancy history: %w", err)
+	}
+
+	// Bills paid before transactions existed get one transaction for their amount
+	err = db.Exec(`
+		INSERT INTO payment_transactions (organization_id, payment_id, amount, method, paid_at, note, created_at)
+		SELECT p.organization_id, p.id, p.amount, p.payment_method, p.paid_at, 'Backfilled', CURRENT_TIMESTAMP
+		FROM payments p
+		WHERE p.paid_at IS NOT NULL
+		AND NOT EXISTS (SELECT 1 FROM payment_transactions t WHERE t.payment_id = p.id)
+	`).Error
+	if err != nil {
+		return fmt.Errorf("failed to backfill payment transactions: %w", err)
+	}
+
+	return nil
+}
+
+// dropGlobalUniques removes uniqueness that became per property (room numbers
+// and receipt numbers) or per organization (utility tariffs).
+func dropGlobalUniques(db *gorm.DB) error {
+	statements := []string{
+		`ALTER TABLE IF EXISTS rooms DROP CONSTRAINT IF EXISTS uni_rooms_room_number`,
+		`ALTER TABLE IF EXISTS rooms DROP CONSTRAINT IF EXISTS rooms_room_number_key`,
+		`DROP INDEX IF EXISTS idx_rooms_room_number`,
+		`ALTER TABLE IF EXISTS receipts DROP CONSTRAINT IF EXISTS uni_receipts_number`,
+		`ALTER TABLE IF EXISTS receipts DROP CONSTRAINT IF EXISTS receipts_number_key`,
+		`DROP INDEX IF EXISTS idx_receipts_year_sequence`,
+		`ALTER TABLE IF EXISTS utility_tariffs DROP CONSTRAINT IF EXISTS uni_utility_tariffs_utility`,
+		`ALTER TABLE IF EXISTS utility_tariffs DROP CONSTRAINT IF EXISTS utility_tariffs_utility_key`,
+		`DROP INDEX IF EXISTS idx_utility_tariffs_utility`,
+	}
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assignDefaultProperty moves data created before properties existed into a
+// "Main Building" property, along with the receipt numbering of that time.
+func assignDefaultProperty(db *gorm.DB, hadReceiptSequences bool) error {
+	statements := []string{
+		`INSERT INTO properties (name, address, floors, receipt_prefix, created_at, updated_at)
+		SELECT 'Main Building', '', 1, 'RCP', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+		WHERE NOT EXISTS (SELECT 1 FROM properties)
+		AND (EXISTS (SELECT 1 FROM rooms) OR EXISTS (SELECT 1 FROM tenants) OR EXISTS (SELECT 1 FROM expenses))`,
+		`UPDATE rooms SET property_id = (SELECT MIN(id) FROM properties)
+		WHERE property_id IS NULL OR property_id = 0`,
+		`UPDATE tenants SET property_id = COALESCE(
+			(SELECT r.property_id FROM rooms r WHERE r.id = tenants.room_id),
+			(SELECT MIN(id) FROM properties))
+		WHERE property_id IS NULL OR property_id = 0`,
+		`UPDATE payments SET property_id = (SELECT t.property_id FROM tenants t WHERE t.id = payments.tenant_id)
+		WHERE property_id IS NULL OR property_id = 0`,
+		`UPDATE expenses SET property_id = (SELECT MIN(id) FROM properties)
+		WHERE property_id IS NULL OR property_id = 0`,
+		`UPDATE receipts SET property_id = (SELECT p.property_id FROM payments p WHERE p.id = receipts.payment_id)
+		WHERE property_id IS NULL OR property_id = 0`,
+	}
+	if hadReceiptSequences {
+		statements = append(statements,
+			`INSERT INTO receipt_counters (property_id, year, last_value)
+			SELECT (SELECT MIN(id) FROM properties), year, last_value FROM receipt_sequences
+			WHERE EXISTS (SELECT 1 FROM properties)
+			ON CONFLICT DO NOTHING`,
+			`DROP TABLE receipt_sequences`,
+		)
+	}
+
+	for _, stmt := range statements {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// organizationTables hold data that belongs to one organization.
+var organizationTables = []string{
+	"users", "properties", "rooms", "tenants", "payments", "payment_line_items",
+	"payment_transactions", "expenses", "deposits", "deposit_entries",
+	"late_fee_policies", "utility_tariffs", "tariff_tiers", "meter_readings",
+	"letterheads", "receipts", "leases", "occupancies", "audit_entries", "invitations",
+}
+
+// assignDefaultOrganization moves data created before organizations existed
+// into a "Default Organization".
+func assignDefaultOrganization(db *gorm.DB) error {
+	err := db.Exec(`
+		INSERT INTO organizations (name, created_at, updated_at)
+		SELECT 'Default Organization', CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+		WHERE NOT EXISTS (SELECT 1 FROM organizations)
+		AND (EXISTS (SELECT 1 FROM users) OR EXISTS (SELECT 1 FROM properties))
+	`).Error
+	if err != nil {
+		return err
+	}
+
+	for _, table := range organizationTables {
+		err := db.Exec(fmt.Sprintf(
+			"UPDATE %s SET organization_id = (SELECT MIN(id) FROM organizations) WHERE organization_id IS NULL OR organization_id = 0",
+			table,
+		)).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moneyColumns were stored as double precision before amounts became exact.
+var moneyColumns = []struct {
+	table  string
+	column string
+}{
+	{"rooms", "price"},
+	{"tenants", "credit_balance"},
+	{"payments", "amount"},
+	{"payment_line_items", "amount"},
+	{"payment_transactions", "amount"},
+	{"expenses", "amount"},
+	{"deposit_entries", "amount"},
+	{"late_fee_policies", "amount"},
+	{"late_fee_policies", "cap"},
+	{"utility_tariffs", "fixed_charge"},
+	{"tariff_tiers", "rate"},
+	{"meter_readings", "charge"},
+}
+
+// migrateMoneyColumns converts existing floating point amounts to
+// numeric(18,2), rounding each value to the nearest cent.
+func migrateMoneyColumns(db *gorm.DB) error {
+	for _, c := range moneyColumns {
+		var dataType string
+		err := db.Raw(`
+			SELECT data_type FROM information_schema.columns
+			WHERE table_schema = CURRENT_SCHEMA() AND table_name = ? AND column_name = ?
+		`, c.table, c.column).Scan(&dataType).Error
+		if err != nil {
+			return err
+		}
+		if dataType != "double precision" {
+			continue
+		}
+
+		err = db.Exec(fmt.Sprintf(
+			"ALTER TABLE %s ALTER COLUMN %s TYPE numeric(18,2) USING ROUND(%s::numeric, 2)",
+			c.table, c.column, c.column,
+		)).Error
+		if err != nil {
+			return err
+		}
+		log.Printf("Converted %s.%s to numeric(18,2)", c.table, c.column)
 	}
-	log.Println("Database migrated successfully")
+	return nil
 }
@@ -0,0 +1,218 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const postgresDSNEnv = "TEST_POSTGRES_DSN"
+
+var schemaSeq atomic.Int64
+
+// openPostgres connects to a new, empty schema of the database named by
+// TEST_POSTGRES_DSN, and skips the test when it is not set. Only Postgres
+// databases were created by AutoMigrate.
+func openPostgres(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeOnCleanup(t, admin)
+
+	schema := fmt.Sprintf("ezkost_legacy_%d_%d", os.Getpid(), schemaSeq.Add(1))
+	exec(t, admin, "CREATE SCHEMA "+schema)
+	t.Cleanup(func() {
+		if err := admin.Exec("DROP SCHEMA " + schema + " CASCADE").Error; err != nil {
+			t.Errorf("failed to drop schema %s: %v", schema, err)
+		}
+	})
+
+	if strings.Contains(dsn, "://") {
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+		dsn += separator + "search_path=" + schema
+	} else {
+		dsn += " search_path=" + schema
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeOnCleanup(t, db)
+	return db
+}
+
+func closeOnCleanup(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+}
+
+func migrate(t *testing.T, db *gorm.DB) error {
+	t.Helper()
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = migrator.Up()
+	return err
+}
+
+func schemaOf(t *testing.T, db *gorm.DB) map[string]string {
+	t.Helper()
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := sqlDB.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	schema, err := describeSchema(context.Background(), conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+// createLegacySchema makes db look like a database AutoMigrate created before
+// amounts were exact and before organizations, properties, occupancy history
+// and payment transactions existed.
+func createLegacySchema(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exec(t, db, migrator.migrations[0].Up)
+	exec(t, db,
+		`DROP TABLE occupancies, payment_transactions, receipt_counters`,
+		`DROP TABLE properties, organizations`,
+		`ALTER TABLE users DROP COLUMN organization_id`,
+		`ALTER TABLE rooms DROP COLUMN organization_id, DROP COLUMN property_id,
+			ALTER COLUMN price TYPE double precision,
+			ADD CONSTRAINT uni_rooms_room_number UNIQUE (room_number)`,
+		`ALTER TABLE tenants DROP COLUMN organization_id, DROP COLUMN property_id`,
+		`ALTER TABLE payments DROP COLUMN organization_id, DROP COLUMN property_id`,
+		`ALTER TABLE late_fee_policies DROP COLUMN organization_id, DROP COLUMN percentage`,
+		`CREATE TABLE receipt_sequences (year bigint PRIMARY KEY, last_value bigint NOT NULL)`,
+	)
+	exec(t, db,
+		`INSERT INTO users (name, email, password_hash, role) VALUES ('Sari', 'sari@example.com', 'hash', 'owner')`,
+		`INSERT INTO rooms (room_number, price) VALUES ('101', 1500000.004)`,
+		`INSERT INTO tenants (name, phone, room_id, start_date) VALUES ('Budi', '0812', 1, '2025-01-01')`,
+		`INSERT INTO payments (tenant_id, amount, due_date, paid_at, status, payment_method, period)
+			VALUES (1, 1500000, '2025-01-05', '2025-01-03', 'paid', 'cash', '2025-01')`,
+		`INSERT INTO late_fee_policies (type, amount, enabled) VALUES ('percentage', 5, true)`,
+		`INSERT INTO receipt_sequences (year, last_value) VALUES (2025, 7)`,
+	)
+}
+
+func TestLegacyUpgradeMatchesFreshDatabase(t *testing.T) {
+	fresh := openPostgres(t)
+	if err := migrate(t, fresh); err != nil {
+		t.Fatal(err)
+	}
+	legacy := openPostgres(t)
+	createLegacySchema(t, legacy)
+	if err := migrate(t, legacy); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := diffSchemas(schemaOf(t, fresh), schemaOf(t, legacy)); len(diff) > 0 {
+		t.Errorf("upgraded schema differs from a fresh one:\n  %s", strings.Join(diff, "\n  "))
+	}
+
+	// The data was carried over into the organization and property
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`SELECT COUNT(*) FROM organizations`, "1"},
+		{`SELECT COUNT(*) FROM properties`, "1"},
+		{`SELECT price::text FROM rooms WHERE organization_id = 1 AND property_id = 1`, "1500000.00"},
+		{`SELECT COUNT(*) FROM tenants WHERE organization_id = 1 AND property_id = 1`, "1"},
+		{`SELECT COUNT(*) FROM occupancies WHERE tenant_id = 1 AND end_date IS NULL`, "1"},
+		{`SELECT amount::text || ' ' || method FROM payment_transactions WHERE payment_id = 1`, "1500000.00 cash"},
+		{`SELECT amount::text || ' ' || percentage::text FROM late_fee_policies WHERE property_id = 1`, "0.00 5.00"},
+		{`SELECT last_value::text FROM receipt_counters WHERE property_id = 1 AND year = 2025`, "7"},
+		{`SELECT COUNT(*) FROM schema_migrations`, "2"},
+	}
+	for _, tt := range tests {
+		var got string
+		if err := legacy.Raw(tt.query).Scan(&got).Error; err != nil {
+			t.Fatalf("%s: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s = %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestLegacyUpgradeRefusesUnknownSchema(t *testing.T) {
+	legacy := openPostgres(t)
+	createLegacySchema(t, legacy)
+	exec(t, legacy, `ALTER TABLE tenants ALTER COLUMN phone TYPE text`)
+
+	err := migrate(t, legacy)
+	if err == nil || !strings.Contains(err.Error(), "column tenants.phone is text NOT NULL, want character varying(20) NOT NULL") {
+		t.Fatalf("got error %v, want the phone column reported", err)
+	}
+	var recorded int64
+	legacy.Raw(`SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = CURRENT_SCHEMA() AND table_name = 'schema_migrations'`).Scan(&recorded)
+	if recorded != 0 {
+		t.Error("migrations were recorded on a schema that does not match the baseline")
+	}
+}
+
+func TestDiffSchemas(t *testing.T) {
+	want := map[string]string{
+		"column rooms.price":       "numeric(18,2) NOT NULL",
+		"column rooms.room_number": "character varying(20) NOT NULL",
+		"index idx_rooms_price":    "CREATE INDEX idx_rooms_price ON rooms USING btree (price)",
+	}
+	tests := []struct {
+		name string
+		got  map[string]string
+		want []string
+	}{
+		{"same", want, nil},
+		{
+			name: "different",
+			got: map[string]string{
+				"column rooms.price":          "double precision NOT NULL",
+				"column rooms.room_number":    "character varying(20) NOT NULL",
+				"constraint rooms.uni_number": "UNIQUE (room_number)",
+			},
+			want: []string{
+				"column rooms.price is double precision NOT NULL, want numeric(18,2) NOT NULL",
+				"unexpected constraint rooms.uni_number: UNIQUE (room_number)",
+				"missing index idx_rooms_price: CREATE INDEX idx_rooms_price ON rooms USING btree (price)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		if got := diffSchemas(want, tt.got); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,705 @@
+-- Brings a schema created by AutoMigrate up to 0001_baseline: creates the
+-- tables, columns, constraints and indexes it lacks. Columns and indexes that
+-- exist are left as they are; the migrator compares the result with the
+-- baseline afterwards and refuses to continue if they differ.
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id bigserial,
+    name varchar(100) NOT NULL,
+    require_two_factor boolean NOT NULL DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE organizations
+    ADD COLUMN IF NOT EXISTS name varchar(100) NOT NULL,
+    ADD COLUMN IF NOT EXISTS require_two_factor boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+
+CREATE TABLE IF NOT EXISTS users (
+    id bigserial,
+    organization_id bigint,
+    name varchar(100) NOT NULL,
+    email varchar(100) NOT NULL,
+    password_hash varchar(255) NOT NULL,
+    role varchar(20) NOT NULL DEFAULT 'staff',
+    status varchar(20) NOT NULL DEFAULT 'active',
+    two_factor_enabled boolean NOT NULL DEFAULT false,
+    totp_secret varchar(64),
+    totp_last_step bigint NOT NULL DEFAULT 0,
+    failed_logins bigint NOT NULL DEFAULT 0,
+    locked_until timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT uni_users_email UNIQUE (email)
+);
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS name varchar(100) NOT NULL,
+    ADD COLUMN IF NOT EXISTS email varchar(100) NOT NULL,
+    ADD COLUMN IF NOT EXISTS password_hash varchar(255) NOT NULL,
+    ADD COLUMN IF NOT EXISTS role varchar(20) NOT NULL DEFAULT 'staff',
+    ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'active',
+    ADD COLUMN IF NOT EXISTS two_factor_enabled boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS totp_secret varchar(64),
+    ADD COLUMN IF NOT EXISTS totp_last_step bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS failed_logins bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS locked_until timestamptz,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS uni_users_email,
+    ADD CONSTRAINT uni_users_email UNIQUE (email);
+CREATE INDEX IF NOT EXISTS idx_users_organization_id ON users (organization_id);
+
+CREATE TABLE IF NOT EXISTS properties (
+    id bigserial,
+    organization_id bigint,
+    name varchar(100) NOT NULL,
+    address text,
+    floors bigint NOT NULL DEFAULT 1,
+    phone varchar(20),
+    email varchar(100),
+    receipt_prefix varchar(10) NOT NULL DEFAULT 'RCP',
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE properties
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS name varchar(100) NOT NULL,
+    ADD COLUMN IF NOT EXISTS address text,
+    ADD COLUMN IF NOT EXISTS floors bigint NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS phone varchar(20),
+    ADD COLUMN IF NOT EXISTS email varchar(100),
+    ADD COLUMN IF NOT EXISTS receipt_prefix varchar(10) NOT NULL DEFAULT 'RCP',
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_properties_organization_id ON properties (organization_id);
+
+CREATE TABLE IF NOT EXISTS rooms (
+    id bigserial,
+    organization_id bigint,
+    property_id bigint,
+    room_number varchar(20) NOT NULL,
+    floor bigint NOT NULL DEFAULT 0,
+    price numeric(18,2) NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'empty',
+    facilities text,
+    notes text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE rooms
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS property_id bigint,
+    ADD COLUMN IF NOT EXISTS room_number varchar(20) NOT NULL,
+    ADD COLUMN IF NOT EXISTS floor bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS price numeric(18,2) NOT NULL,
+    ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'empty',
+    ADD COLUMN IF NOT EXISTS facilities text,
+    ADD COLUMN IF NOT EXISTS notes text,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_rooms_property_room_number ON rooms (property_id, room_number);
+CREATE INDEX IF NOT EXISTS idx_rooms_property_id ON rooms (property_id);
+CREATE INDEX IF NOT EXISTS idx_rooms_organization_id ON rooms (organization_id);
+
+CREATE TABLE IF NOT EXISTS tenants (
+    id bigserial,
+    organization_id bigint,
+    property_id bigint,
+    name varchar(100) NOT NULL,
+    phone varchar(20) NOT NULL,
+    room_id bigint,
+    start_date timestamptz NOT NULL,
+    end_date timestamptz,
+    status varchar(20) NOT NULL DEFAULT 'active',
+    credit_balance numeric(18,2) NOT NULL DEFAULT '0',
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_rooms_tenant FOREIGN KEY (room_id) REFERENCES rooms(id)
+);
+ALTER TABLE tenants
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS property_id bigint,
+    ADD COLUMN IF NOT EXISTS name varchar(100) NOT NULL,
+    ADD COLUMN IF NOT EXISTS phone varchar(20) NOT NULL,
+    ADD COLUMN IF NOT EXISTS room_id bigint,
+    ADD COLUMN IF NOT EXISTS start_date timestamptz NOT NULL,
+    ADD COLUMN IF NOT EXISTS end_date timestamptz,
+    ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'active',
+    ADD COLUMN IF NOT EXISTS credit_balance numeric(18,2) NOT NULL DEFAULT '0',
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+ALTER TABLE tenants
+    DROP CONSTRAINT IF EXISTS fk_rooms_tenant,
+    ADD CONSTRAINT fk_rooms_tenant FOREIGN KEY (room_id) REFERENCES rooms(id);
+CREATE INDEX IF NOT EXISTS idx_tenants_room_id ON tenants (room_id);
+CREATE INDEX IF NOT EXISTS idx_tenants_property_id ON tenants (property_id);
+CREATE INDEX IF NOT EXISTS idx_tenants_organization_id ON tenants (organization_id);
+
+CREATE TABLE IF NOT EXISTS payments (
+    id bigserial,
+    organization_id bigint,
+    property_id bigint,
+    tenant_id bigint NOT NULL,
+    amount numeric(18,2) NOT NULL,
+    due_date timestamptz NOT NULL,
+    paid_at timestamptz,
+    status varchar(20) NOT NULL DEFAULT 'unpaid',
+    payment_method varchar(20),
+    period varchar(7),
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_tenants_payments FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS property_id bigint,
+    ADD COLUMN IF NOT EXISTS tenant_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS amount numeric(18,2) NOT NULL,
+    ADD COLUMN IF NOT EXISTS due_date timestamptz NOT NULL,
+    ADD COLUMN IF NOT EXISTS paid_at timestamptz,
+    ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'unpaid',
+    ADD COLUMN IF NOT EXISTS payment_method varchar(20),
+    ADD COLUMN IF NOT EXISTS period varchar(7),
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+ALTER TABLE payments
+    DROP CONSTRAINT IF EXISTS fk_tenants_payments,
+    ADD CONSTRAINT fk_tenants_payments FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_tenant_period ON payments (tenant_id, period) WHERE period <> '';
+CREATE INDEX IF NOT EXISTS idx_payments_tenant_id ON payments (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_payments_property_id ON payments (property_id);
+CREATE INDEX IF NOT EXISTS idx_payments_organization_id ON payments (organization_id);
+
+CREATE TABLE IF NOT EXISTS payment_line_items (
+    id bigserial,
+    organization_id bigint,
+    payment_id bigint NOT NULL,
+    type varchar(20) NOT NULL,
+    description varchar(255),
+    amount numeric(18,2) NOT NULL,
+    waived boolean NOT NULL DEFAULT false,
+    waived_by bigint,
+    waived_at timestamptz,
+    waiver_reason text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_payments_line_items FOREIGN KEY (payment_id) REFERENCES payments(id)
+);
+ALTER TABLE payment_line_items
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS payment_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS type varchar(20) NOT NULL,
+    ADD COLUMN IF NOT EXISTS description varchar(255),
+    ADD COLUMN IF NOT EXISTS amount numeric(18,2) NOT NULL,
+    ADD COLUMN IF NOT EXISTS waived boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS waived_by bigint,
+    ADD COLUMN IF NOT EXISTS waived_at timestamptz,
+    ADD COLUMN IF NOT EXISTS waiver_reason text,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+ALTER TABLE payment_line_items
+    DROP CONSTRAINT IF EXISTS fk_payments_line_items,
+    ADD CONSTRAINT fk_payments_line_items FOREIGN KEY (payment_id) REFERENCES payments(id);
+CREATE INDEX IF NOT EXISTS idx_payment_line_items_payment_id ON payment_line_items (payment_id);
+CREATE INDEX IF NOT EXISTS idx_payment_line_items_organization_id ON payment_line_items (organization_id);
+
+CREATE TABLE IF NOT EXISTS payment_transactions (
+    id bigserial,
+    organization_id bigint,
+    payment_id bigint NOT NULL,
+    amount numeric(18,2) NOT NULL,
+    method varchar(20),
+    paid_at timestamptz NOT NULL,
+    note text,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_payments_transactions FOREIGN KEY (payment_id) REFERENCES payments(id)
+);
+ALTER TABLE payment_transactions
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS payment_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS amount numeric(18,2) NOT NULL,
+    ADD COLUMN IF NOT EXISTS method varchar(20),
+    ADD COLUMN IF NOT EXISTS paid_at timestamptz NOT NULL,
+    ADD COLUMN IF NOT EXISTS note text,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz;
+ALTER TABLE payment_transactions
+    DROP CONSTRAINT IF EXISTS fk_payments_transactions,
+    ADD CONSTRAINT fk_payments_transactions FOREIGN KEY (payment_id) REFERENCES payments(id);
+CREATE INDEX IF NOT EXISTS idx_payment_transactions_paid_at ON payment_transactions (paid_at);
+CREATE INDEX IF NOT EXISTS idx_payment_transactions_payment_id ON payment_transactions (payment_id);
+CREATE INDEX IF NOT EXISTS idx_payment_transactions_organization_id ON payment_transactions (organization_id);
+
+CREATE TABLE IF NOT EXISTS expenses (
+    id bigserial,
+    organization_id bigint,
+    property_id bigint,
+    description varchar(255) NOT NULL,
+    amount numeric(18,2) NOT NULL,
+    expense_date timestamptz NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE expenses
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS property_id bigint,
+    ADD COLUMN IF NOT EXISTS description varchar(255) NOT NULL,
+    ADD COLUMN IF NOT EXISTS amount numeric(18,2) NOT NULL,
+    ADD COLUMN IF NOT EXISTS expense_date timestamptz NOT NULL,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_expenses_property_id ON expenses (property_id);
+CREATE INDEX IF NOT EXISTS idx_expenses_organization_id ON expenses (organization_id);
+
+CREATE TABLE IF NOT EXISTS deposits (
+    id bigserial,
+    organization_id bigint,
+    tenant_id bigint NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'held',
+    closed_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_deposits_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+ALTER TABLE deposits
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS tenant_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'held',
+    ADD COLUMN IF NOT EXISTS closed_at timestamptz,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+ALTER TABLE deposits
+    DROP CONSTRAINT IF EXISTS fk_deposits_tenant,
+    ADD CONSTRAINT fk_deposits_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_deposits_tenant_id ON deposits (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_deposits_organization_id ON deposits (organization_id);
+
+CREATE TABLE IF NOT EXISTS deposit_entries (
+    id bigserial,
+    organization_id bigint,
+    deposit_id bigint NOT NULL,
+    type varchar(20) NOT NULL,
+    amount numeric(18,2) NOT NULL,
+    reason text,
+    payment_id bigint,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_deposits_entries FOREIGN KEY (deposit_id) REFERENCES deposits(id)
+);
+ALTER TABLE deposit_entries
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS deposit_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS type varchar(20) NOT NULL,
+    ADD COLUMN IF NOT EXISTS amount numeric(18,2) NOT NULL,
+    ADD COLUMN IF NOT EXISTS reason text,
+    ADD COLUMN IF NOT EXISTS payment_id bigint,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz;
+ALTER TABLE deposit_entries
+    DROP CONSTRAINT IF EXISTS fk_deposits_entries,
+    ADD CONSTRAINT fk_deposits_entries FOREIGN KEY (deposit_id) REFERENCES deposits(id);
+CREATE INDEX IF NOT EXISTS idx_deposit_entries_payment_id ON deposit_entries (payment_id);
+CREATE INDEX IF NOT EXISTS idx_deposit_entries_deposit_id ON deposit_entries (deposit_id);
+CREATE INDEX IF NOT EXISTS idx_deposit_entries_organization_id ON deposit_entries (organization_id);
+
+CREATE TABLE IF NOT EXISTS late_fee_policies (
+    id bigserial,
+    organization_id bigint,
+    type varchar(20) NOT NULL DEFAULT 'flat',
+    amount numeric(18,2) NOT NULL DEFAULT '0',
+    percentage decimal NOT NULL DEFAULT 0,
+    cap numeric(18,2) NOT NULL DEFAULT '0',
+    grace_days bigint NOT NULL DEFAULT 0,
+    enabled boolean NOT NULL DEFAULT false,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE late_fee_policies
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS type varchar(20) NOT NULL DEFAULT 'flat',
+    ADD COLUMN IF NOT EXISTS amount numeric(18,2) NOT NULL DEFAULT '0',
+    ADD COLUMN IF NOT EXISTS percentage decimal NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS cap numeric(18,2) NOT NULL DEFAULT '0',
+    ADD COLUMN IF NOT EXISTS grace_days bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS enabled boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_late_fee_policies_organization_id ON late_fee_policies (organization_id);
+
+CREATE TABLE IF NOT EXISTS utility_tariffs (
+    id bigserial,
+    organization_id bigint,
+    utility varchar(20) NOT NULL,
+    unit varchar(10),
+    fixed_charge numeric(18,2) NOT NULL DEFAULT '0',
+    spike_threshold_percent decimal NOT NULL DEFAULT 0,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE utility_tariffs
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS utility varchar(20) NOT NULL,
+    ADD COLUMN IF NOT EXISTS unit varchar(10),
+    ADD COLUMN IF NOT EXISTS fixed_charge numeric(18,2) NOT NULL DEFAULT '0',
+    ADD COLUMN IF NOT EXISTS spike_threshold_percent decimal NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_utility_tariffs_organization_utility ON utility_tariffs (organization_id, utility);
+
+CREATE TABLE IF NOT EXISTS tariff_tiers (
+    id bigserial,
+    organization_id bigint,
+    tariff_id bigint NOT NULL,
+    up_to decimal NOT NULL DEFAULT 0,
+    rate numeric(18,2) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_utility_tariffs_tiers FOREIGN KEY (tariff_id) REFERENCES utility_tariffs(id)
+);
+ALTER TABLE tariff_tiers
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS tariff_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS up_to decimal NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rate numeric(18,2) NOT NULL;
+ALTER TABLE tariff_tiers
+    DROP CONSTRAINT IF EXISTS fk_utility_tariffs_tiers,
+    ADD CONSTRAINT fk_utility_tariffs_tiers FOREIGN KEY (tariff_id) REFERENCES utility_tariffs(id);
+CREATE INDEX IF NOT EXISTS idx_tariff_tiers_tariff_id ON tariff_tiers (tariff_id);
+CREATE INDEX IF NOT EXISTS idx_tariff_tiers_organization_id ON tariff_tiers (organization_id);
+
+CREATE TABLE IF NOT EXISTS meter_readings (
+    id bigserial,
+    organization_id bigint,
+    room_id bigint NOT NULL,
+    utility varchar(20) NOT NULL,
+    period varchar(7) NOT NULL,
+    reading decimal NOT NULL,
+    consumption decimal NOT NULL DEFAULT 0,
+    charge numeric(18,2) NOT NULL DEFAULT '0',
+    flag varchar(20),
+    payment_id bigint,
+    recorded_by bigint,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_meter_readings_room FOREIGN KEY (room_id) REFERENCES rooms(id)
+);
+ALTER TABLE meter_readings
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS room_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS utility varchar(20) NOT NULL,
+    ADD COLUMN IF NOT EXISTS period varchar(7) NOT NULL,
+    ADD COLUMN IF NOT EXISTS reading decimal NOT NULL,
+    ADD COLUMN IF NOT EXISTS consumption decimal NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS charge numeric(18,2) NOT NULL DEFAULT '0',
+    ADD COLUMN IF NOT EXISTS flag varchar(20),
+    ADD COLUMN IF NOT EXISTS payment_id bigint,
+    ADD COLUMN IF NOT EXISTS recorded_by bigint,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+ALTER TABLE meter_readings
+    DROP CONSTRAINT IF EXISTS fk_meter_readings_room,
+    ADD CONSTRAINT fk_meter_readings_room FOREIGN KEY (room_id) REFERENCES rooms(id);
+CREATE INDEX IF NOT EXISTS idx_meter_readings_payment_id ON meter_readings (payment_id);
+CREATE INDEX IF NOT EXISTS idx_meter_readings_flag ON meter_readings (flag);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_meter_readings_room_utility_period ON meter_readings (room_id, utility, period);
+CREATE INDEX IF NOT EXISTS idx_meter_readings_organization_id ON meter_readings (organization_id);
+
+CREATE TABLE IF NOT EXISTS letterheads (
+    id bigserial,
+    organization_id bigint,
+    name varchar(100) NOT NULL,
+    address text,
+    phone varchar(20),
+    email varchar(100),
+    footer text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE letterheads
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS name varchar(100) NOT NULL,
+    ADD COLUMN IF NOT EXISTS address text,
+    ADD COLUMN IF NOT EXISTS phone varchar(20),
+    ADD COLUMN IF NOT EXISTS email varchar(100),
+    ADD COLUMN IF NOT EXISTS footer text,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_letterheads_organization_id ON letterheads (organization_id);
+
+CREATE TABLE IF NOT EXISTS receipts (
+    id bigserial,
+    organization_id bigint,
+    property_id bigint,
+    payment_id bigint NOT NULL,
+    year bigint NOT NULL,
+    sequence bigint NOT NULL,
+    number varchar(30) NOT NULL,
+    issued_at timestamptz NOT NULL,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE receipts
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS property_id bigint,
+    ADD COLUMN IF NOT EXISTS payment_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS year bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS sequence bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS number varchar(30) NOT NULL,
+    ADD COLUMN IF NOT EXISTS issued_at timestamptz NOT NULL,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_receipts_number ON receipts (number);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_receipts_payment_id ON receipts (payment_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_receipts_property_year_sequence ON receipts (property_id, year, sequence);
+CREATE INDEX IF NOT EXISTS idx_receipts_organization_id ON receipts (organization_id);
+
+CREATE TABLE IF NOT EXISTS receipt_counters (
+    property_id bigint,
+    year bigint,
+    last_value bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (property_id, year)
+);
+ALTER TABLE receipt_counters
+    ADD COLUMN IF NOT EXISTS property_id bigint,
+    ADD COLUMN IF NOT EXISTS year bigint,
+    ADD COLUMN IF NOT EXISTS last_value bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS leases (
+    id bigserial,
+    organization_id bigint,
+    tenant_id bigint NOT NULL,
+    room_id bigint NOT NULL,
+    start_date timestamptz NOT NULL,
+    end_date timestamptz NOT NULL,
+    term_months bigint NOT NULL,
+    rent numeric(18,2) NOT NULL,
+    billing_cycle varchar(20) NOT NULL DEFAULT 'monthly',
+    deposit numeric(18,2) NOT NULL DEFAULT '0',
+    notice_days bigint NOT NULL DEFAULT 0,
+    status varchar(20) NOT NULL DEFAULT 'active',
+    terminated_at timestamptz,
+    termination_reason text,
+    renewed_from_id bigint,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_leases_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT fk_leases_room FOREIGN KEY (room_id) REFERENCES rooms(id)
+);
+ALTER TABLE leases
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS tenant_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS room_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS start_date timestamptz NOT NULL,
+    ADD COLUMN IF NOT EXISTS end_date timestamptz NOT NULL,
+    ADD COLUMN IF NOT EXISTS term_months bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS rent numeric(18,2) NOT NULL,
+    ADD COLUMN IF NOT EXISTS billing_cycle varchar(20) NOT NULL DEFAULT 'monthly',
+    ADD COLUMN IF NOT EXISTS deposit numeric(18,2) NOT NULL DEFAULT '0',
+    ADD COLUMN IF NOT EXISTS notice_days bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'active',
+    ADD COLUMN IF NOT EXISTS terminated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS termination_reason text,
+    ADD COLUMN IF NOT EXISTS renewed_from_id bigint,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+ALTER TABLE leases
+    DROP CONSTRAINT IF EXISTS fk_leases_tenant,
+    ADD CONSTRAINT fk_leases_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+ALTER TABLE leases
+    DROP CONSTRAINT IF EXISTS fk_leases_room,
+    ADD CONSTRAINT fk_leases_room FOREIGN KEY (room_id) REFERENCES rooms(id);
+CREATE INDEX IF NOT EXISTS idx_leases_end_date ON leases (end_date);
+CREATE INDEX IF NOT EXISTS idx_leases_room_id ON leases (room_id);
+CREATE INDEX IF NOT EXISTS idx_leases_tenant_id ON leases (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_leases_organization_id ON leases (organization_id);
+
+CREATE TABLE IF NOT EXISTS occupancies (
+    id bigserial,
+    organization_id bigint,
+    room_id bigint NOT NULL,
+    tenant_id bigint NOT NULL,
+    start_date timestamptz NOT NULL,
+    end_date timestamptz,
+    reason text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_occupancies_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT fk_occupancies_room FOREIGN KEY (room_id) REFERENCES rooms(id)
+);
+ALTER TABLE occupancies
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS room_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS tenant_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS start_date timestamptz NOT NULL,
+    ADD COLUMN IF NOT EXISTS end_date timestamptz,
+    ADD COLUMN IF NOT EXISTS reason text,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+ALTER TABLE occupancies
+    DROP CONSTRAINT IF EXISTS fk_occupancies_tenant,
+    ADD CONSTRAINT fk_occupancies_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id);
+ALTER TABLE occupancies
+    DROP CONSTRAINT IF EXISTS fk_occupancies_room,
+    ADD CONSTRAINT fk_occupancies_room FOREIGN KEY (room_id) REFERENCES rooms(id);
+CREATE INDEX IF NOT EXISTS idx_occupancies_tenant_id ON occupancies (tenant_id);
+CREATE INDEX IF NOT EXISTS idx_occupancies_room_id ON occupancies (room_id);
+CREATE INDEX IF NOT EXISTS idx_occupancies_organization_id ON occupancies (organization_id);
+
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id bigserial,
+    organization_id bigint,
+    user_id bigint,
+    role varchar(20),
+    action varchar(50) NOT NULL,
+    entity_type varchar(50),
+    entity_id bigint,
+    ip varchar(45),
+    detail text,
+    before text,
+    after text,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE audit_entries
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS user_id bigint,
+    ADD COLUMN IF NOT EXISTS role varchar(20),
+    ADD COLUMN IF NOT EXISTS action varchar(50) NOT NULL,
+    ADD COLUMN IF NOT EXISTS entity_type varchar(50),
+    ADD COLUMN IF NOT EXISTS entity_id bigint,
+    ADD COLUMN IF NOT EXISTS ip varchar(45),
+    ADD COLUMN IF NOT EXISTS detail text,
+    ADD COLUMN IF NOT EXISTS before text,
+    ADD COLUMN IF NOT EXISTS after text,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_audit_entries_entity ON audit_entries (entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_action ON audit_entries (action);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_user_id ON audit_entries (user_id);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_organization_id ON audit_entries (organization_id);
+
+CREATE TABLE IF NOT EXISTS invitations (
+    id bigserial,
+    organization_id bigint,
+    email varchar(100) NOT NULL,
+    role varchar(20) NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    invited_by bigint NOT NULL,
+    expires_at timestamptz NOT NULL,
+    accepted_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE invitations
+    ADD COLUMN IF NOT EXISTS organization_id bigint,
+    ADD COLUMN IF NOT EXISTS email varchar(100) NOT NULL,
+    ADD COLUMN IF NOT EXISTS role varchar(20) NOT NULL,
+    ADD COLUMN IF NOT EXISTS token_hash varchar(64) NOT NULL,
+    ADD COLUMN IF NOT EXISTS invited_by bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS expires_at timestamptz NOT NULL,
+    ADD COLUMN IF NOT EXISTS accepted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invitations_token_hash ON invitations (token_hash);
+CREATE INDEX IF NOT EXISTS idx_invitations_organization_id ON invitations (organization_id);
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id bigserial,
+    user_id bigint NOT NULL,
+    revoked_at timestamptz,
+    revoked_reason varchar(50),
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE sessions
+    ADD COLUMN IF NOT EXISTS user_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS revoked_at timestamptz,
+    ADD COLUMN IF NOT EXISTS revoked_reason varchar(50),
+    ADD COLUMN IF NOT EXISTS created_at timestamptz,
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (user_id);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id bigserial,
+    session_id bigint NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE refresh_tokens
+    ADD COLUMN IF NOT EXISTS session_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS token_hash varchar(64) NOT NULL,
+    ADD COLUMN IF NOT EXISTS expires_at timestamptz NOT NULL,
+    ADD COLUMN IF NOT EXISTS used_at timestamptz,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON refresh_tokens (session_id);
+
+CREATE TABLE IF NOT EXISTS password_resets (
+    id bigserial,
+    user_id bigint NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE password_resets
+    ADD COLUMN IF NOT EXISTS user_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS token_hash varchar(64) NOT NULL,
+    ADD COLUMN IF NOT EXISTS expires_at timestamptz NOT NULL,
+    ADD COLUMN IF NOT EXISTS used_at timestamptz,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_password_resets_token_hash ON password_resets (token_hash);
+CREATE INDEX IF NOT EXISTS idx_password_resets_user_id ON password_resets (user_id);
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id bigserial,
+    user_id bigint NOT NULL,
+    code_hash varchar(64) NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE recovery_codes
+    ADD COLUMN IF NOT EXISTS user_id bigint NOT NULL,
+    ADD COLUMN IF NOT EXISTS code_hash varchar(64) NOT NULL,
+    ADD COLUMN IF NOT EXISTS used_at timestamptz,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_user_id ON recovery_codes (user_id);
+
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id bigserial,
+    user_id bigint,
+    email varchar(100),
+    ip varchar(45),
+    reason varchar(30) NOT NULL,
+    created_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE login_attempts
+    ADD COLUMN IF NOT EXISTS user_id bigint,
+    ADD COLUMN IF NOT EXISTS email varchar(100),
+    ADD COLUMN IF NOT EXISTS ip varchar(45),
+    ADD COLUMN IF NOT EXISTS reason varchar(30) NOT NULL,
+    ADD COLUMN IF NOT EXISTS created_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_login_attempts_email ON login_attempts (email);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_user_id ON login_attempts (user_id);
@@ -0,0 +1,469 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations
+var migrationFiles embed.FS
+
+// migrationLockKey identifies the advisory lock held while migrating, so
+// replicas starting together apply each migration once.
+const migrationLockKey int64 = 7_239_410_021
+
+// baselineVersion is the migration describing the schema AutoMigrate used to
+// create.
+const baselineVersion = 1
+
+// Migration is one versioned schema change, read from a pair of
+// NNNN_name.up.sql and NNNN_name.down.sql files.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus is a migration with the time it was applied, nil while it
+// is pending.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Migrator applies the migrations embedded for the dialect of a database and
+// records them in the schema_migrations table.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, path.Join("migrations", db.Dialector.Name()))
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// loadMigrations reads the migrations in dir, ordered by version.
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for %s: %w", path.Base(dir), err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		name := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		prefix, label, ok := strings.Cut(base, "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s is not named NNNN_name.%s.sql", name, direction)
+		}
+		script, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: label}
+			byVersion[version] = migration
+		} else if migration.Name != label {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, migration.Name, label)
+		}
+		if direction == "up" {
+			migration.Up = string(script)
+		} else {
+			migration.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %04d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	if len(migrations) == 0 || migrations[0].Version != baselineVersion {
+		return nil, fmt.Errorf("migrations for %s do not start with the baseline", path.Base(dir))
+	}
+	return migrations, nil
+}
+
+// Up applies every pending migration in order and returns the ones applied.
+// A database created by AutoMigrate is first brought up to the baseline and
+// the baseline recorded as applied.
+func (m *Migrator) Up() ([]Migration, error) {
+	var applied []Migration
+	err := m.locked(func(ctx context.Context, conn *sql.Conn) error {
+		legacy, err := m.isLegacy(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if legacy {
+			log.Println("Upgrading database created by AutoMigrate to the baseline migration")
+			if err := m.upgradeLegacy(ctx, conn); err != nil {
+				return err
+			}
+			if err := m.checkBaseline(ctx, conn); err != nil {
+				return err
+			}
+		}
+		if err := m.createMigrationsTable(ctx, conn); err != nil {
+			return err
+		}
+		if legacy {
+			if err := recordMigration(ctx, conn, m.migrations[0]); err != nil {
+				return err
+			}
+		}
+
+		done, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			if err := runMigration(ctx, conn, migration.Up, func(tx *sql.Tx) error {
+				return recordMigration(ctx, tx, migration)
+			}); err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			log.Printf("Applied migration %04d_%s", migration.Version, migration.Name)
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the last steps applied migrations, newest first, and
+// returns the ones rolled back.
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.locked(func(ctx context.Context, conn *sql.Conn) error {
+		if err := m.createMigrationsTable(ctx, conn); err != nil {
+			return err
+		}
+		done, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %04d_%s cannot be rolled back", migration.Version, migration.Name)
+			}
+			if err := runMigration(ctx, conn, migration.Down, func(tx *sql.Tx) error {
+				_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+				return err
+			}); err != nil {
+				return fmt.Errorf("rollback of %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			log.Printf("Rolled back migration %04d_%s", migration.Version, migration.Name)
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every known migration and when it was applied.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.locked(func(ctx context.Context, conn *sql.Conn) error {
+		recorded, err := m.hasTable(ctx, conn, "schema_migrations")
+		if err != nil {
+			return err
+		}
+		done := make(map[int]time.Time)
+		if recorded {
+			if done, err = appliedMigrations(ctx, conn); err != nil {
+				return err
+			}
+		}
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Migration: migration}
+			if appliedAt, ok := done[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// locked runs fn on one connection while holding the migration lock. SQLite
+// databases are only opened by one process and need no lock.
+func (m *Migrator) locked(fn func(ctx context.Context, conn *sql.Conn) error) error {
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if m.db.Dialector.Name() == DriverPostgres {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+			return fmt.Errorf("failed to take migration lock: %w", err)
+		}
+		defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockKey)
+	}
+
+	return fn(ctx, conn)
+}
+
+// upgradeLegacy runs upgradeLegacySchema in one transaction on conn, which
+// holds the migration lock, so a failed upgrade leaves the database as it was.
+func (m *Migrator) upgradeLegacy(ctx context.Context, conn *sql.Conn) error {
+	db := m.db.Session(&gorm.Session{NewDB: true, Context: ctx})
+	db.Statement.ConnPool = conn
+	return db.Transaction(upgradeLegacySchema)
+}
+
+// isLegacy reports whether the schema was created by AutoMigrate, before
+// migrations were recorded. Only Postgres databases predate migrations.
+func (m *Migrator) isLegacy(ctx context.Context, conn *sql.Conn) (bool, error) {
+	if m.db.Dialector.Name() != DriverPostgres {
+		return false, nil
+	}
+	recorded, err := m.hasTable(ctx, conn, "schema_migrations")
+	if err != nil || recorded {
+		return false, err
+	}
+	return m.hasTable(ctx, conn, "users")
+}
+
+// baselineCheckSchema is the scratch schema checkBaseline builds the
+// baseline in. It is created inside a transaction that is rolled back.
+const baselineCheckSchema = "ezkost_baseline_check"
+
+// checkBaseline compares the schema of an upgraded legacy database with the
+// one the baseline migration creates, and fails listing the differences, so
+// later migrations never run on a schema they were not written for.
+func (m *Migrator) checkBaseline(ctx context.Context, conn *sql.Conn) error {
+	upgraded, err := describeSchema(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.ExecContext(ctx, "CREATE SCHEMA "+baselineCheckSchema+"; SET LOCAL search_path TO "+baselineCheckSchema)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, m.migrations[0].Up); err != nil {
+		return fmt.Errorf("failed to build the baseline to compare with: %w", err)
+	}
+	baseline, err := describeSchema(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if diff := diffSchemas(baseline, upgraded); len(diff) > 0 {
+		return fmt.Errorf("upgraded database does not match migration %04d_%s, fix these by hand and start again:\n  %s",
+			m.migrations[0].Version, m.migrations[0].Name, strings.Join(diff, "\n  "))
+	}
+	return nil
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// describeSchema lists the columns, indexes and constraints of the tables in
+// the current Postgres schema, keyed by "column table.name", "index name" and
+// "constraint table.name".
+func describeSchema(ctx context.Context, q querier) (map[string]string, error) {
+	const namespace = "(SELECT oid FROM pg_namespace WHERE nspname = CURRENT_SCHEMA())"
+	queries := []struct {
+		kind  string
+		query string
+	}{
+		{"column", `
+			SELECT c.relname || '.' || a.attname,
+				format_type(a.atttypid, a.atttypmod)
+				|| CASE WHEN a.attnotnull THEN ' NOT NULL' ELSE '' END
+				|| COALESCE(' DEFAULT ' || pg_get_expr(d.adbin, d.adrelid), '')
+			FROM pg_attribute a
+			JOIN pg_class c ON c.oid = a.attrelid
+			LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+			WHERE c.relkind = 'r' AND c.relnamespace = ` + namespace + `
+			AND a.attnum > 0 AND NOT a.attisdropped`},
+		// Index definitions name the table with its schema
+		{"index", `
+			SELECT i.relname, replace(pg_get_indexdef(i.oid), ' ON ' || quote_ident(CURRENT_SCHEMA()) || '.', ' ON ')
+			FROM pg_class i
+			WHERE i.relkind = 'i' AND i.relnamespace = ` + namespace},
+		{"constraint", `
+			SELECT c.relname || '.' || k.conname, pg_get_constraintdef(k.oid)
+			FROM pg_constraint k
+			JOIN pg_class c ON c.oid = k.conrelid
+			WHERE k.contype IN ('p', 'u', 'f') AND c.relnamespace = ` + namespace},
+	}
+
+	schema := make(map[string]string)
+	for _, part := range queries {
+		rows, err := q.QueryContext(ctx, part.query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe %ss: %w", part.kind, err)
+		}
+		for rows.Next() {
+			var name, definition string
+			if err := rows.Scan(&name, &definition); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			schema[part.kind+" "+name] = definition
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return schema, nil
+}
+
+// diffSchemas describes how got differs from want, one line per object in
+// name order.
+func diffSchemas(want, got map[string]string) []string {
+	names := make([]string, 0, len(want))
+	for name := range want {
+		names = append(names, name)
+	}
+	for name := range got {
+		if _, ok := want[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var diff []string
+	for _, name := range names {
+		wanted, inWant := want[name]
+		actual, inGot := got[name]
+		switch {
+		case !inGot:
+			diff = append(diff, fmt.Sprintf("missing %s: %s", name, wanted))
+		case !inWant:
+			diff = append(diff, fmt.Sprintf("unexpected %s: %s", name, actual))
+		case actual != wanted:
+			diff = append(diff, fmt.Sprintf("%s is %s, want %s", name, actual, wanted))
+		}
+	}
+	return diff
+}
+
+func (m *Migrator) hasTable(ctx context.Context, conn *sql.Conn, table string) (bool, error) {
+	query := "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = CURRENT_SCHEMA() AND table_name = $1"
+	if m.db.Dialector.Name() == DriverSQLite {
+		query = "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = $1"
+	}
+	var count int
+	err := conn.QueryRowContext(ctx, query, table).Scan(&count)
+	return count > 0, err
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func (m *Migrator) createMigrationsTable(ctx context.Context, conn *sql.Conn) error {
+	timestamp := "timestamptz"
+	if m.db.Dialector.Name() == DriverSQLite {
+		timestamp = "datetime"
+	}
+	_, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version bigint PRIMARY KEY,
+			name varchar(255) NOT NULL,
+			applied_at `+timestamp+` NOT NULL
+		)
+	`)
+	return err
+}
+
+func appliedMigrations(ctx context.Context, conn *sql.Conn) (map[int]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+func recordMigration(ctx context.Context, conn execer, migration Migration) error {
+	_, err := conn.ExecContext(ctx,
+		"INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)",
+		migration.Version, migration.Name, time.Now(),
+	)
+	return err
+}
+
+// runMigration runs a script and its bookkeeping in one transaction.
+func runMigration(ctx context.Context, conn *sql.Conn, script string, record func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := record(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"ezkost/internal/config"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// openMemory returns an empty in-memory SQLite database for one test.
+func openMemory(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := ConnectDB(&config.Config{DBDriver: DriverMemory})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return db
+}
+
+func exec(t *testing.T, db *gorm.DB, statements ...string) {
+	t.Helper()
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("%s: %v", statement, err)
+		}
+	}
+}
+
+func TestLateFeePolicyPropertyMigration(t *testing.T) {
+	db := openMemory(t)
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatal(err)
+	}
+
+	// One policy per organization, as before policies had a property
+	exec(t, db,
+		`INSERT INTO organizations (id, name) VALUES (1, 'Kos Melati'), (2, 'Kos Mawar'), (3, 'Kos Baru')`,
+		`INSERT INTO properties (id, organization_id, name) VALUES (1, 1, 'Melati 1'), (2, 1, 'Melati 2'), (3, 2, 'Mawar')`,
+		`INSERT INTO late_fee_policies (organization_id, type, amount, grace_days, enabled) VALUES
+			(1, 'flat', 50000, 3, true), (2, 'per_day', 1000, 0, false), (3, 'flat', 1, 0, true)`,
+	)
+	if _, err := migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+
+	type policy struct {
+		OrganizationID uint
+		PropertyID     uint
+		Type           string
+		GraceDays      int
+		Enabled        bool
+	}
+	var got []policy
+	err = db.Raw(`SELECT organization_id, property_id, type, grace_days, enabled FROM late_fee_policies ORDER BY property_id`).
+		Scan(&got).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []policy{
+		{1, 1, "flat", 3, true},
+		{1, 2, "flat", 3, true},
+		{2, 3, "per_day", 0, false},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("policies = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("policy %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// A property has one policy
+	err = db.Exec(`INSERT INTO late_fee_policies (organization_id, property_id, type) VALUES (1, 1, 'flat')`).Error
+	if err == nil {
+		t.Error("second policy for property 1 was stored")
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatal(err)
+	}
+	var count int64
+	if err := db.Raw(`SELECT COUNT(*) FROM late_fee_policies`).Scan(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("%d policies after rolling back, want one per organization", count)
+	}
+}
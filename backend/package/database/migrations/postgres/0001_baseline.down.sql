@@ -0,0 +1,27 @@
+DROP TABLE IF EXISTS login_attempts;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS password_resets;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS invitations;
+DROP TABLE IF EXISTS audit_entries;
+DROP TABLE IF EXISTS occupancies;
+DROP TABLE IF EXISTS leases;
+DROP TABLE IF EXISTS receipt_counters;
+DROP TABLE IF EXISTS receipts;
+DROP TABLE IF EXISTS letterheads;
+DROP TABLE IF EXISTS meter_readings;
+DROP TABLE IF EXISTS tariff_tiers;
+DROP TABLE IF EXISTS utility_tariffs;
+DROP TABLE IF EXISTS late_fee_policies;
+DROP TABLE IF EXISTS deposit_entries;
+DROP TABLE IF EXISTS deposits;
+DROP TABLE IF EXISTS expenses;
+DROP TABLE IF EXISTS payment_transactions;
+DROP TABLE IF EXISTS payment_line_items;
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS tenants;
+DROP TABLE IF EXISTS rooms;
+DROP TABLE IF EXISTS properties;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,14 @@
+-- Late fee policies belong to a property. Every property starts with the
+-- policy its organization had.
+
+ALTER TABLE late_fee_policies ADD COLUMN property_id bigint;
+
+INSERT INTO late_fee_policies (organization_id, property_id, type, amount, percentage, cap, grace_days, enabled, created_at, updated_at)
+SELECT p.organization_id, properties.id, p.type, p.amount, p.percentage, p.cap, p.grace_days, p.enabled, p.created_at, p.updated_at
+FROM late_fee_policies p
+JOIN properties ON properties.organization_id = p.organization_id
+WHERE p.id IN (SELECT MIN(id) FROM late_fee_policies GROUP BY organization_id);
+
+DELETE FROM late_fee_policies WHERE property_id IS NULL;
+
+CREATE UNIQUE INDEX idx_late_fee_policies_organization_property ON late_fee_policies (organization_id, property_id);
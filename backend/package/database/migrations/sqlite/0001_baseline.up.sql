@@ -0,0 +1,396 @@
+-- Baseline: the schema of the model package, in SQLite types, as of the
+-- first release that could run on SQLite.
+
+CREATE TABLE organizations (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text NOT NULL,
+    require_two_factor numeric NOT NULL DEFAULT false,
+    created_at datetime,
+    updated_at datetime
+);
+
+CREATE TABLE users (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    name text NOT NULL,
+    email text NOT NULL,
+    password_hash text NOT NULL,
+    role text NOT NULL DEFAULT 'staff',
+    status text NOT NULL DEFAULT 'active',
+    two_factor_enabled numeric NOT NULL DEFAULT false,
+    totp_secret text,
+    totp_last_step integer NOT NULL DEFAULT 0,
+    failed_logins integer NOT NULL DEFAULT 0,
+    locked_until datetime,
+    created_at datetime,
+    updated_at datetime,
+    CONSTRAINT uni_users_email UNIQUE (email)
+);
+CREATE INDEX idx_users_organization_id ON users (organization_id);
+
+CREATE TABLE properties (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    name text NOT NULL,
+    address text,
+    floors integer NOT NULL DEFAULT 1,
+    phone text,
+    email text,
+    receipt_prefix text NOT NULL DEFAULT 'RCP',
+    created_at datetime,
+    updated_at datetime
+);
+CREATE INDEX idx_properties_organization_id ON properties (organization_id);
+
+CREATE TABLE rooms (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    property_id integer,
+    room_number text NOT NULL,
+    floor integer NOT NULL DEFAULT 0,
+    price numeric(18,2) NOT NULL,
+    status text NOT NULL DEFAULT 'empty',
+    facilities text,
+    notes text,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE UNIQUE INDEX idx_rooms_property_room_number ON rooms (property_id, room_number);
+CREATE INDEX idx_rooms_property_id ON rooms (property_id);
+CREATE INDEX idx_rooms_organization_id ON rooms (organization_id);
+
+CREATE TABLE tenants (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    property_id integer,
+    name text NOT NULL,
+    phone text NOT NULL,
+    room_id integer,
+    start_date datetime NOT NULL,
+    end_date datetime,
+    status text NOT NULL DEFAULT 'active',
+    credit_balance numeric(18,2) NOT NULL DEFAULT '0',
+    created_at datetime,
+    updated_at datetime,
+    CONSTRAINT fk_rooms_tenant FOREIGN KEY (room_id) REFERENCES rooms(id)
+);
+CREATE INDEX idx_tenants_room_id ON tenants (room_id);
+CREATE INDEX idx_tenants_property_id ON tenants (property_id);
+CREATE INDEX idx_tenants_organization_id ON tenants (organization_id);
+
+CREATE TABLE payments (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    property_id integer,
+    tenant_id integer NOT NULL,
+    amount numeric(18,2) NOT NULL,
+    due_date datetime NOT NULL,
+    paid_at datetime,
+    status text NOT NULL DEFAULT 'unpaid',
+    payment_method text,
+    period text,
+    created_at datetime,
+    updated_at datetime,
+    CONSTRAINT fk_tenants_payments FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_payments_tenant_period ON payments (tenant_id, period) WHERE period <> '';
+CREATE INDEX idx_payments_tenant_id ON payments (tenant_id);
+CREATE INDEX idx_payments_property_id ON payments (property_id);
+CREATE INDEX idx_payments_organization_id ON payments (organization_id);
+
+CREATE TABLE payment_line_items (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    payment_id integer NOT NULL,
+    type text NOT NULL,
+    description text,
+    amount numeric(18,2) NOT NULL,
+    waived numeric NOT NULL DEFAULT false,
+    waived_by integer,
+    waived_at datetime,
+    waiver_reason text,
+    created_at datetime,
+    updated_at datetime,
+    CONSTRAINT fk_payments_line_items FOREIGN KEY (payment_id) REFERENCES payments(id)
+);
+CREATE INDEX idx_payment_line_items_payment_id ON payment_line_items (payment_id);
+CREATE INDEX idx_payment_line_items_organization_id ON payment_line_items (organization_id);
+
+CREATE TABLE payment_transactions (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    payment_id integer NOT NULL,
+    amount numeric(18,2) NOT NULL,
+    method text,
+    paid_at datetime NOT NULL,
+    note text,
+    created_at datetime,
+    CONSTRAINT fk_payments_transactions FOREIGN KEY (payment_id) REFERENCES payments(id)
+);
+CREATE INDEX idx_payment_transactions_paid_at ON payment_transactions (paid_at);
+CREATE INDEX idx_payment_transactions_payment_id ON payment_transactions (payment_id);
+CREATE INDEX idx_payment_transactions_organization_id ON payment_transactions (organization_id);
+
+CREATE TABLE expenses (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    property_id integer,
+    description text NOT NULL,
+    amount numeric(18,2) NOT NULL,
+    expense_date datetime NOT NULL,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE INDEX idx_expenses_property_id ON expenses (property_id);
+CREATE INDEX idx_expenses_organization_id ON expenses (organization_id);
+
+CREATE TABLE deposits (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    tenant_id integer NOT NULL,
+    status text NOT NULL DEFAULT 'held',
+    closed_at datetime,
+    created_at datetime,
+    updated_at datetime,
+    CONSTRAINT fk_deposits_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE UNIQUE INDEX idx_deposits_tenant_id ON deposits (tenant_id);
+CREATE INDEX idx_deposits_organization_id ON deposits (organization_id);
+
+CREATE TABLE deposit_entries (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    deposit_id integer NOT NULL,
+    type text NOT NULL,
+    amount numeric(18,2) NOT NULL,
+    reason text,
+    payment_id integer,
+    created_at datetime,
+    CONSTRAINT fk_deposits_entries FOREIGN KEY (deposit_id) REFERENCES deposits(id)
+);
+CREATE INDEX idx_deposit_entries_payment_id ON deposit_entries (payment_id);
+CREATE INDEX idx_deposit_entries_deposit_id ON deposit_entries (deposit_id);
+CREATE INDEX idx_deposit_entries_organization_id ON deposit_entries (organization_id);
+
+CREATE TABLE late_fee_policies (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    type text NOT NULL DEFAULT 'flat',
+    amount numeric(18,2) NOT NULL DEFAULT '0',
+    percentage real NOT NULL DEFAULT 0,
+    cap numeric(18,2) NOT NULL DEFAULT '0',
+    grace_days integer NOT NULL DEFAULT 0,
+    enabled numeric NOT NULL DEFAULT false,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE INDEX idx_late_fee_policies_organization_id ON late_fee_policies (organization_id);
+
+CREATE TABLE utility_tariffs (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    utility text NOT NULL,
+    unit text,
+    fixed_charge numeric(18,2) NOT NULL DEFAULT '0',
+    spike_threshold_percent real NOT NULL DEFAULT 0,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE UNIQUE INDEX idx_utility_tariffs_organization_utility ON utility_tariffs (organization_id, utility);
+
+CREATE TABLE tariff_tiers (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    tariff_id integer NOT NULL,
+    up_to real NOT NULL DEFAULT 0,
+    rate numeric(18,2) NOT NULL,
+    CONSTRAINT fk_utility_tariffs_tiers FOREIGN KEY (tariff_id) REFERENCES utility_tariffs(id)
+);
+CREATE INDEX idx_tariff_tiers_tariff_id ON tariff_tiers (tariff_id);
+CREATE INDEX idx_tariff_tiers_organization_id ON tariff_tiers (organization_id);
+
+CREATE TABLE meter_readings (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    room_id integer NOT NULL,
+    utility text NOT NULL,
+    period text NOT NULL,
+    reading real NOT NULL,
+    consumption real NOT NULL DEFAULT 0,
+    charge numeric(18,2) NOT NULL DEFAULT '0',
+    flag text,
+    payment_id integer,
+    recorded_by integer,
+    created_at datetime,
+    updated_at datetime,
+    CONSTRAINT fk_meter_readings_room FOREIGN KEY (room_id) REFERENCES rooms(id)
+);
+CREATE INDEX idx_meter_readings_payment_id ON meter_readings (payment_id);
+CREATE INDEX idx_meter_readings_flag ON meter_readings (flag);
+CREATE UNIQUE INDEX idx_meter_readings_room_utility_period ON meter_readings (room_id, utility, period);
+CREATE INDEX idx_meter_readings_organization_id ON meter_readings (organization_id);
+
+CREATE TABLE letterheads (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    name text NOT NULL,
+    address text,
+    phone text,
+    email text,
+    footer text,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE INDEX idx_letterheads_organization_id ON letterheads (organization_id);
+
+CREATE TABLE receipts (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    property_id integer,
+    payment_id integer NOT NULL,
+    year integer NOT NULL,
+    sequence integer NOT NULL,
+    number text NOT NULL,
+    issued_at datetime NOT NULL,
+    created_at datetime
+);
+CREATE INDEX idx_receipts_number ON receipts (number);
+CREATE UNIQUE INDEX idx_receipts_payment_id ON receipts (payment_id);
+CREATE UNIQUE INDEX idx_receipts_property_year_sequence ON receipts (property_id, year, sequence);
+CREATE INDEX idx_receipts_organization_id ON receipts (organization_id);
+
+CREATE TABLE receipt_counters (
+    property_id integer,
+    year integer,
+    last_value integer NOT NULL DEFAULT 0,
+    PRIMARY KEY (property_id, year)
+);
+
+CREATE TABLE leases (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    tenant_id integer NOT NULL,
+    room_id integer NOT NULL,
+    start_date datetime NOT NULL,
+    end_date datetime NOT NULL,
+    term_months integer NOT NULL,
+    rent numeric(18,2) NOT NULL,
+    billing_cycle text NOT NULL DEFAULT 'monthly',
+    deposit numeric(18,2) NOT NULL DEFAULT '0',
+    notice_days integer NOT NULL DEFAULT 0,
+    status text NOT NULL DEFAULT 'active',
+    terminated_at datetime,
+    termination_reason text,
+    renewed_from_id integer,
+    created_at datetime,
+    updated_at datetime,
+    CONSTRAINT fk_leases_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id),
+    CONSTRAINT fk_leases_room FOREIGN KEY (room_id) REFERENCES rooms(id)
+);
+CREATE INDEX idx_leases_end_date ON leases (end_date);
+CREATE INDEX idx_leases_room_id ON leases (room_id);
+CREATE INDEX idx_leases_tenant_id ON leases (tenant_id);
+CREATE INDEX idx_leases_organization_id ON leases (organization_id);
+
+CREATE TABLE occupancies (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    room_id integer NOT NULL,
+    tenant_id integer NOT NULL,
+    start_date datetime NOT NULL,
+    end_date datetime,
+    reason text,
+    created_at datetime,
+    updated_at datetime,
+    CONSTRAINT fk_occupancies_room FOREIGN KEY (room_id) REFERENCES rooms(id),
+    CONSTRAINT fk_occupancies_tenant FOREIGN KEY (tenant_id) REFERENCES tenants(id)
+);
+CREATE INDEX idx_occupancies_tenant_id ON occupancies (tenant_id);
+CREATE INDEX idx_occupancies_room_id ON occupancies (room_id);
+CREATE INDEX idx_occupancies_organization_id ON occupancies (organization_id);
+
+CREATE TABLE audit_entries (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    user_id integer,
+    role text,
+    action text NOT NULL,
+    entity_type text,
+    entity_id integer,
+    ip text,
+    detail text,
+    before text,
+    after text,
+    created_at datetime
+);
+CREATE INDEX idx_audit_entries_entity ON audit_entries (entity_type, entity_id);
+CREATE INDEX idx_audit_entries_action ON audit_entries (action);
+CREATE INDEX idx_audit_entries_user_id ON audit_entries (user_id);
+CREATE INDEX idx_audit_entries_organization_id ON audit_entries (organization_id);
+
+CREATE TABLE invitations (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    organization_id integer,
+    email text NOT NULL,
+    role text NOT NULL,
+    token_hash text NOT NULL,
+    invited_by integer NOT NULL,
+    expires_at datetime NOT NULL,
+    accepted_at datetime,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE UNIQUE INDEX idx_invitations_token_hash ON invitations (token_hash);
+CREATE INDEX idx_invitations_organization_id ON invitations (organization_id);
+
+CREATE TABLE sessions (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer NOT NULL,
+    revoked_at datetime,
+    revoked_reason text,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE INDEX idx_sessions_user_id ON sessions (user_id);
+
+CREATE TABLE refresh_tokens (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    session_id integer NOT NULL,
+    token_hash text NOT NULL,
+    expires_at datetime NOT NULL,
+    used_at datetime,
+    created_at datetime
+);
+CREATE UNIQUE INDEX idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+CREATE INDEX idx_refresh_tokens_session_id ON refresh_tokens (session_id);
+
+CREATE TABLE password_resets (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer NOT NULL,
+    token_hash text NOT NULL,
+    expires_at datetime NOT NULL,
+    used_at datetime,
+    created_at datetime
+);
+CREATE UNIQUE INDEX idx_password_resets_token_hash ON password_resets (token_hash);
+CREATE INDEX idx_password_resets_user_id ON password_resets (user_id);
+
+CREATE TABLE recovery_codes (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer NOT NULL,
+    code_hash text NOT NULL,
+    used_at datetime,
+    created_at datetime
+);
+CREATE INDEX idx_recovery_codes_user_id ON recovery_codes (user_id);
+
+CREATE TABLE login_attempts (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer,
+    email text,
+    ip text,
+    reason text NOT NULL,
+    created_at datetime
+);
+CREATE INDEX idx_login_attempts_email ON login_attempts (email);
+CREATE INDEX idx_login_attempts_user_id ON login_attempts (user_id);
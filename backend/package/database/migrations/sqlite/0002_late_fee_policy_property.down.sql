@@ -0,0 +1,6 @@
+-- Keep one policy per organization, that of its first property.
+DELETE FROM late_fee_policies
+WHERE id NOT IN (SELECT MIN(id) FROM late_fee_policies GROUP BY organization_id);
+
+DROP INDEX idx_late_fee_policies_organization_property;
+ALTER TABLE late_fee_policies DROP COLUMN property_id;
@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"ezkost/internal/usecase"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogMailer is meant for local development: instead of sending mail it
+// appends it to a file, or writes it to the log when no file is given.
+type LogMailer struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewLogMailer(path string) *LogMailer {
+	return &LogMailer{path: path}
+}
+
+func (m *LogMailer) Send(mail usecase.Mail) error {
+	text := fmt.Sprintf("To: %s\nSubject: %s\n\n%s\n", mail.To, mail.Subject, mail.Body)
+	if m.path == "" {
+		log.Printf("Mail not sent (log mailer):\n%s", text)
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(f, "Date: %s\n%s\n", time.Now().Format(time.RFC1123Z), text)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
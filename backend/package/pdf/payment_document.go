@@ -0,0 +1,172 @@
+package pdf
+
+import (
+	"bytes"
+	"ezkost/internal/domain/entity"
+	"ezkost/internal/usecase"
+	"fmt"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Renderer draws receipts and invoices as A4 PDFs using only the core fonts.
+type Renderer struct{}
+
+func NewRenderer() *Renderer {
+	return &Renderer{}
+}
+
+func (r *Renderer) RenderPaymentDocument(doc *usecase.PaymentDocument) ([]byte, error) {
+	p := fpdf.New("P", "mm", "A4", "")
+	p.SetMargins(20, 20, 20)
+	p.SetAutoPageBreak(true, 20)
+	p.AddPage()
+	tr := p.UnicodeTranslatorFromDescriptor("")
+
+	// Letterhead
+	lh := doc.Letterhead
+	p.SetFont("Helvetica", "B", 16)
+	p.CellFormat(0, 8, tr(orDefault(lh.Name, "EZKost")), "", 1, "L", false, 0, "")
+	p.SetFont("Helvetica", "", 9)
+	for _, line := range []string{lh.Address, joinNonEmpty(" | ", lh.Phone, lh.Email)} {
+		if line != "" {
+			p.MultiCell(0, 4.5, tr(line), "", "L", false)
+		}
+	}
+	p.Ln(2)
+	p.Line(20, p.GetY(), 190, p.GetY())
+	p.Ln(6)
+
+	// Title block
+	payment := doc.Payment
+	title := "INVOICE"
+	if doc.Kind == usecase.DocumentReceipt {
+		title = "RECEIPT"
+	}
+	p.SetFont("Helvetica", "B", 14)
+	p.CellFormat(95, 8, title, "", 0, "L", false, 0, "")
+	p.SetFont("Helvetica", "", 10)
+	p.CellFormat(75, 8, "No. "+doc.Number, "", 1, "R", false, 0, "")
+	p.CellFormat(95, 5, "", "", 0, "L", false, 0, "")
+	p.CellFormat(75, 5, "Date: "+doc.IssuedAt.Format("02 Jan 2006"), "", 1, "R", false, 0, "")
+	p.Ln(4)
+
+	// Bill to
+	tenant := payment.Tenant
+	p.SetFont("Helvetica", "B", 10)
+	p.CellFormat(0, 5, "Billed to", "", 1, "L", false, 0, "")
+	p.SetFont("Helvetica", "", 10)
+	p.CellFormat(0, 5, tr(tenant.Name), "", 1, "L", false, 0, "")
+	if tenant.Phone != "" {
+		p.CellFormat(0, 5, tr(tenant.Phone), "", 1, "L", false, 0, "")
+	}
+	if tenant.Room != nil {
+		p.CellFormat(0, 5, tr("Room "+tenant.Room.RoomNumber), "", 1, "L", false, 0, "")
+	}
+	p.CellFormat(0, 5, "Due date: "+payment.DueDate.Format("02 Jan 2006"), "", 1, "L", false, 0, "")
+	p.Ln(4)
+
+	// Charges
+	p.SetFont("Helvetica", "B", 10)
+	p.SetFillColor(235, 235, 235)
+	p.CellFormat(120, 7, "Description", "1", 0, "L", true, 0, "")
+	p.CellFormat(50, 7, "Amount", "1", 1, "R", true, 0, "")
+	p.SetFont("Helvetica", "", 10)
+
+	rent := "Rent"
+	if payment.Period != "" {
+		rent = "Rent " + payment.Period
+	}
+	row(p, tr(rent), formatMoney(payment.Amount))
+	for _, item := range payment.LineItems {
+		desc := orDefault(item.Description, item.Type)
+		if item.Waived {
+			row(p, tr(desc+" (waived)"), "-")
+			continue
+		}
+		row(p, tr(desc), formatMoney(item.Amount))
+	}
+
+	p.SetFont("Helvetica", "B", 10)
+	p.CellFormat(120, 7, "Total", "1", 0, "R", false, 0, "")
+	p.CellFormat(50, 7, formatMoney(payment.TotalDue()), "1", 1, "R", false, 0, "")
+	p.SetFont("Helvetica", "", 10)
+	p.Ln(4)
+
+	// Payments received
+	if len(payment.Transactions) > 0 {
+		p.SetFont("Helvetica", "B", 10)
+		p.CellFormat(0, 6, "Payments received", "", 1, "L", false, 0, "")
+		p.SetFont("Helvetica", "", 10)
+		for _, t := range payment.Transactions {
+			row(p, tr(t.PaidAt.Format("02 Jan 2006")+" - "+orDefault(t.Method, "cash")), formatMoney(t.Amount))
+		}
+		p.Ln(2)
+	}
+
+	p.SetFont("Helvetica", "B", 11)
+	if doc.Kind == usecase.DocumentReceipt {
+		p.CellFormat(0, 7, "PAID IN FULL on "+payment.PaidAt.Format("02 Jan 2006"), "", 1, "L", false, 0, "")
+	} else {
+		p.CellFormat(120, 7, "Balance due", "", 0, "R", false, 0, "")
+		p.CellFormat(50, 7, formatMoney(payment.Balance()), "", 1, "R", false, 0, "")
+	}
+
+	if lh.Footer != "" {
+		p.Ln(8)
+		p.SetFont("Helvetica", "I", 9)
+		p.MultiCell(0, 4.5, tr(lh.Footer), "", "L", false)
+	}
+
+	var buf bytes.Buffer
+	if err := p.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func row(p *fpdf.Fpdf, desc, amount string) {
+	p.CellFormat(120, 7, desc, "1", 0, "L", false, 0, "")
+	p.CellFormat(50, 7, amount, "1", 1, "R", false, 0, "")
+}
+
+// formatMoney prints an amount the Indonesian way, e.g. "IDR 1.500.000,00".
+func formatMoney(m entity.Money) string {
+	s := m.String()
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac := s[:len(s)-3], s[len(s)-2:]
+
+	var grouped []string
+	for len(whole) > 3 {
+		grouped = append([]string{whole[len(whole)-3:]}, grouped...)
+		whole = whole[:len(whole)-3]
+	}
+	grouped = append([]string{whole}, grouped...)
+
+	currency := m.Currency
+	if currency == "" {
+		currency = entity.DefaultCurrency
+	}
+	return fmt.Sprintf("%s %s%s,%s", currency, sign, strings.Join(grouped, "."), frac)
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, v := range values {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, sep)
+}